@@ -22,7 +22,9 @@ type authAttrs struct {
 	_refreshPassword     func() (password string, ok bool)
 	_refreshClientCert   func() (clientCert, clientKey []byte, ok bool)
 	_refreshToken        func() (token string, ok bool)
-	cbmu                 sync.Mutex // prevents refresh callbacks from being called in parallel
+	_authenticator       Authenticator // custom authentication methods
+	_noSessionCookie     bool          // disables session cookie authentication on reconnect
+	cbmu                 sync.Mutex    // prevents refresh callbacks from being called in parallel
 }
 
 func isJWTToken(token string) bool { return strings.HasPrefix(token, "ey") }
@@ -44,6 +46,8 @@ func (c *authAttrs) clone() *authAttrs {
 		_refreshPassword:   c._refreshPassword,
 		_refreshClientCert: c._refreshClientCert,
 		_refreshToken:      c._refreshToken,
+		_authenticator:     c._authenticator,
+		_noSessionCookie:   c._noSessionCookie,
 	}
 }
 
@@ -55,6 +59,10 @@ func (c *authAttrs) cookieAuth() *p.AuthHnd {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
+	if c._noSessionCookie {
+		return nil
+	}
+
 	auth := p.NewAuthHnd(c._logonname)                              // important: for session cookie auth we do need the logonname from JWT auth,
 	auth.AddSessionCookie(c._sessionCookie, c._logonname, clientID) // and for HANA onPrem the final session cookie req needs the logonname as well.
 	return auth
@@ -78,6 +86,11 @@ func (c *authAttrs) authHnd() *p.AuthHnd {
 	if c._password != "" {
 		authHnd.AddBasic(c._username, c._password)
 	}
+	if c._authenticator != nil {
+		for _, method := range c._authenticator.Methods() {
+			authHnd.AddExchange(method, &authExchanger{authenticator: c._authenticator, method: method})
+		}
+	}
 	return authHnd
 }
 
@@ -142,6 +155,9 @@ func (c *authAttrs) invalidateCookie() { c.hasCookie.Store(false) }
 func (c *authAttrs) setCookie(logonname string, sessionCookie []byte) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	if c._noSessionCookie {
+		return
+	}
 	c.hasCookie.Store(true)
 	c._logonname = logonname
 	c._sessionCookie = sessionCookie
@@ -217,3 +233,44 @@ func (c *authAttrs) SetRefreshToken(refreshToken func() (token string, ok bool))
 	defer c.mu.Unlock()
 	c._refreshToken = refreshToken
 }
+
+// Authenticator returns the custom authentication provider of the connector.
+func (c *authAttrs) Authenticator() Authenticator {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c._authenticator
+}
+
+// SetAuthenticator sets the custom authentication provider of the connector.
+func (c *authAttrs) SetAuthenticator(authenticator Authenticator) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c._authenticator = authenticator
+}
+
+// SessionCookieDisabled returns true if session cookie authentication on reconnect is disabled, false otherwise.
+func (c *authAttrs) SessionCookieDisabled() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c._noSessionCookie
+}
+
+/*
+SetSessionCookieDisabled disables or enables session cookie authentication on reconnect.
+
+By default the session cookie returned by the database after a successful authentication (e.g. JWT) is kept by the
+connector and used for subsequent connections, avoiding a full authentication round trip. In case the session cookie
+is rejected the connector falls back to a full authentication. For security sensitive deployments the session cookie
+authentication can be disabled, so that every connection is authenticated via the configured authentication methods.
+Disabling drops an already captured session cookie.
+*/
+func (c *authAttrs) SetSessionCookieDisabled(disabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c._noSessionCookie = disabled
+	if disabled {
+		c.hasCookie.Store(false)
+		c._logonname = ""
+		c._sessionCookie = nil
+	}
+}
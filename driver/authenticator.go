@@ -0,0 +1,78 @@
+package driver
+
+import (
+	"fmt"
+
+	"github.com/SAP/go-hdb/driver/internal/protocol/auth"
+)
+
+// AuthInitRequest is the client data of the initial authentication request for an Authenticator method.
+type AuthInitRequest struct {
+	Data []byte
+}
+
+// AuthInitReply is the server data of the initial authentication reply for the Authenticator method selected by the database server.
+type AuthInitReply struct {
+	Method string
+	Data   []byte
+}
+
+// AuthFinalRequest is the client data of the final authentication request for the selected Authenticator method.
+type AuthFinalRequest struct {
+	Logonname string
+	Data      []byte
+}
+
+/*
+An Authenticator provides custom authentication methods (e.g. single sign-on schemes supported by the database server).
+
+The authentication is a two step handshake:
+  - the initial request does contain the client data of all methods returned by Methods
+  - the database server selects one method and returns the method specific server data
+  - the final request does contain the logon name and the client data for the selected method
+
+The method specific data is opaque to the driver. In case a method is using sub parameters the data needs to contain
+the encoded sub parameters. The built-in SCRAMSHA256 basic authentication method is implemented on the same data exchange
+and can be used as reference.
+
+Authenticator methods are offered in addition to the authentication methods configured by the connector attributes and
+replace built-in methods of the same type.
+*/
+type Authenticator interface {
+	// Methods returns the authentication method types provided by the authenticator.
+	Methods() []string
+	// InitialRequest returns the client data of the initial authentication request for method.
+	InitialRequest(method string) (*AuthInitRequest, error)
+	// FinalRequest returns the client data of the final authentication request.
+	FinalRequest(reply *AuthInitReply) (*AuthFinalRequest, error)
+}
+
+// authExchanger implements the auth.Exchanger interface for an Authenticator method.
+type authExchanger struct {
+	authenticator Authenticator
+	method        string
+}
+
+var _ auth.Exchanger = (*authExchanger)(nil)
+
+func (e *authExchanger) InitData() ([]byte, error) {
+	req, err := e.authenticator.InitialRequest(e.method)
+	if err != nil {
+		return nil, err
+	}
+	if req == nil {
+		return nil, fmt.Errorf("authenticator method %s: missing initial request", e.method)
+	}
+	return req.Data, nil
+}
+
+func (e *authExchanger) FinalData(initReply []byte) (string, []byte, error) {
+	req, err := e.authenticator.FinalRequest(&AuthInitReply{Method: e.method, Data: initReply})
+	if err != nil {
+		return "", nil, err
+	}
+	if req == nil {
+		return "", nil, fmt.Errorf("authenticator method %s: missing final request", e.method)
+	}
+	return req.Logonname, req.Data, nil
+}
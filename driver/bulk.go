@@ -0,0 +1,48 @@
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+)
+
+/*
+BulkInsert executes the prepared statement stmt (e.g. an insert statement) for all rows.
+
+The rows are sent to the database in packages of at most bulk size rows (see SetBulkSize). Packages exceeding the
+maximum protocol message size are split automatically. The number of affected rows of all packages is aggregated.
+In case of database errors the input rows which failed can be determined by BulkFailedRows.
+
+Like for all bulk statements the operation is not 'atomic': in auto-commit mode the packages sent before the failing
+one are committed.
+*/
+func BulkInsert(ctx context.Context, stmt *sql.Stmt, rows [][]any) (sql.Result, error) {
+	return BulkInsertSeq(ctx, stmt, func(yield func([]any, error) bool) {
+		for _, row := range rows {
+			if !yield(row, nil) {
+				return
+			}
+		}
+	})
+}
+
+/*
+BulkInsertSeq executes the prepared statement stmt (e.g. an insert statement) for all rows provided by the iterator
+function seq like BulkInsert. The rows are pulled package by package, so that the rows do not need to be materialized
+at once. In case seq yields an error, the rows collected but not yet sent are discarded and the error is returned.
+*/
+func BulkInsertSeq(ctx context.Context, stmt *sql.Stmt, seq func(yield func([]any, error) bool)) (sql.Result, error) {
+	return stmt.ExecContext(ctx, seq)
+}
+
+// BulkFailedRows returns the indexes of the input rows of a bulk statement which failed with the database errors contained in err (warnings excluded).
+func BulkFailedRows(err error) []int {
+	var rows []int
+	for _, dbErr := range AllErrors(err) {
+		if !dbErr.IsWarning() {
+			rows = append(rows, dbErr.StmtNo())
+		}
+	}
+	slices.Sort(rows)
+	return slices.Compact(rows)
+}
@@ -5,8 +5,11 @@ package driver
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"errors"
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 	"testing"
 )
@@ -58,6 +61,9 @@ func testBulkInsertDuplicates(t *testing.T, ctr *Connector, db *sql.DB) {
 	if !errors.As(err, &hdbErr) {
 		t.Fatal("driver.Error expected")
 	}
+	if !IsUniqueConstraintViolation(err) {
+		t.Fatalf("error %v - expected unique constraint violation", err)
+	}
 
 	// expect 3 errors for statement 1,2 and 3
 	if hdbErr.NumError() != 3 {
@@ -74,6 +80,94 @@ func testBulkInsertDuplicates(t *testing.T, ctr *Connector, db *sql.DB) {
 	}
 }
 
+func testBulkExecResult(t *testing.T, ctr *Connector, db *sql.DB) {
+	ctx := context.Background()
+
+	table := RandomIdentifier("bulkExecResult")
+
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("create table %s (k integer primary key, v integer)", table)); err != nil {
+		t.Fatalf("create table failed: %s", err)
+	}
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("insert into %s values (1, 1)", table)); err != nil {
+		t.Fatal(err)
+	}
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	// insert 3 rows (ids: 0,1,2) with 1 duplicate (id: 1)
+	if err := conn.Raw(func(driverConn any) error {
+		stmt, err := driverConn.(driver.ConnPrepareContext).PrepareContext(ctx, fmt.Sprintf("insert into %s values (?,?)", table))
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+
+		args := []driver.NamedValue{{Ordinal: 1, Value: 0}, {Ordinal: 2, Value: 0}, {Ordinal: 1, Value: 1}, {Ordinal: 2, Value: 1}, {Ordinal: 1, Value: 2}, {Ordinal: 2, Value: 2}}
+		result, err := stmt.(driver.StmtExecContext).ExecContext(ctx, args)
+		if err == nil {
+			t.Fatal("error duplicate key expected")
+		}
+		execResult, ok := result.(ExecResult)
+		if !ok {
+			t.Fatalf("result type %T - expected %T", result, execResult)
+		}
+		if fc := execResult.FunctionCode(); fc != FcInsert {
+			t.Fatalf("function code %s - expected %s", fc, FcInsert)
+		}
+		if failed := execResult.FailedStmts(); !slices.Equal(failed, []int{1}) {
+			t.Fatalf("failed statements %v - expected %v", failed, []int{1})
+		}
+		if rows, _ := execResult.RowsAffected(); rows != 2 {
+			t.Fatalf("rows affected %d - expected %d", rows, 2)
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func testBulkInsertHelper(t *testing.T, ctr *Connector, db *sql.DB) {
+	ctx := context.Background()
+
+	table := RandomIdentifier("bulkInsertHelper")
+
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("create table %s (k integer primary key, v integer)", table)); err != nil {
+		t.Fatalf("create table failed: %s", err)
+	}
+
+	stmt, err := db.PrepareContext(ctx, fmt.Sprintf("insert into %s values (?,?)", table))
+	if err != nil {
+		t.Fatalf("prepare bulk insert failed: %s", err)
+	}
+	defer stmt.Close()
+
+	numRow := ctr.BulkSize() + 10 // more than one package
+	rows := make([][]any, numRow)
+	for i := range rows {
+		rows[i] = []any{i, i}
+	}
+	result, err := BulkInsert(ctx, stmt, rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, _ := result.RowsAffected(); n != int64(numRow) {
+		t.Fatalf("rows affected %d - expected %d", n, numRow)
+	}
+
+	// insert duplicates (ids: 0, 1) and new rows
+	_, err = BulkInsert(ctx, stmt, [][]any{{numRow, numRow}, {0, 0}, {numRow + 1, numRow + 1}, {1, 1}})
+	if err == nil {
+		t.Fatal("error duplicate key expected")
+	}
+	if failed := BulkFailedRows(err); !slices.Equal(failed, []int{1, 3}) {
+		t.Fatalf("failed rows %v - expected %v", failed, []int{1, 3})
+	}
+}
+
 // TestBulkInsertStmtNo.
 func testBulkInsertStmtNo(t *testing.T, ctr *Connector, db *sql.DB) {
 	ctx := context.Background()
@@ -384,6 +478,73 @@ func testBulkGeo(t *testing.T, ctr *Connector, db *sql.DB) {
 	}
 }
 
+func testBulkInsertSeq(t *testing.T, ctr *Connector, db *sql.DB) {
+	const numRow = 100000
+
+	ctx := context.Background()
+	bulkSize := ctr.BulkSize()
+
+	table := RandomIdentifier("bulkInsertSeq")
+
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("create table %s (i integer, s varchar(10))", table)); err != nil {
+		t.Fatalf("create table failed: %s", err)
+	}
+
+	stmt, err := db.PrepareContext(ctx, fmt.Sprintf("insert into %s values (?,?)", table))
+	if err != nil {
+		t.Fatalf("prepare bulk insert failed: %s", err)
+	}
+	defer stmt.Close()
+
+	count := func() int {
+		var n int
+		if err := db.QueryRowContext(ctx, fmt.Sprintf("select count(*) from %s", table)).Scan(&n); err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+
+	// rows are produced lazily by the iterator
+	seq := func(yield func([]any) bool) {
+		for i := 0; i < numRow; i++ {
+			if !yield([]any{i, strconv.Itoa(i)}) {
+				return
+			}
+		}
+	}
+	r, err := stmt.ExecContext(ctx, seq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rowsAffected, err := r.RowsAffected()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rowsAffected != numRow {
+		t.Fatalf("rows affected %d - expected %d", rowsAffected, numRow)
+	}
+	if n := count(); n != numRow {
+		t.Fatalf("number of rows %d - expected %d", n, numRow)
+	}
+
+	// producer error: rows of the in-flight batch are discarded
+	errProducer := errors.New("producer error")
+	seq2 := func(yield func([]any, error) bool) {
+		for i := 0; i < bulkSize+bulkSize/2; i++ {
+			if !yield([]any{numRow + i, ""}, nil) {
+				return
+			}
+		}
+		yield(nil, errProducer)
+	}
+	if _, err := stmt.ExecContext(ctx, seq2); !errors.Is(err, errProducer) {
+		t.Fatalf("error %v - expected %v", err, errProducer)
+	}
+	if n := count(); n != numRow+bulkSize {
+		t.Fatalf("number of rows %d - expected %d", n, numRow+bulkSize)
+	}
+}
+
 func TestBulk(t *testing.T) {
 	t.Parallel()
 
@@ -393,6 +554,9 @@ func TestBulk(t *testing.T) {
 	}{
 		{"testBulkInsertDuplicates", testBulkInsertDuplicates},
 		{"testBulkInsertStmtNo", testBulkInsertStmtNo},
+		{"testBulkExecResult", testBulkExecResult},
+		{"testBulkInsertHelper", testBulkInsertHelper},
+		{"testBulkInsertSeq", testBulkInsertSeq},
 		{"testBulkBlob", testBulkBlob},
 		{"testBulkBlob106", testBulkBlob106},
 		{"testBulkGeo", testBulkGeo},
@@ -2,11 +2,15 @@ package driver
 
 import (
 	"database/sql/driver"
+
+	p "github.com/SAP/go-hdb/driver/internal/protocol"
 )
 
 // NullBytes represents an []byte that may be null.
 // NullBytes implements the Scanner interface so
 // it can be used as a scan destination, similar to NullString.
+// Besides binary fields NullBytes can be used to scan Lob fields, distinguishing
+// NULL Lobs (Valid false) from empty Lobs (Valid true, Bytes of length zero).
 type NullBytes struct {
 	Bytes []byte
 	Valid bool // Valid is true if Bytes is not NULL
@@ -14,6 +18,14 @@ type NullBytes struct {
 
 // Scan implements the Scanner interface.
 func (n *NullBytes) Scan(value any) error {
+	if _, ok := value.(p.LobScanner); ok {
+		if err := ScanLobBytes(value, &n.Bytes); err != nil {
+			n.Bytes, n.Valid = nil, false
+			return err
+		}
+		n.Valid = true
+		return nil
+	}
 	n.Bytes, n.Valid = value.([]byte)
 	return nil
 }
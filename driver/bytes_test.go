@@ -0,0 +1,41 @@
+package driver
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	p "github.com/SAP/go-hdb/driver/internal/protocol"
+)
+
+func TestNullBytesScanLob(t *testing.T) {
+	lob := func(b []byte) *p.LobOutDescr {
+		descr := &p.LobOutDescr{Opt: 0x04, B: b} // last data
+		descr.SetDecoder(func(descr *p.LobOutDescr, wr io.Writer) error {
+			_, err := wr.Write(descr.B)
+			return err
+		})
+		return descr
+	}
+
+	testData := []struct {
+		src   any
+		bytes []byte
+		valid bool
+	}{
+		{nil, nil, false},                         // NULL lob
+		{lob(nil), []byte{}, true},                // empty lob
+		{lob([]byte("lob")), []byte("lob"), true}, // lob
+		{[]byte("bytes"), []byte("bytes"), true},  // binary field
+	}
+
+	for i, d := range testData {
+		var nb NullBytes
+		if err := nb.Scan(d.src); err != nil {
+			t.Fatal(err)
+		}
+		if nb.Valid != d.valid || !bytes.Equal(nb.Bytes, d.bytes) || (nb.Bytes == nil) != (d.bytes == nil) {
+			t.Fatalf("%d: scanned %v %t - expected %v %t", i, nb.Bytes, nb.Valid, d.bytes, d.valid)
+		}
+	}
+}
@@ -46,6 +46,14 @@ end
 		}
 	}
 
+	testExecMixedPrms := func() { // exec - named and positional arguments mixed
+		if _, err := db.Exec(fmt.Sprintf("call %s(?, ?)", proc), txt, sql.Named("ODATA", sql.Out{Dest: &out})); err != nil {
+			t.Log(err)
+		} else {
+			t.Fatal("should return mixed arguments error")
+		}
+	}
+
 	testExecRndPrms := func() { // exec random parameters - switch input / output argument (test named parameters)
 		if _, err := db.Exec(fmt.Sprintf("call %s(?, ?)", proc), sql.Named("ODATA", sql.Out{Dest: &out}), sql.Named("IDATA", txt)); err != nil {
 			t.Fatal(err)
@@ -60,6 +68,7 @@ end
 		fct  func()
 	}{
 		{"ExecInvNamedPrm", testExecInvNamedPrm},
+		{"ExecMixedPrms", testExecMixedPrms},
 		{"Exec", testExec},
 		{"ExecRndPrms", testExecRndPrms},
 	}
@@ -208,6 +217,64 @@ end
 	check(data[2], &resultRows3)
 }
 
+func testCallNullTableOut(t *testing.T, db *sql.DB) {
+	const procNullTableOut = `create procedure %[1]s (in i integer, out t1 %[2]s, out t2 %[2]s)
+language SQLSCRIPT as
+begin
+  t1 = select 0 as i, '' as x from dummy where 1 = 0;
+  if :i > 0 then
+    t2 = select :i as i, 'A' as x from dummy;
+  end if;
+end
+`
+	tableType := driver.RandomIdentifier("tableType_")
+	proc := driver.RandomIdentifier("procNullTableOut_")
+
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, fmt.Sprintf("create type %s as table (i integer, x varchar(10))", tableType)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := conn.ExecContext(ctx, fmt.Sprintf(procNullTableOut, proc, tableType)); err != nil {
+		t.Fatal(err)
+	}
+
+	stmt, err := conn.PrepareContext(ctx, fmt.Sprintf("call %s(?, ?, ?)", proc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stmt.Close()
+
+	count := func(rows *sql.Rows) int {
+		n := 0
+		for rows.Next() {
+			n++
+		}
+		if err := rows.Err(); err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+
+	for _, i := range []int{0, 1} {
+		var resultRows1, resultRows2 sql.Rows
+		if _, err := stmt.Exec(i, sql.Named("T1", sql.Out{Dest: &resultRows1}), sql.Named("T2", sql.Out{Dest: &resultRows2})); err != nil {
+			t.Fatal(err)
+		}
+		if n := count(&resultRows1); n != 0 {
+			t.Fatalf("invalid number of records %d - expected %d", n, 0)
+		}
+		if n := count(&resultRows2); n != i {
+			t.Fatalf("invalid number of records %d - expected %d", n, i)
+		}
+	}
+}
+
 func testCallNoPrm(t *testing.T, db *sql.DB) {
 	const procNoPrm = `create procedure %[1]s
 language SQLSCRIPT as
@@ -284,6 +351,7 @@ func TestCall(t *testing.T) {
 		{"echo", testCallEcho},
 		{"blobEcho", testCallBlobEcho},
 		{"tableOut", testCallTableOut},
+		{"nullTableOut", testCallNullTableOut},
 		{"noPrm", testCallNoPrm},
 		{"noOut", testCallNoOut},
 	}
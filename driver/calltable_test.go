@@ -0,0 +1,19 @@
+package driver
+
+import (
+	"database/sql/driver"
+	"io"
+	"testing"
+)
+
+func TestNullTableOutputParameter(t *testing.T) {
+	// table output parameter without resultset provided by the database
+	qr := &queryResult{}
+
+	if err := qr.Next([]driver.Value{}); err != io.EOF {
+		t.Fatalf("got error %v - expected %v", err, io.EOF)
+	}
+	if err := qr.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,151 @@
+package driver
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/SAP/go-hdb/driver/dial"
+)
+
+func TestDrainCancelled(t *testing.T) {
+	t.Parallel()
+
+	errCancel := errors.New("statement cancelled")
+
+	errCancelCmd := errors.New("cancel command failed")
+
+	tests := []struct {
+		name        string
+		delay       time.Duration
+		err         error
+		cancelDelay time.Duration
+		cancelErr   error
+		drained     bool
+	}{
+		{"dbError", 10 * time.Millisecond, errCancel, 0, nil, true},
+		{"success", 10 * time.Millisecond, nil, 0, nil, false},
+		{"badConn", 10 * time.Millisecond, fmt.Errorf("read: %w", driver.ErrBadConn), 0, nil, false},
+		{"timeout", time.Second, errCancel, 0, nil, false},
+		// db call finished before the cancel command: wait for the cancel command.
+		{"lateCancel", 0, errCancel, 50 * time.Millisecond, nil, true},
+		{"cancelError", 10 * time.Millisecond, errCancel, 0, errCancelCmd, false},
+		{"cancelTimeout", 10 * time.Millisecond, errCancel, time.Second, nil, false},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			done := make(chan struct{})
+			var err error
+			go func() { // simulate slow db call
+				time.Sleep(test.delay)
+				err = test.err
+				close(done)
+			}()
+
+			cancelErr := make(chan error, 1)
+			go func() { // simulate cancel command
+				time.Sleep(test.cancelDelay)
+				cancelErr <- test.cancelErr
+			}()
+
+			if drained := drainCancelled(done, &err, cancelErr, 100*time.Millisecond); drained != test.drained {
+				t.Fatalf("drained %t - expected %t", drained, test.drained)
+			}
+			<-done
+		})
+	}
+}
+
+func TestCancelSessionTimeout(t *testing.T) {
+	t.Parallel()
+
+	connector := NewBasicAuthConnector("hanahost:30015", "user", "password")
+	connector.SetTimeout(0) // no read timeout
+	connector.SetDialer(dial.DialerFunc(func(ctx context.Context, network, address string) (net.Conn, error) {
+		client, server := net.Pipe()
+		go func() { // fake server not answering.
+			defer server.Close()
+			io.Copy(io.Discard, server) //nolint:errcheck
+		}()
+		return client, nil
+	}))
+
+	attrs := connector.connAttrs.clone()
+	c := &conn{
+		attrs:  attrs,
+		host:   "hanahost:30015",
+		logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+		hostConnect: func(ctx context.Context, host string, attrs *connAttrs) (driver.Conn, error) {
+			return connect(ctx, host, connector.metrics, attrs, connector.authAttrs)
+		},
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- c.cancelSessionID(1) }()
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatal("cancel session succeeded - expected error")
+		}
+	case <-time.After(cancelDrainTimeout + 5*time.Second):
+		t.Fatal("cancel session via not answering control connection is not bounded")
+	}
+}
+
+func TestWaitDrain(t *testing.T) {
+	t.Parallel()
+
+	c := &conn{}
+	ctx, done := withDBCall(context.Background())
+	defer close(done)
+
+	c.setLastError(errCancelled)
+	drain := &cancelDrain{call: done, done: make(chan struct{})}
+	c.drain = drain
+
+	wait := func(ctx context.Context) <-chan struct{} {
+		waited := make(chan struct{})
+		go func() {
+			c.waitDrain(ctx)
+			close(waited)
+		}()
+		return waited
+	}
+
+	// the cancelled db call does not wait for its own drain.
+	select {
+	case <-wait(ctx):
+	case <-time.After(time.Second):
+		t.Fatal("cancelled db call waits for its own drain")
+	}
+
+	// other requests wait for the drain.
+	waited := []<-chan struct{}{wait(context.Background()), wait(context.Background())}
+	if !c.drainPending() {
+		t.Fatal("drain is not pending")
+	}
+	select {
+	case <-waited[0]:
+		t.Fatal("request does not wait for the drain")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	drain.ok = true
+	close(drain.done)
+	for _, waited := range waited {
+		<-waited
+	}
+	if c.isBad() || c.cancelDrain() != nil {
+		t.Fatal("connection is not reset after successful drain")
+	}
+}
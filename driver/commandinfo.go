@@ -0,0 +1,80 @@
+package driver
+
+import (
+	"context"
+	"runtime"
+	"strings"
+
+	p "github.com/SAP/go-hdb/driver/internal/protocol"
+)
+
+// commandInfoCtxKey is the context key of a command info.
+type commandInfoCtxKey struct{}
+
+type commandInfo struct {
+	sourceModule string
+	lineNumber   int
+}
+
+/*
+WithCommandInfo returns a copy of ctx associated with the source module and line number of the issuing application code.
+Statements executed or prepared with the returned context are tagged with this information, which is shown
+by the database server monitoring views. A command info provided by the context takes precedence over the
+caller information collected in case the connector CommandInfo flag is set.
+*/
+func WithCommandInfo(ctx context.Context, sourceModule string, lineNumber int) context.Context {
+	return context.WithValue(ctx, commandInfoCtxKey{}, commandInfo{sourceModule: sourceModule, lineNumber: lineNumber})
+}
+
+// skipCallerPrefixes are the function name prefixes of callers which are not application code.
+var skipCallerPrefixes = []string{"database/sql.", "github.com/SAP/go-hdb/driver.", "runtime."}
+
+// callerCommandInfo returns the command info of the first caller outside of database/sql and this driver.
+func callerCommandInfo() (commandInfo, bool) {
+	pc := make([]uintptr, 32)
+	n := runtime.Callers(2, pc)
+	frames := runtime.CallersFrames(pc[:n])
+	for {
+		frame, more := frames.Next()
+		if !isSkipCaller(frame.Function) {
+			return commandInfo{sourceModule: frame.File, lineNumber: frame.Line}, true
+		}
+		if !more {
+			return commandInfo{}, false
+		}
+	}
+}
+
+func isSkipCaller(function string) bool {
+	for _, prefix := range skipCallerPrefixes {
+		if strings.HasPrefix(function, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// commandInfo returns the command info part for a statement or nil if command info is not requested.
+func (c *conn) commandInfo(ctx context.Context) *p.CommandInfo {
+	info, ok := ctx.Value(commandInfoCtxKey{}).(commandInfo)
+	if !ok {
+		if !c.attrs._commandInfo {
+			return nil
+		}
+		if info, ok = callerCommandInfo(); !ok {
+			return nil
+		}
+	}
+	ci := &p.CommandInfo{}
+	ci.SetSourceModule(info.sourceModule)
+	ci.SetLineNumber(info.lineNumber)
+	return ci
+}
+
+// writeCommand writes a request message of type mt including the command part of query and the command info part if requested.
+func (c *conn) writeCommand(ctx context.Context, mt p.MessageType, commit bool, query string) error {
+	if ci := c.commandInfo(ctx); ci != nil {
+		return c.pw.Write(ctx, c.sessionID, mt, commit, p.Command(query), ci)
+	}
+	return c.pw.Write(ctx, c.sessionID, mt, commit, p.Command(query))
+}
@@ -0,0 +1,37 @@
+package driver
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCommandInfo(t *testing.T) {
+	ctx := context.Background()
+
+	attrs := newConnAttrs()
+	c := &conn{attrs: attrs}
+
+	// not requested
+	if ci := c.commandInfo(ctx); ci != nil {
+		t.Fatalf("command info %v - expected nil", ci)
+	}
+
+	// provided by context
+	ci := c.commandInfo(WithCommandInfo(ctx, "app/main.go", 42))
+	if ci == nil {
+		t.Fatal("command info expected")
+	}
+	if ci.SourceModuleOrZero() != "app/main.go" || ci.LineNumberOrZero() != 42 {
+		t.Fatalf("command info %v - expected source module %s line number %d", ci, "app/main.go", 42)
+	}
+
+	// skip callers of this driver
+	for _, function := range []string{"database/sql.(*DB).QueryContext", "github.com/SAP/go-hdb/driver.(*conn).QueryContext"} {
+		if !isSkipCaller(function) {
+			t.Fatalf("function %s is expected to be skipped", function)
+		}
+	}
+	if isSkipCaller("github.com/SAP/go-hdb/driver_test.ExampleConn") {
+		t.Fatal("function of application code is not expected to be skipped")
+	}
+}
@@ -0,0 +1,55 @@
+//go:build !unit
+
+package driver
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+)
+
+func TestConnectInitSQL(t *testing.T) {
+	t.Parallel()
+
+	t.Run("execute", func(t *testing.T) {
+		t.Parallel()
+
+		connector := MT.NewConnector()
+		// statements are executed in order: the second one overwrites the session variable set by the first one.
+		connector.SetConnectInitSQL([]string{
+			"set 'INIT_SQL_TEST' = 'first'",
+			"set 'INIT_SQL_TEST' = 'second'",
+		})
+		db := sql.OpenDB(connector)
+		defer db.Close()
+
+		var v string
+		if err := db.QueryRow("select session_context('INIT_SQL_TEST') from dummy").Scan(&v); err != nil {
+			t.Fatal(err)
+		}
+		if v != "second" {
+			t.Fatalf("session variable %s - expected %s", v, "second")
+		}
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		t.Parallel()
+
+		connector := MT.NewConnector()
+		connector.SetConnectInitSQL([]string{
+			"set 'INIT_SQL_TEST' = 'first'",
+			"set schema " + RandomIdentifier("schema_").String(), // schema does not exist
+		})
+		db := sql.OpenDB(connector)
+		defer db.Close()
+
+		err := db.Ping()
+		if err == nil {
+			t.Fatal("connect error expected")
+		}
+		var dbErr Error
+		if !errors.As(err, &dbErr) {
+			t.Fatalf("error %v - expected database error", err)
+		}
+	})
+}
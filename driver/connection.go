@@ -13,6 +13,7 @@ import (
 	"log/slog"
 	"net"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -40,6 +41,26 @@ var ErrNestedTransaction = errors.New("nested transactions are not supported")
 // This error can be avoided in whether using a transaction or a dedicated connection (sql.Tx or sql.Conn).
 var ErrNestedQuery = errors.New("nested sql queries are not supported")
 
+// ErrMaxNumArgExceeded is the error raised if the number of arguments of a protocol part (e.g. the rows of
+// a bulk statement) exceeds the protocol maximum.
+var ErrMaxNumArgExceeded = p.ErrMaxNumArgExceeded
+
+// ErrUnsupportedType is the error raised if a value of a data type not supported by the driver (e.g. nested table types)
+// is sent to or received from the database.
+var ErrUnsupportedType = p.ErrUnsupportedType
+
+// ErrConcurrentUse is the error raised if a connection is used concurrently while the concurrent use check
+// is enabled (see SetCheckConcurrentUse).
+var ErrConcurrentUse = p.ErrConcurrentUse
+
+// ErrConnectTimeout is the error raised if the connection handshake (protocol prolog and authentication)
+// does not complete within the handshake timeout (see HandshakeTimeout).
+var ErrConnectTimeout = errors.New("connect timeout: connection handshake not completed in time")
+
+// ErrStandbyNotReady is the error raised if the connected database host is a standby host of a
+// system replication setup and the connector is configured to fail on standby (see FailOnStandby).
+var ErrStandbyNotReady = errors.New("database host is a standby host not ready for connections")
+
 // queries.
 const (
 	dummyQuery                      = "select 1 from dummy"
@@ -49,6 +70,7 @@ const (
 	setAccessModeReadOnly           = "set transaction read only"
 	setAccessModeReadWrite          = "set transaction read write"
 	setDefaultSchema                = "set schema"
+	cancelSession                   = "alter system cancel session"
 )
 
 var (
@@ -56,6 +78,9 @@ var (
 	_ = p.RegisterScanType(p.DtBytes, hdbreflect.TypeFor[[]byte](), hdbreflect.TypeFor[NullBytes]())
 	_ = p.RegisterScanType(p.DtDecimal, hdbreflect.TypeFor[Decimal](), hdbreflect.TypeFor[NullDecimal]())
 	_ = p.RegisterScanType(p.DtLob, hdbreflect.TypeFor[Lob](), hdbreflect.TypeFor[NullLob]())
+	_ = p.RegisterBindType(p.DtBytes, hdbreflect.TypeFor[NullBytes]())
+	_ = p.RegisterBindType(p.DtDecimal, hdbreflect.TypeFor[Decimal](), hdbreflect.TypeFor[NullDecimal]())
+	_ = p.RegisterBindType(p.DtLob, hdbreflect.TypeFor[*Lob](), hdbreflect.TypeFor[NullLob]())
 )
 
 // dbConn wraps the database tcp connection. It sets timeouts and handles driver ErrBadConn behavior.
@@ -66,17 +91,49 @@ type dbConn struct {
 	logger    *slog.Logger
 	lastRead  time.Time
 	lastWrite time.Time
+	// reply timing (server vs. client time)
+	flushed    time.Time     // end of last request write
+	awaitReply bool          // waiting for the first byte of the reply
+	replyStart time.Time     // first reply byte read
+	replyWait  time.Duration // time spent waiting for further reply bytes
+	// deadline of the connection handshake (zero: no handshake deadline)
+	handshakeDeadline time.Time
+	// deadline of a call not bound by a caller context like the keep-alive ping (zero: no call deadline)
+	callDeadline time.Time
 }
 
 func (c *dbConn) deadline() (deadline time.Time) {
-	if c.timeout == 0 {
-		return
+	if c.timeout != 0 {
+		deadline = time.Now().Add(c.timeout)
+	}
+	for _, d := range []time.Time{c.handshakeDeadline, c.callDeadline} {
+		if !d.IsZero() && (deadline.IsZero() || d.Before(deadline)) {
+			deadline = d
+		}
+	}
+	return
+}
+
+// handshakeError wraps err by ErrConnectTimeout in case the handshake deadline is exceeded.
+func (c *dbConn) handshakeError(err error) error {
+	if c.handshakeDeadline.IsZero() || time.Now().Before(c.handshakeDeadline) {
+		return err
 	}
-	return time.Now().Add(c.timeout)
+	return fmt.Errorf("%w: %w", ErrConnectTimeout, err)
 }
 
 func (c *dbConn) close() error { return c.conn.Close() }
 
+// replyDone records the client time of a processed reply, which is the time since the first reply byte
+// was read without the time spent on waiting for further reply bytes.
+func (c *dbConn) replyDone() {
+	if c.replyStart.IsZero() {
+		return
+	}
+	c.collector.msgCh <- timeMsg{idx: timeClient, d: time.Since(c.replyStart) - c.replyWait}
+	c.replyStart = time.Time{}
+}
+
 // Read implements the io.Reader interface.
 func (c *dbConn) Read(b []byte) (int, error) {
 	// set timeout
@@ -85,10 +142,20 @@ func (c *dbConn) Read(b []byte) (int, error) {
 	}
 	c.lastRead = time.Now()
 	n, err := c.conn.Read(b)
-	c.collector.msgCh <- timeMsg{idx: timeRead, d: time.Since(c.lastRead)}
+	d := time.Since(c.lastRead)
+	c.collector.msgCh <- timeMsg{idx: timeRead, d: d}
+	if n > 0 {
+		switch {
+		case c.awaitReply:
+			c.collector.msgCh <- timeMsg{idx: timeServer, d: time.Since(c.flushed)}
+			c.awaitReply, c.replyStart, c.replyWait = false, time.Now(), 0
+		case !c.replyStart.IsZero():
+			c.replyWait += d
+		}
+	}
 	c.collector.msgCh <- counterMsg{idx: counterBytesRead, v: uint64(n)}
 	if err != nil {
-		c.logger.LogAttrs(context.Background(), slog.LevelError, "DB conn read error", slog.String("error", err.Error()), slog.String("local address", c.conn.LocalAddr().String()), slog.String("remote address", c.conn.RemoteAddr().String()))
+		c.logger.LogAttrs(context.Background(), slog.LevelError, "DB conn read error", slog.String("error", err.Error()), slog.Any("local address", c.conn.LocalAddr()), slog.Any("remote address", c.conn.RemoteAddr()))
 		// wrap error in driver.ErrBadConn
 		return n, fmt.Errorf("%w: %w", driver.ErrBadConn, err)
 	}
@@ -103,10 +170,12 @@ func (c *dbConn) Write(b []byte) (int, error) {
 	}
 	c.lastWrite = time.Now()
 	n, err := c.conn.Write(b)
-	c.collector.msgCh <- timeMsg{idx: timeWrite, d: time.Since(c.lastWrite)}
+	c.flushed = time.Now()
+	c.awaitReply, c.replyStart = true, time.Time{}
+	c.collector.msgCh <- timeMsg{idx: timeWrite, d: c.flushed.Sub(c.lastWrite)}
 	c.collector.msgCh <- counterMsg{idx: counterBytesWritten, v: uint64(n)}
 	if err != nil {
-		c.logger.LogAttrs(context.Background(), slog.LevelError, "DB conn write error", slog.String("error", err.Error()), slog.String("local address", c.conn.LocalAddr().String()), slog.String("remote address", c.conn.RemoteAddr().String()))
+		c.logger.LogAttrs(context.Background(), slog.LevelError, "DB conn write error", slog.String("error", err.Error()), slog.Any("local address", c.conn.LocalAddr()), slog.Any("remote address", c.conn.RemoteAddr()))
 		// wrap error in driver.ErrBadConn
 		return n, fmt.Errorf("%w: %w", driver.ErrBadConn, err)
 	}
@@ -138,11 +207,37 @@ const (
 
 var errCancelled = fmt.Errorf("%w: %w", driver.ErrBadConn, errors.New("db call cancelled"))
 
+// cancelDrainTimeout is the maximum time to wait for the reply of a db call cancelled on the database server.
+const cancelDrainTimeout = 10 * time.Second
+
+// dbCallCtxKey is the context key of the done channel of a db call running in a separate goroutine (see waitDrain).
+type dbCallCtxKey struct{}
+
+// withDBCall returns a context identifying the db call by the returned done channel.
+func withDBCall(ctx context.Context) (context.Context, chan struct{}) {
+	done := make(chan struct{})
+	return context.WithValue(ctx, dbCallCtxKey{}, (<-chan struct{})(done)), done
+}
+
+func dbCallFromContext(ctx context.Context) <-chan struct{} {
+	done, _ := ctx.Value(dbCallCtxKey{}).(<-chan struct{})
+	return done
+}
+
 // Conn enhances a connection with go-hdb specific connection functions.
 type Conn interface {
 	HDBVersion() *Version
 	DatabaseName() string
 	DBConnectInfo(ctx context.Context, databaseName string) (*DBConnectInfo, error)
+	BytesPerRow() float64
+	ProtocolVersion() (major, minor int)
+	ExecPipeline(ctx context.Context, queries ...string) ([]PipelineResult, error)
+	Topology() []TopologyHost
+	SetSessionVariable(ctx context.Context, key, value string) error
+	SessionVariable(ctx context.Context, key string) (string, error)
+	Warnings() []DBError
+	TransactionID() (int64, bool)
+	Stats() *Stats
 }
 
 var stdConnTracker = &connTracker{}
@@ -181,6 +276,7 @@ func (t *connTracker) callDB() *sql.DB {
 // Conn is the implementation of the database/sql/driver Conn interface.
 type conn struct {
 	attrs     *connAttrs
+	metrics   *metrics // connection metrics (rolled up into the parent metrics)
 	collector *metricsCollector
 
 	sqlTrace bool
@@ -189,16 +285,34 @@ type conn struct {
 	dbConn *dbConn
 
 	wg        sync.WaitGroup // wait for concurrent db calls when closing connections
+	lobMu     sync.Mutex     // lob read round trips
 	inTx      bool           // in transaction
+	txID      int64          // transaction id (0 if not determined yet)
+	errMu     sync.Mutex     // lastError and drain (accessed by cancelled db calls still running)
 	lastError error          // last error
 	sessionID int64
 
+	lobPrefetches sync.WaitGroup // running lob prefetches: requests other than lob reads wait for them
+
 	serverOptions *p.ConnectOptions
+	topology      []TopologyHost // topology provided by the database on connect
 	hdbVersion    *Version
 	fieldTypeCtx  *p.FieldTypeCtx
 
+	rowSize   rowSizeEstimate
+	fetchSize adaptiveFetchSize // fetch size adapted to memory pressure
+	warnings  *warnings         // retained database warnings
+	stmtCache *stmtCache        // prepared statement cache (nil if disabled)
+	keepAlive *keepAlive        // keep-alive pings of idle connection (nil if disabled)
+
 	pr *p.Reader
 	pw *p.Writer
+
+	host        string                                                                        // database host of the connection
+	hostConnect func(ctx context.Context, host string, attrs *connAttrs) (driver.Conn, error) // opens a further connection (see cancelSession, replica)
+	drain       *cancelDrain                                                                  // background drain of a cancelled db call (nil if none)
+	replica     *conn                                                                         // read-only replica connection (see WithReadOnlyRouting)
+	isReplica   bool                                                                          // connection is a read-only replica connection
 }
 
 // isAuthError returns true in case of X509 certificate validation errrors or hdb authentication errors, else otherwise.
@@ -214,10 +328,14 @@ func isAuthError(err error) bool {
 	return hdbErrors.Code() == p.HdbErrAuthenticationFailed
 }
 
-func connect(ctx context.Context, host string, metrics *metrics, connAttrs *connAttrs, authAttrs *authAttrs) (driver.Conn, error) {
+func connect(ctx context.Context, host string, metrics *metrics, attrs *connAttrs, authAttrs *authAttrs) (driver.Conn, error) {
+	hostConnect := func(ctx context.Context, host string, hostAttrs *connAttrs) (driver.Conn, error) {
+		return connect(ctx, host, metrics, hostAttrs, authAttrs)
+	}
+
 	// can we connect via cookie?
 	if auth := authAttrs.cookieAuth(); auth != nil {
-		conn, err := newSession(ctx, host, metrics, connAttrs, auth)
+		conn, err := newSession(ctx, host, metrics, attrs, auth, hostConnect)
 		if err == nil {
 			return conn, nil
 		}
@@ -231,7 +349,7 @@ func connect(ctx context.Context, host string, metrics *metrics, connAttrs *conn
 	for {
 		authHnd := authAttrs.authHnd()
 
-		conn, err := newSession(ctx, host, metrics, connAttrs, authHnd)
+		conn, err := newSession(ctx, host, metrics, attrs, authHnd, hostConnect)
 		if err == nil {
 			if method, ok := authHnd.Selected().(auth.CookieGetter); ok {
 				authAttrs.setCookie(method.Cookie())
@@ -255,8 +373,11 @@ func connect(ctx context.Context, host string, metrics *metrics, connAttrs *conn
 }
 
 var (
-	protTrace atomic.Bool
-	sqlTrace  atomic.Bool
+	protTrace           atomic.Bool
+	protTraceRedact     atomic.Bool
+	protTraceStructured atomic.Bool
+	protTraceOmitValues atomic.Bool
+	sqlTrace            atomic.Bool
 )
 
 func init() {
@@ -268,6 +389,9 @@ func init() {
 		return err
 	}
 	flag.BoolFunc("hdb.protTrace", "enabling hdb protocol trace", func(s string) error { return setTrace(&protTrace, s) })
+	flag.BoolFunc("hdb.protTraceRedact", "masking credentials and parameter values in hdb protocol trace", func(s string) error { return setTrace(&protTraceRedact, s) })
+	flag.BoolFunc("hdb.protTraceStructured", "tracing hdb protocol parts as structured attributes (JSON in case of a trace writer)", func(s string) error { return setTrace(&protTraceStructured, s) })
+	flag.BoolFunc("hdb.protTraceOmitValues", "omitting part content like field values in structured hdb protocol trace", func(s string) error { return setTrace(&protTraceOmitValues, s) })
 	flag.BoolFunc("hdb.sqlTrace", "enabling hdb sql trace", func(s string) error { return setTrace(&sqlTrace, s) })
 }
 
@@ -277,11 +401,54 @@ func SQLTrace() bool { return sqlTrace.Load() }
 // SetSQLTrace sets sql tracing output active or inactive.
 func SetSQLTrace(on bool) { sqlTrace.Store(on) }
 
+// ProtTraceRedact returns true if credentials and parameter values are masked in protocol traces, false otherwise.
+func ProtTraceRedact() bool { return protTraceRedact.Load() }
+
+// SetProtTraceRedact sets the masking of credentials and parameter values in protocol traces active or inactive.
+// Part and segment header information is not affected.
+func SetProtTraceRedact(on bool) { protTraceRedact.Store(on) }
+
+// ProtTraceStructured returns true if protocol parts are traced as structured attributes, false otherwise.
+func ProtTraceStructured() bool { return protTraceStructured.Load() }
+
+// SetProtTraceStructured sets the tracing of protocol parts as structured attributes (kind, numArg, bufferLength
+// and field values) active or inactive. In case a protocol trace writer is set, trace records are written in JSON format.
+func SetProtTraceStructured(on bool) { protTraceStructured.Store(on) }
+
+// ProtTraceOmitValues returns true if part content like field values is omitted in structured protocol traces, false otherwise.
+func ProtTraceOmitValues() bool { return protTraceOmitValues.Load() }
+
+// SetProtTraceOmitValues sets the omission of part content like field values in structured protocol traces active or inactive.
+func SetProtTraceOmitValues(on bool) { protTraceOmitValues.Store(on) }
+
+func protTraceMode() p.TraceMode {
+	switch {
+	case !protTraceStructured.Load():
+		return p.TraceText
+	case protTraceOmitValues.Load():
+		return p.TraceStructured
+	default:
+		return p.TraceStructuredValues
+	}
+}
+
 // unique connection number.
 var connNo atomic.Uint64
 
+// newConnMetrics returns the metrics of a single connection rolled up into parent.
+func newConnMetrics(parent *metrics) *metrics {
+	return newMetrics(parent, statsCfg.TimeUnit, statsCfg.TimeUpperBounds, statsCfg.SQLTimeUpperBounds)
+}
+
 func newConn(ctx context.Context, host string, metrics *metrics, attrs *connAttrs) (*conn, error) {
-	netConn, err := attrs._dialer.DialContext(ctx, host, dial.DialerOptions{Timeout: attrs._timeout, TCPKeepAlive: attrs._tcpKeepAlive})
+	dialer := attrs._dialer
+	if attrs._proxyURL != nil {
+		var err error
+		if dialer, err = dial.NewProxyDialer(attrs._proxyURL, dialer); err != nil {
+			return nil, err
+		}
+	}
+	netConn, err := dialer.DialContext(ctx, host, dial.DialerOptions{Timeout: attrs._timeout, TCPKeepAlive: attrs._tcpKeepAlive})
 	if err != nil {
 		return nil, err
 	}
@@ -293,35 +460,71 @@ func newConn(ctx context.Context, host string, metrics *metrics, attrs *connAttr
 
 	logger := attrs._logger.With(slog.Uint64("conn", connNo.Add(1)))
 
+	metrics = newConnMetrics(metrics)
 	collector := newMetricsCollector(metrics)
 
 	dbConn := &dbConn{collector: collector, conn: netConn, timeout: attrs._timeout, logger: logger}
+	if attrs._handshakeTimeout != 0 {
+		dbConn.handshakeDeadline = time.Now().Add(attrs._handshakeTimeout)
+	}
 	// buffer connection
 	rw := bufio.NewReadWriter(bufio.NewReaderSize(dbConn, attrs._bufferSize), bufio.NewWriterSize(dbConn, attrs._bufferSize))
 
-	protTrace := protTrace.Load()
+	protTrace, protTraceRedact := protTrace.Load(), protTraceRedact.Load()
 
 	c := &conn{
 		attrs:     attrs,
+		metrics:   metrics,
 		collector: collector,
 		dbConn:    dbConn,
 		sqlTrace:  sqlTrace.Load(),
 		logger:    logger,
-		pw:        p.NewWriter(rw.Writer, protTrace, logger, attrs._cesu8Encoder, attrs._sessionVariables), // write upstream
-		pr:        p.NewDBReader(rw.Reader, protTrace, logger, attrs._cesu8Decoder),                        // read downstream
+		pw:        p.NewWriter(rw.Writer, protTrace, protTraceRedact, logger, attrs._protTraceWriter, attrs._cesu8Encoder, attrs._sessionVariables), // write upstream
+		pr:        p.NewDBReader(rw.Reader, protTrace, protTraceRedact, logger, attrs._protTraceWriter, attrs._cesu8Decoder),                        // read downstream
 		sessionID: defaultSessionID,
 	}
+	if traceMode := protTraceMode(); traceMode != p.TraceText {
+		c.pw.SetTraceMode(traceMode)
+		c.pr.SetTraceMode(traceMode)
+	}
+	c.pr.MessageHook = func(size int) { collector.msgCh <- counterMsg{idx: counterUncompressedBytesRead, v: uint64(size)} }
+	c.pw.MessageHook = func(size int) { collector.msgCh <- counterMsg{idx: counterUncompressedBytesWritten, v: uint64(size)} }
+	c.pw.BeforeWrite = func(ctx context.Context, mt p.MessageType) {
+		c.waitDrain(ctx)
+		if mt != p.MtReadLob { // lob reads of prefetch and application are serialized by lobMu
+			c.lobPrefetches.Wait()
+		}
+	}
+	c.pr.ReplyHook = func() {
+		dbConn.replyDone()
+		c.pw.ReplyDone()
+	}
+	c.pr.LogUnknownParts = attrs._logUnknownParts
+	c.pw.CheckConcurrentUse = attrs._checkConcurrentUse
+	c.pr.ErrorPolicy = p.ErrorPolicy(attrs._errorPolicy)
+	c.warnings = newWarnings(attrs._maxWarnings, func(n int) { collector.msgCh <- counterMsg{idx: counterDroppedWarnings, v: uint64(n)} })
+	c.pr.OnWarnings = onWarnings(c.warnings, attrs._maxWarnings != 0, attrs._onWarning)
+	if attrs._stmtCacheSize > 0 {
+		c.stmtCache = newStmtCache(attrs._stmtCacheSize)
+	}
+	if attrs._keepAliveInterval > 0 {
+		c.keepAlive = newKeepAlive(attrs._keepAliveInterval, c.keepAlivePing)
+	}
+	if onUnknownPart := attrs._onUnknownPart; onUnknownPart != nil {
+		c.pr.OnUnknownPart = func(kind p.PartKind, raw []byte) { onUnknownPart(int(kind), raw) }
+	}
+	c.pr.RawColumn, c.pr.OnRawColumn = attrs._rawColumn, attrs._onRawColumn
 
 	if err := c.pw.WriteProlog(ctx); err != nil {
 		dbConn.close()
 		collector.close()
-		return nil, err
+		return nil, dbConn.handshakeError(err)
 	}
 
 	if err := c.pr.ReadProlog(ctx); err != nil {
 		dbConn.close()
 		collector.close()
-		return nil, err
+		return nil, dbConn.handshakeError(err)
 	}
 
 	stdConnTracker.add()
@@ -346,14 +549,15 @@ func fetchRedirectHost(ctx context.Context, host, databaseName string, metrics *
 	return net.JoinHostPort(dbi.Host, strconv.Itoa(dbi.Port)), nil
 }
 
-func newSession(ctx context.Context, host string, metrics *metrics, attrs *connAttrs, authHnd *p.AuthHnd) (driver.Conn, error) {
+func newSession(ctx context.Context, host string, metrics *metrics, attrs *connAttrs, authHnd *p.AuthHnd, hostConnect func(ctx context.Context, host string, attrs *connAttrs) (driver.Conn, error)) (driver.Conn, error) {
 	c, err := newConn(ctx, host, metrics, attrs)
 	if err != nil {
 		return nil, err
 	}
+	c.host, c.hostConnect = host, hostConnect
 	if err := c.initSession(ctx, attrs, authHnd); err != nil {
 		c.Close()
-		return nil, err
+		return nil, c.dbConn.handshakeError(err)
 	}
 	return c, nil
 }
@@ -365,15 +569,34 @@ func (c *conn) initSession(ctx context.Context, attrs *connAttrs, authHnd *p.Aut
 	if c.sessionID <= 0 {
 		return fmt.Errorf("invalid session id %d", c.sessionID)
 	}
+	// handshake completed: session initialization statements (e.g. connect init sql) are bound by the timeout only.
+	c.dbConn.handshakeDeadline = time.Time{}
 
 	c.hdbVersion = parseVersion(c.versionString())
-	c.fieldTypeCtx = p.NewFieldTypeCtx(c.serverOptions.DataFormatVersion2OrZero(), attrs._emptyDateAsNull)
+	c.fieldTypeCtx = p.NewFieldTypeCtx(c.serverOptions.DataFormatVersion2OrZero(), attrs._emptyDateAsNull, attrs._emptyStringAsNull)
+	c.fieldTypeCtx.SetDurationUnit(attrs._durationUnit)
+	c.fieldTypeCtx.SetNullFloatAsNaN(attrs._nullFloatAsNaN && nullFloatAsNaNSupported)
+	c.fieldTypeCtx.SetDecimalAsText(attrs._decimalAsText)
+
+	if attrs._sessionTimezone {
+		loc, err := c.sessionLocation(ctx)
+		if err != nil {
+			return err
+		}
+		c.fieldTypeCtx.SetLocation(loc)
+	}
 
 	if attrs._defaultSchema != "" {
 		if _, err := c.ExecContext(ctx, strings.Join([]string{setDefaultSchema, Identifier(attrs._defaultSchema).String()}, " "), nil); err != nil {
 			return err
 		}
 	}
+
+	for i, stmt := range attrs._connectInitSQL {
+		if _, err := c.ExecContext(ctx, stmt, nil); err != nil {
+			return fmt.Errorf("connect init sql statement %d: %w", i+1, err)
+		}
+	}
 	return nil
 }
 
@@ -381,11 +604,32 @@ func (c *conn) versionString() (version string) { return c.serverOptions.FullVer
 
 // ResetSession implements the driver.SessionResetter interface.
 func (c *conn) ResetSession(ctx context.Context) error {
+	c.keepAlive.busy()
+	if drain := c.cancelDrain(); drain != nil { // wait for the drain of a cancelled db call
+		select {
+		case <-drain.done:
+		case <-ctx.Done():
+			return driver.ErrBadConn
+		}
+		c.waitDrain(ctx)
+	}
 	if c.isBad() {
 		return driver.ErrBadConn
 	}
 
-	c.lastError = nil
+	c.setLastError(nil)
+
+	if c.inTx { // transaction was not finished properly: state of session unknown
+		return driver.ErrBadConn
+	}
+	if c.attrs._resetSessionVars {
+		c.pw.ResetSessionVariables(c.attrs._sessionVariables)
+	}
+	if c.attrs._onResetSession != nil {
+		if err := c.attrs._onResetSession(ctx, c); err != nil {
+			return fmt.Errorf("%w: reset session: %w", driver.ErrBadConn, err)
+		}
+	}
 
 	if c.attrs._pingInterval == 0 || c.dbConn.lastRead.IsZero() || time.Since(c.dbConn.lastRead) < c.attrs._pingInterval {
 		return nil
@@ -397,18 +641,211 @@ func (c *conn) ResetSession(ctx context.Context) error {
 	return nil
 }
 
-func (c *conn) isBad() bool { return errors.Is(c.lastError, driver.ErrBadConn) }
+/*
+cancelled is called if the context of a db call got cancelled. As the db call is still running,
+the connection is marked as bad.
+
+If requested, the session is cancelled on the database server and the reply of the db call (done, err)
+is drained in the background, so that the caller is not delayed: in case the database server finishes
+the db call with a database error in time and the cancel command is finished as well, the connection
+is in a consistent state and can be reused. Until the drain is finished the connection stays bad and
+further requests wait for the drain (see waitDrain).
+*/
+func (c *conn) cancelled(done <-chan struct{}, err *error) {
+	c.setLastError(errCancelled)
+	if !c.attrs._cancelSession {
+		return
+	}
+	cancelErr := c.cancelSession()
+	if cancelErr == nil {
+		return
+	}
+	drain := &cancelDrain{call: done, done: make(chan struct{})}
+	c.errMu.Lock()
+	c.drain = drain
+	c.errMu.Unlock()
+	c.wg.Add(1) // let Close wait until the drain is done
+	go func() {
+		defer c.wg.Done()
+		drain.ok = drainCancelled(done, err, cancelErr, cancelDrainTimeout)
+		close(drain.done)
+	}()
+}
+
+// cancelDrain is the state of the background drain of a cancelled db call.
+type cancelDrain struct {
+	call <-chan struct{} // done channel of the cancelled db call
+	done chan struct{}
+	ok   bool // valid after done is closed
+}
+
+// cancelDrain returns the drain of a cancelled db call, nil if none.
+func (c *conn) cancelDrain() *cancelDrain {
+	c.errMu.Lock()
+	defer c.errMu.Unlock()
+	return c.drain
+}
+
+// drainPending returns true if the drain of a cancelled db call is not finished yet.
+func (c *conn) drainPending() bool {
+	drain := c.cancelDrain()
+	if drain == nil {
+		return false
+	}
+	select {
+	case <-drain.done:
+		return false
+	default:
+		return true
+	}
+}
+
+// waitDrain waits until the drain of a cancelled db call is finished and resets the connection error
+// if the connection can be reused. The cancelled db call itself (identified by ctx) does not wait for
+// its own drain (e.g. writing the next batch of a bulk operation).
+func (c *conn) waitDrain(ctx context.Context) {
+	drain := c.cancelDrain()
+	if drain == nil || drain.call == dbCallFromContext(ctx) {
+		return
+	}
+	<-drain.done
+	c.errMu.Lock()
+	defer c.errMu.Unlock()
+	if c.drain != drain { // already reset by a concurrent wait
+		return
+	}
+	if drain.ok {
+		c.lastError = nil
+	}
+	c.drain = nil
+}
+
+// drainCancelled waits up to timeout for a cancelled db call and the cancel command to be finished and returns
+// true if the db call was finished by a database error (e.g. cancelled on the database server) and the cancel
+// command was executed successfully, false otherwise.
+func drainCancelled(done <-chan struct{}, err *error, cancelErr <-chan error, timeout time.Duration) bool {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+		// successful db calls might have left state on the database server (e.g. resultsets): discard connection
+		if *err == nil || errors.Is(*err, driver.ErrBadConn) {
+			return false
+		}
+	case <-timer.C:
+		return false
+	}
+	// a cancel command executed later on would cancel the next db call: wait for the cancel command.
+	select {
+	case err := <-cancelErr:
+		return err == nil
+	case <-timer.C:
+		return false
+	}
+}
+
+// cancelSession cancels the db call running on this connection. As the connection is blocked
+// waiting for the database reply, the cancel command is sent via a separate control connection.
+// cancelSession returns a channel receiving the result of the cancel command, nil if the session
+// cannot be cancelled.
+func (c *conn) cancelSession() <-chan error {
+	connectionID := c.serverOptions.ConnectionIDOrZero()
+	if connectionID == 0 || c.hostConnect == nil {
+		return nil
+	}
+	cancelErr := make(chan error, 1)
+	c.wg.Add(1) // let Close wait until the cancellation is done
+	go func() {
+		defer c.wg.Done()
+		cancelErr <- c.cancelSessionID(connectionID)
+	}()
+	return cancelErr
+}
+
+// cancelSessionID cancels the session connectionID via a control connection. As Close waits for the
+// cancellation, the control connection (including connect handshake and reads) is bounded by the connection
+// timeout, at most by the drain timeout (see drainCancelled).
+func (c *conn) cancelSessionID(connectionID int) error {
+	timeout := cancelDrainTimeout
+	if c.attrs._timeout > 0 {
+		timeout = min(timeout, c.attrs._timeout)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	attrs := c.attrs.clone()
+	attrs._timeout, attrs._handshakeTimeout = timeout, timeout
+	attrs._cancelSession = false // do not cancel the control connection itself
+
+	ctrl, err := c.hostConnect(ctx, c.host, attrs)
+	if err != nil {
+		c.logger.LogAttrs(ctx, slog.LevelError, "open control connection error", slog.String("error", err.Error()))
+		return err
+	}
+	defer ctrl.Close()
+	query := fmt.Sprintf("%s '%d'", cancelSession, connectionID)
+	if _, err = ctrl.(driver.ExecerContext).ExecContext(ctx, query, nil); err != nil {
+		c.logger.LogAttrs(ctx, slog.LevelError, "cancel session error", slog.Int("connection id", connectionID), slog.String("error", err.Error()))
+	}
+	return err
+}
+
+// setLastError sets the last error of the connection.
+func (c *conn) setLastError(err error) {
+	c.errMu.Lock()
+	defer c.errMu.Unlock()
+	c.lastError = err
+}
+
+func (c *conn) isBad() bool {
+	c.errMu.Lock()
+	defer c.errMu.Unlock()
+	return errors.Is(c.lastError, driver.ErrBadConn)
+}
 
 // IsValid implements the driver.Validator interface.
-func (c *conn) IsValid() bool { return !c.isBad() }
+// As it is called by database/sql if the connection is returned to the connection pool, keep-alive pings are started.
+func (c *conn) IsValid() bool {
+	if c.drainPending() { // the connection is checked when it is reused (see ResetSession)
+		return true
+	}
+	c.waitDrain(context.Background())
+	if c.isBad() {
+		return false
+	}
+	c.keepAlive.idle()
+	return true
+}
+
+// keepAlivePing executes a database ping on an idle connection.
+// The ping is bounded by the keep-alive interval and the connection timeout, so that an unresponsive database server
+// does not block the database calls waiting for the ping to finish (see keepAlive busy).
+func (c *conn) keepAlivePing() error {
+	timeout := c.attrs._keepAliveInterval
+	if c.attrs._timeout > 0 {
+		timeout = min(timeout, c.attrs._timeout)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	c.dbConn.callDeadline, _ = ctx.Deadline()
+	defer func() { c.dbConn.callDeadline = time.Time{} }()
+
+	if _, err := c.queryDirect(ctx, dummyQuery, !c.inTx); err != nil {
+		c.setLastError(err)
+		c.logger.LogAttrs(ctx, slog.LevelWarn, "keep-alive ping error", slog.String("error", err.Error()))
+		return err
+	}
+	return nil
+}
 
 // Ping implements the driver.Pinger interface.
 func (c *conn) Ping(ctx context.Context) error {
+	c.keepAlive.busy()
 	if c.sqlTrace {
 		defer c.logSQLTrace(ctx, time.Now(), dummyQuery, nil)
 	}
 
-	done := make(chan struct{})
+	ctx, done := withDBCall(ctx)
 	var err error
 	c.wg.Add(1)
 	go func() {
@@ -419,21 +856,22 @@ func (c *conn) Ping(ctx context.Context) error {
 
 	select {
 	case <-ctx.Done():
-		c.lastError = errCancelled
+		c.cancelled(done, &err)
 		return ctx.Err()
 	case <-done:
-		c.lastError = err
+		c.setLastError(err)
 		return err
 	}
 }
 
 // PrepareContext implements the driver.ConnPrepareContext interface.
 func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	c.keepAlive.busy()
 	if c.sqlTrace {
 		defer c.logSQLTrace(ctx, time.Now(), query, nil)
 	}
 
-	done := make(chan struct{})
+	ctx, done := withDBCall(ctx)
 	var stmt driver.Stmt
 	var err error
 	c.wg.Add(1)
@@ -441,7 +879,7 @@ func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, e
 		defer c.wg.Done()
 		var pr *prepareResult
 
-		if pr, err = c.prepare(ctx, query); err == nil {
+		if pr, err = c.prepareCached(ctx, query); err == nil {
 			stmt = newStmt(c, query, pr)
 		}
 
@@ -450,19 +888,28 @@ func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, e
 
 	select {
 	case <-ctx.Done():
-		c.lastError = errCancelled
+		c.cancelled(done, &err)
 		return nil, ctx.Err()
 	case <-done:
 		c.collector.msgCh <- gaugeMsg{idx: gaugeStmt, v: 1} // increment number of statements.
-		c.lastError = err
+		c.setLastError(err)
 		return stmt, err
 	}
 }
 
 // Close implements the driver.Conn interface.
 func (c *conn) Close() error {
-	c.wg.Wait()                                          // wait until concurrent db calls are finalized
+	c.keepAlive.close()
+	c.wg.Wait()            // wait until concurrent db calls are finalized
+	c.lobPrefetches.Wait() // wait until lob prefetches are finalized
+	if c.replica != nil {
+		c.replica.Close()
+	}
 	c.collector.msgCh <- gaugeMsg{idx: gaugeConn, v: -1} // decrement open connections.
+	if c.stmtCache != nil {
+		c.collector.msgCh <- gaugeMsg{idx: gaugeStmtCache, v: -int64(c.stmtCache.len())} // statements are dropped by disconnect.
+	}
+	c.waitDrain(context.Background()) // drain is finished after wait
 	// do not disconnect if isBad or invalid sessionID
 	if !c.isBad() && c.sessionID != defaultSessionID {
 		c.disconnect(context.Background()) //nolint:errcheck
@@ -475,9 +922,13 @@ func (c *conn) Close() error {
 
 // BeginTx implements the driver.ConnBeginTx interface.
 func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	c.keepAlive.busy()
 	if c.inTx {
 		return nil, ErrNestedTransaction
 	}
+	if err := c.checkReadOnlyRouting(ctx); err != nil {
+		return nil, err
+	}
 
 	var isolationLevelQuery string
 	switch sql.IsolationLevel(opts.Isolation) {
@@ -491,7 +942,7 @@ func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, e
 		return nil, ErrUnsupportedIsolationLevel
 	}
 
-	done := make(chan struct{})
+	ctx, done := withDBCall(ctx)
 	var tx driver.Tx
 	var err error
 	c.wg.Add(1)
@@ -518,11 +969,11 @@ func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, e
 
 	select {
 	case <-ctx.Done():
-		c.lastError = errCancelled
+		c.cancelled(done, &err)
 		return nil, ctx.Err()
 	case <-done:
 		c.collector.msgCh <- gaugeMsg{idx: gaugeTx, v: 1} // increment number of transactions.
-		c.lastError = err
+		c.setLastError(err)
 		return tx, err
 	}
 }
@@ -531,62 +982,94 @@ var callStmt = regexp.MustCompile(`(?i)^\s*call\s+.*`) // sql statement beginnin
 
 // QueryContext implements the driver.QueryerContext interface.
 func (c *conn) QueryContext(ctx context.Context, query string, nvargs []driver.NamedValue) (driver.Rows, error) {
+	c.keepAlive.busy()
 	if callStmt.MatchString(query) {
 		return nil, fmt.Errorf("invalid procedure call %s - please use Exec instead", query)
 	}
+	query, nvargs, expanded, err := expandSliceArgs(query, nvargs)
+	if err != nil {
+		return nil, err
+	}
+	if rows, routed, err := c.routeQuery(ctx, query, nvargs); routed {
+		return rows, err
+	}
+	if expanded {
+		return c.preparedQuery(ctx, query, nvargs) // prepare expanded query
+	}
 	if len(nvargs) != 0 {
 		return nil, driver.ErrSkip // fast path not possible (prepare needed)
 	}
+	if c.inTx && c.attrs._ddlPolicy != DDLPolicyAllow {
+		return nil, driver.ErrSkip // prepare needed to check for DDL before execution
+	}
 	if c.sqlTrace {
 		defer c.logSQLTrace(ctx, time.Now(), query, nvargs)
 	}
 
-	done := make(chan struct{})
+	ctx, done := withDBCall(ctx)
 	var rows driver.Rows
-	var err error
 	c.wg.Add(1)
 	go func() {
 		defer c.wg.Done()
-		rows, err = c.queryDirect(ctx, query, !c.inTx)
+		err = c.retryQuery(ctx, true, func() (err error) {
+			rows, err = c.queryDirect(ctx, query, !c.inTx)
+			return err
+		})
 		close(done)
 	}()
 
 	select {
 	case <-ctx.Done():
-		c.lastError = errCancelled
+		c.cancelled(done, &err)
 		return nil, ctx.Err()
 	case <-done:
-		c.lastError = err
+		c.setLastError(err)
 		return rows, err
 	}
 }
 
 // ExecContext implements the driver.ExecerContext interface.
 func (c *conn) ExecContext(ctx context.Context, query string, nvargs []driver.NamedValue) (driver.Result, error) {
+	c.keepAlive.busy()
+	if err := c.checkReadOnlyRouting(ctx); err != nil {
+		return nil, err
+	}
+	query, nvargs, expanded, err := expandSliceArgs(query, nvargs)
+	if err != nil {
+		return nil, err
+	}
+	if expanded {
+		return c.preparedExec(ctx, query, nvargs) // prepare expanded query
+	}
 	if len(nvargs) != 0 {
 		return nil, driver.ErrSkip // fast path not possible (prepare needed)
 	}
+	if c.inTx && c.attrs._ddlPolicy != DDLPolicyAllow {
+		return nil, driver.ErrSkip // prepare needed to check for DDL before execution
+	}
 	if c.sqlTrace {
 		defer c.logSQLTrace(ctx, time.Now(), query, nvargs)
 	}
 
-	done := make(chan struct{})
+	ctx, done := withDBCall(ctx)
 	var result driver.Result
-	var err error
 	c.wg.Add(1)
 	go func() {
 		defer c.wg.Done()
 		// handle procesure call without parameters here as well
 		result, err = c.execDirect(ctx, query, !c.inTx)
+		if err == nil {
+			err = c.invalidateStmtCache(ctx, query)
+		}
 		close(done)
 	}()
 
 	select {
 	case <-ctx.Done():
-		c.lastError = errCancelled
+		c.cancelled(done, &err)
 		return nil, ctx.Err()
 	case <-done:
-		c.lastError = err
+		c.setLastError(err)
 		return result, err
 	}
 }
@@ -609,9 +1092,22 @@ func (c *conn) HDBVersion() *Version { return c.hdbVersion }
 // DatabaseName implements the Conn interface.
 func (c *conn) DatabaseName() string { return c.serverOptions.DatabaseNameOrZero() }
 
+// BytesPerRow implements the Conn interface.
+func (c *conn) BytesPerRow() float64 { return c.rowSize.bytesPerRow() }
+
+// ProtocolVersion implements the Conn interface.
+// The protocol version is negotiated in the protocol prolog and might be used to
+// decide if a protocol feature is supported by the database server.
+func (c *conn) ProtocolVersion() (major, minor int) { return c.pr.ProtocolVersion() }
+
+// Topology implements the Conn interface.
+// The topology is empty in case the database did not provide topology information on connect.
+func (c *conn) Topology() []TopologyHost { return slices.Clone(c.topology) }
+
 // DBConnectInfo implements the Conn interface.
 func (c *conn) DBConnectInfo(ctx context.Context, databaseName string) (*DBConnectInfo, error) {
-	done := make(chan struct{})
+	c.keepAlive.busy()
+	ctx, done := withDBCall(ctx)
 	var ci *DBConnectInfo
 	var err error
 	c.wg.Add(1)
@@ -623,10 +1119,10 @@ func (c *conn) DBConnectInfo(ctx context.Context, databaseName string) (*DBConne
 
 	select {
 	case <-ctx.Done():
-		c.lastError = errCancelled
+		c.cancelled(done, &err)
 		return nil, ctx.Err()
 	case <-done:
-		c.lastError = err
+		c.setLastError(err)
 		return ci, err
 	}
 }
@@ -658,8 +1154,28 @@ func (c *conn) addTimeValue(start time.Time, k int) {
 	c.collector.msgCh <- timeMsg{idx: k, d: time.Since(start)}
 }
 
-func (c *conn) addSQLTimeValue(start time.Time, k int) {
-	c.collector.msgCh <- sqlTimeMsg{idx: k, d: time.Since(start)}
+// addSQLMsg sends a sql metric message to the connection metrics and to the metrics scope of ctx if available.
+func (c *conn) addSQLMsg(ctx context.Context, msg any) {
+	c.collector.msgCh <- msg
+	if scope := metricsScopeFromContext(ctx); scope != nil {
+		c.collector.msgCh <- scopeMsg{metrics: scope.metrics, msg: msg}
+	}
+}
+
+func (c *conn) addSQLTimeValue(ctx context.Context, start time.Time, k int) {
+	c.addSQLMsg(ctx, sqlTimeMsg{idx: k, d: time.Since(start)})
+}
+
+func (c *conn) addRowsValue(ctx context.Context, numRow int) {
+	c.addSQLMsg(ctx, rowsMsg{v: numRow})
+	c.rowSize.add(c.pr.VarPartLength(), numRow)
+}
+
+// addSQLErrorValue is meant to be deferred with the address of the error result of the sql operation.
+func (c *conn) addSQLErrorValue(ctx context.Context, k int, err *error) {
+	if *err != nil {
+		c.addSQLMsg(ctx, sqlErrorMsg{idx: k})
+	}
 }
 
 // transaction.
@@ -681,9 +1197,11 @@ func (t *tx) Rollback() error { return t.close(true) }
 
 func (t *tx) close(rollback bool) (err error) {
 	c := t.conn
+	c.keepAlive.busy()
 
 	c.collector.msgCh <- gaugeMsg{idx: gaugeTx, v: -1} // decrement number of transactions.
 
+	c.waitDrain(context.Background())
 	if c.isBad() {
 		return driver.ErrBadConn
 	}
@@ -693,6 +1211,7 @@ func (t *tx) close(rollback bool) (err error) {
 	t.closed = true
 
 	c.inTx = false
+	c.txID = 0
 
 	if rollback {
 		err = c.rollback(context.Background())
@@ -704,6 +1223,9 @@ func (t *tx) close(rollback bool) (err error) {
 
 const defaultSessionID = -1
 
+// compressionLevelAndFlags is the connect option value requesting lz4 message compression (default compression level).
+const compressionLevelAndFlags = 1
+
 func (c *conn) dbConnectInfo(ctx context.Context, databaseName string) (*DBConnectInfo, error) {
 	ci := &p.DBConnectInfo{}
 	ci.SetDatabaseName(databaseName)
@@ -774,6 +1296,9 @@ func (c *conn) authenticate(ctx context.Context, authHnd *p.AuthHnd, attrs *conn
 	if attrs._locale != "" {
 		co.SetClientLocale(attrs._locale)
 	}
+	if attrs._compression {
+		co.SetCompressionLevelAndFlags(compressionLevelAndFlags)
+	}
 
 	if err := c.pw.Write(ctx, c.sessionID, p.MtConnect, false, finalRequest, p.ClientID(clientID), co); err != nil {
 		return 0, nil, err
@@ -800,18 +1325,27 @@ func (c *conn) authenticate(ctx context.Context, authHnd *p.AuthHnd, attrs *conn
 	}
 	// log.Printf("co: %s", co)
 	// log.Printf("ti: %s", ti)
+	c.topology = newTopology(ti)
+	if attrs._failOnStandby && ti.IsStandby() {
+		return 0, nil, ErrStandbyNotReady
+	}
+	// compress requests only if the server confirmed compression (compressed replies are decompressed in any case)
+	if attrs._compression && co.CompressionLevelAndFlagsOrZero() != 0 {
+		c.pw.SetCompression(true)
+	}
 	return c.pr.SessionID(), co, nil
 }
 
-func (c *conn) queryDirect(ctx context.Context, query string, commit bool) (driver.Rows, error) {
-	defer c.addSQLTimeValue(time.Now(), sqlTimeQuery)
+func (c *conn) queryDirect(ctx context.Context, query string, commit bool) (_ driver.Rows, err error) {
+	defer c.addSQLTimeValue(ctx, time.Now(), sqlTimeQuery)
+	defer c.addSQLErrorValue(ctx, sqlTimeQuery, &err)
 
 	// allow e.g inserts as query -> handle commit like in _execDirect
-	if err := c.pw.Write(ctx, c.sessionID, p.MtExecuteDirect, commit, p.Command(query)); err != nil {
+	if err := c.writeCommand(ctx, p.MtExecuteDirect, commit, query); err != nil {
 		return nil, err
 	}
 
-	qr := &queryResult{conn: c}
+	qr := c.newQueryResult(ctx, nil)
 	meta := &p.ResultMetadata{FieldTypeCtx: c.fieldTypeCtx}
 	resSet := &p.Resultset{}
 
@@ -823,11 +1357,7 @@ func (c *conn) queryDirect(ctx context.Context, query string, commit bool) (driv
 		case p.PkResultsetID:
 			read((*p.ResultsetID)(&qr.rsID))
 		case p.PkResultset:
-			resSet.ResultFields = qr.fields
-			read(resSet)
-			qr.fieldValues = resSet.FieldValues
-			qr.decodeErrors = resSet.DecodeErrors
-			qr.attrs = attrs
+			c.readResultset(ctx, qr, resSet, attrs, read)
 		}
 	}); err != nil {
 		return nil, err
@@ -835,36 +1365,42 @@ func (c *conn) queryDirect(ctx context.Context, query string, commit bool) (driv
 	if qr.rsID == 0 { // non select query
 		return noResult, nil
 	}
+	qr.estimatedRowCount, qr.hasEstimate = estimateRowCount(qr.attrs.LastPacket(), qr.numRow())
+	if c.attrs._lobPrefetch && qr.stream == nil {
+		qr.lobPrefetcher = newLobPrefetcher(c.lobDecoder(ctx), &c.lobPrefetches)
+	}
 	return qr, nil
 }
 
-func (c *conn) execDirect(ctx context.Context, query string, commit bool) (driver.Result, error) {
-	defer c.addSQLTimeValue(time.Now(), sqlTimeExec)
+func (c *conn) execDirect(ctx context.Context, query string, commit bool) (_ driver.Result, err error) {
+	defer c.addSQLTimeValue(ctx, time.Now(), sqlTimeExec)
+	defer c.addSQLErrorValue(ctx, sqlTimeExec, &err)
 
-	if err := c.pw.Write(ctx, c.sessionID, p.MtExecuteDirect, commit, p.Command(query)); err != nil {
+	if err := c.writeCommand(ctx, p.MtExecuteDirect, commit, query); err != nil {
 		return nil, err
 	}
 
 	rows := &p.RowsAffected{}
-	var numRow int64
+	hasRowsAffected := false
 	if err := c.pr.IterateParts(ctx, func(kind p.PartKind, attrs p.PartAttributes, read func(part p.Part)) {
 		if kind == p.PkRowsAffected {
 			read(rows)
-			numRow = rows.Total()
+			hasRowsAffected = true
 		}
 	}); err != nil {
+		if hasRowsAffected {
+			return &execResult{fc: c.pr.FunctionCode(), rows: rows.Rows()}, err
+		}
 		return nil, err
 	}
-	if c.pr.FunctionCode() == p.FcDDL {
-		return driver.ResultNoRows, nil
-	}
-	return driver.RowsAffected(numRow), nil
+	return &execResult{fc: c.pr.FunctionCode(), rows: rows.Rows()}, nil
 }
 
-func (c *conn) prepare(ctx context.Context, query string) (*prepareResult, error) {
-	defer c.addSQLTimeValue(time.Now(), sqlTimePrepare)
+func (c *conn) prepare(ctx context.Context, query string) (_ *prepareResult, err error) {
+	defer c.addSQLTimeValue(ctx, time.Now(), sqlTimePrepare)
+	defer c.addSQLErrorValue(ctx, sqlTimePrepare, &err)
 
-	if err := c.pw.Write(ctx, c.sessionID, p.MtPrepare, false, p.Command(query)); err != nil {
+	if err := c.writeCommand(ctx, p.MtPrepare, false, query); err != nil {
 		return nil, err
 	}
 
@@ -890,8 +1426,9 @@ func (c *conn) prepare(ctx context.Context, query string) (*prepareResult, error
 	return pr, nil
 }
 
-func (c *conn) query(ctx context.Context, pr *prepareResult, nvargs []driver.NamedValue, commit bool) (driver.Rows, error) {
-	defer c.addSQLTimeValue(time.Now(), sqlTimeQuery)
+func (c *conn) query(ctx context.Context, pr *prepareResult, nvargs []driver.NamedValue, commit bool) (_ driver.Rows, err error) {
+	defer c.addSQLTimeValue(ctx, time.Now(), sqlTimeQuery)
+	defer c.addSQLErrorValue(ctx, sqlTimeQuery, &err)
 
 	// allow e.g inserts as query -> handle commit like in exec
 
@@ -906,7 +1443,7 @@ func (c *conn) query(ctx context.Context, pr *prepareResult, nvargs []driver.Nam
 		return nil, err
 	}
 
-	qr := &queryResult{conn: c, fields: pr.resultFields}
+	qr := c.newQueryResult(ctx, pr.resultFields)
 	resSet := &p.Resultset{}
 
 	if err := c.pr.IterateParts(ctx, func(kind p.PartKind, attrs p.PartAttributes, read func(part p.Part)) {
@@ -914,11 +1451,7 @@ func (c *conn) query(ctx context.Context, pr *prepareResult, nvargs []driver.Nam
 		case p.PkResultsetID:
 			read((*p.ResultsetID)(&qr.rsID))
 		case p.PkResultset:
-			resSet.ResultFields = qr.fields
-			read(resSet)
-			qr.fieldValues = resSet.FieldValues
-			qr.decodeErrors = resSet.DecodeErrors
-			qr.attrs = attrs
+			c.readResultset(ctx, qr, resSet, attrs, read)
 		}
 	}); err != nil {
 		return nil, err
@@ -926,6 +1459,10 @@ func (c *conn) query(ctx context.Context, pr *prepareResult, nvargs []driver.Nam
 	if qr.rsID == 0 { // non select query
 		return noResult, nil
 	}
+	qr.estimatedRowCount, qr.hasEstimate = estimateRowCount(qr.attrs.LastPacket(), qr.numRow())
+	if c.attrs._lobPrefetch && qr.stream == nil {
+		qr.lobPrefetcher = newLobPrefetcher(c.lobDecoder(ctx), &c.lobPrefetches)
+	}
 	return qr, nil
 }
 
@@ -941,21 +1478,24 @@ func (c *conn) exec(ctx context.Context, pr *prepareResult, nvargs []driver.Name
 	rows := &p.RowsAffected{Ofs: ofs}
 	var ids []p.LocatorID
 	lobReply := &p.WriteLobReply{}
-	var rowsAffected int64
+	hasRowsAffected := false
 
 	if err := c.pr.IterateParts(ctx, func(kind p.PartKind, attrs p.PartAttributes, read func(part p.Part)) {
 		switch kind {
 		case p.PkRowsAffected:
 			read(rows)
-			rowsAffected = rows.Total()
+			hasRowsAffected = true
 		case p.PkWriteLobReply:
 			read(lobReply)
 			ids = lobReply.IDs
 		}
 	}); err != nil {
+		if hasRowsAffected { // keep rows affected for partially failed bulk statements
+			return &execResult{fc: c.pr.FunctionCode(), rows: rows.Rows()}, err
+		}
 		return nil, err
 	}
-	fc := c.pr.FunctionCode()
+	result := &execResult{fc: c.pr.FunctionCode(), rows: rows.Rows()}
 
 	if len(ids) != 0 {
 		/*
@@ -968,19 +1508,16 @@ func (c *conn) exec(ctx context.Context, pr *prepareResult, nvargs []driver.Name
 			write lob data only for the last record as lob streaming is only available for the last one
 		*/
 		startLastRec := len(nvargs) - len(pr.parameterFields)
-		if err := c.encodeLobs(nil, ids, pr.parameterFields, nvargs[startLastRec:]); err != nil {
+		if err := c.encodeLobs(ctx, nil, ids, pr.parameterFields, nvargs[startLastRec:]); err != nil {
 			return nil, err
 		}
 	}
 
-	if fc == p.FcDDL {
-		return driver.ResultNoRows, nil
-	}
-	return driver.RowsAffected(rowsAffected), nil
+	return result, nil
 }
 
 func (c *conn) execCall(ctx context.Context, outputFields []*p.ParameterField) (*callResult, []p.LocatorID, int64, error) {
-	cr := &callResult{conn: c, outputFields: outputFields}
+	cr := &callResult{conn: c, outputFields: outputFields, ctx: ctx}
 
 	var qr *queryResult
 	rows := &p.RowsAffected{}
@@ -1034,10 +1571,50 @@ func (c *conn) execCall(ctx context.Context, outputFields []*p.ParameterField) (
 	return cr, ids, numRow, nil
 }
 
-func (c *conn) fetchNext(ctx context.Context, qr *queryResult) error {
-	defer c.addSQLTimeValue(time.Now(), sqlTimeFetch)
+/*
+fetchNextContext fetches the next rows of the resultset like fetchNext. The fetch is cancelled if ctx
+(the context of the query) gets cancelled while waiting for the database reply (see cancelled).
+*/
+func (c *conn) fetchNextContext(ctx context.Context, qr *queryResult) error {
+	if ctx == nil {
+		return c.fetchNext(context.Background(), qr)
+	}
+	if ctx.Done() == nil {
+		return c.fetchNext(ctx, qr)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	ctx, done := withDBCall(ctx)
+	var err error
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		err = c.fetchNext(context.WithoutCancel(ctx), qr) // keep context values (e.g. metrics scope)
+		close(done)
+	}()
+
+	select {
+	case <-ctx.Done():
+		c.cancelled(done, &err)
+		return ctx.Err()
+	case <-done:
+		c.setLastError(err)
+		return err
+	}
+}
 
-	if err := c.pw.Write(ctx, c.sessionID, p.MtFetchNext, false, p.ResultsetID(qr.rsID), p.Fetchsize(c.attrs._fetchSize)); err != nil {
+func (c *conn) fetchNext(ctx context.Context, qr *queryResult) (err error) {
+	defer c.addSQLTimeValue(ctx, time.Now(), sqlTimeFetch)
+	defer c.addSQLErrorValue(ctx, sqlTimeFetch, &err)
+
+	fetchSize, ok := queryFetchSize(qr.ctx)
+	if !ok {
+		fetchSize = c.nextFetchSize()
+	}
+	fetchSize = min(fetchSize, c.serverOptions.MaxFetchSize())
+	if err := c.pw.Write(ctx, c.sessionID, p.MtFetchNext, false, p.ResultsetID(qr.rsID), p.Fetchsize(fetchSize)); err != nil {
 		return err
 	}
 
@@ -1045,14 +1622,47 @@ func (c *conn) fetchNext(ctx context.Context, qr *queryResult) error {
 
 	return c.pr.IterateParts(ctx, func(kind p.PartKind, attrs p.PartAttributes, read func(part p.Part)) {
 		if kind == p.PkResultset {
-			read(resSet)
-			qr.fieldValues = resSet.FieldValues
-			qr.decodeErrors = resSet.DecodeErrors
-			qr.attrs = attrs
+			c.readResultset(ctx, qr, resSet, attrs, read)
 		}
 	})
 }
 
+// newQueryResult returns a new query result - decoding the resultset row by row if resultset streaming is enabled.
+func (c *conn) newQueryResult(ctx context.Context, fields []*p.ResultField) *queryResult {
+	qr := &queryResult{conn: c, fields: fields, ctx: ctx}
+	if c.attrs._streamResultsets {
+		qr.stream = p.NewResultsetStream(c.attrs._cesu8Decoder)
+	}
+	return qr
+}
+
+// readResultset reads a resultset part into qr (streamed or buffered).
+func (c *conn) readResultset(ctx context.Context, qr *queryResult, resSet *p.Resultset, attrs p.PartAttributes, read func(part p.Part)) {
+	if qr.stream != nil {
+		qr.stream.ResultFields = qr.fields
+		read(qr.stream)
+	} else {
+		resSet.ResultFields = qr.fields
+		read(resSet)
+		qr.fieldValues = resSet.FieldValues
+		qr.decodeErrors = resSet.DecodeErrors
+	}
+	qr.attrs = attrs
+	c.addRowsValue(ctx, qr.numRow())
+}
+
+// nextFetchSize returns the fetch size for the next fetch depending on the memory pressure if adaptive fetch sizing is enabled.
+func (c *conn) nextFetchSize() int {
+	if c.attrs._memoryPressure == nil {
+		return c.attrs._fetchSize
+	}
+	maxSize := c.attrs._fetchSizeMax
+	if maxSize == 0 {
+		maxSize = c.attrs._fetchSize
+	}
+	return c.fetchSize.next(c.attrs._fetchSize, c.attrs._fetchSizeMin, maxSize, c.attrs._memoryPressure())
+}
+
 func (c *conn) dropStatementID(ctx context.Context, id uint64) error {
 	if err := c.pw.Write(ctx, c.sessionID, p.MtDropStatementID, false, p.StatementID(id)); err != nil {
 		return err
@@ -1067,8 +1677,9 @@ func (c *conn) closeResultsetID(ctx context.Context, id uint64) error {
 	return c.pr.SkipParts(ctx)
 }
 
-func (c *conn) commit(ctx context.Context) error {
-	defer c.addSQLTimeValue(time.Now(), sqlTimeCommit)
+func (c *conn) commit(ctx context.Context) (err error) {
+	defer c.addSQLTimeValue(ctx, time.Now(), sqlTimeCommit)
+	defer c.addSQLErrorValue(ctx, sqlTimeCommit, &err)
 
 	if err := c.pw.Write(ctx, c.sessionID, p.MtCommit, false); err != nil {
 		return err
@@ -1079,8 +1690,9 @@ func (c *conn) commit(ctx context.Context) error {
 	return nil
 }
 
-func (c *conn) rollback(ctx context.Context) error {
-	defer c.addSQLTimeValue(time.Now(), sqlTimeRollback)
+func (c *conn) rollback(ctx context.Context) (err error) {
+	defer c.addSQLTimeValue(ctx, time.Now(), sqlTimeRollback)
+	defer c.addSQLErrorValue(ctx, sqlTimeRollback, &err)
 
 	if err := c.pw.Write(ctx, c.sessionID, p.MtRollback, false); err != nil {
 		return err
@@ -1107,6 +1719,14 @@ func (c *conn) disconnect(ctx context.Context) error {
 	return nil
 }
 
+// lobDecoder returns the lob decoder of the query or call executed with ctx (nil if not available).
+func (c *conn) lobDecoder(ctx context.Context) func(descr *p.LobOutDescr, wr io.Writer) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return func(descr *p.LobOutDescr, wr io.Writer) error { return c.decodeLob(ctx, descr, wr) }
+}
+
 // decodeLobs decodes (reads from db) output lob or result lob parameters.
 
 /*
@@ -1114,14 +1734,16 @@ read lob reply
   - seems like readLobreply returns only a result for one lob - even if more then one is requested
     --> read single lobs
 */
-func (c *conn) decodeLob(descr *p.LobOutDescr, wr io.Writer) error {
-	defer c.addSQLTimeValue(time.Now(), sqlTimeFetchLob)
+func (c *conn) decodeLob(ctx context.Context, descr *p.LobOutDescr, wr io.Writer) (err error) {
+	c.lobMu.Lock() // serialize lob reads of the application and the lob prefetch
+	defer c.lobMu.Unlock()
 
-	var err error
+	defer c.addSQLTimeValue(ctx, time.Now(), sqlTimeFetchLob)
+	defer c.addSQLErrorValue(ctx, sqlTimeFetchLob, &err)
 
 	if descr.IsCharBased {
 		wrcl := transform.NewWriter(wr, c.attrs._cesu8Decoder()) // CESU8 transformer
-		err = c._decodeLob(descr, wrcl, func(b []byte) (size int, numChar int) {
+		err = c._decodeLob(ctx, descr, wrcl, func(b []byte) (size int, numChar int) {
 			for len(b) > 0 {
 				if !cesu8.FullRune(b) {
 					return
@@ -1138,48 +1760,26 @@ func (c *conn) decodeLob(descr *p.LobOutDescr, wr io.Writer) error {
 			return
 		})
 	} else {
-		err = c._decodeLob(descr, wr, func(b []byte) (int, int) { return len(b), len(b) })
+		err = c._decodeLob(ctx, descr, wr, func(b []byte) (int, int) { return len(b), len(b) })
 	}
 
-	if pw, ok := wr.(*io.PipeWriter); ok { // if the writer is a pipe-end -> close at the end
+	closeLobWriter(wr, err)
+	return err
+}
+
+// closeLobWriter closes wr if the writer is a pipe-end.
+func closeLobWriter(wr io.Writer, err error) {
+	if pw, ok := wr.(*io.PipeWriter); ok {
 		if err != nil {
 			pw.CloseWithError(err)
 		} else {
 			pw.Close()
 		}
 	}
-	return err
 }
 
-func (c *conn) _decodeLob(descr *p.LobOutDescr, wr io.Writer, countChars func(b []byte) (int, int)) error {
-	lobChunkSize := int64(c.attrs._lobChunkSize)
-
-	chunkSize := func(numChar, ofs int64) int32 {
-		chunkSize := numChar - ofs
-		if chunkSize > lobChunkSize {
-			return int32(lobChunkSize)
-		}
-		return int32(chunkSize)
-	}
-
-	size, numChar := countChars(descr.B)
-	if _, err := wr.Write(descr.B[:size]); err != nil {
-		return err
-	}
-
-	lobRequest := &p.ReadLobRequest{}
-	lobRequest.ID = descr.ID
-
-	lobReply := &p.ReadLobReply{}
-
-	eof := descr.Opt.IsLastData()
-
-	ctx := context.Background()
-
-	for !eof {
-		lobRequest.Ofs += int64(numChar)
-		lobRequest.ChunkSize = chunkSize(descr.NumChar, lobRequest.Ofs)
-
+func (c *conn) _decodeLob(ctx context.Context, descr *p.LobOutDescr, wr io.Writer, countChars func(b []byte) (int, int)) error {
+	return decodeLobChunks(descr, wr, int64(c.attrs.lobReadChunkSize()), countChars, func(lobRequest *p.ReadLobRequest, lobReply *p.ReadLobReply) error {
 		if err := c.pw.Write(ctx, c.sessionID, p.MtWriteLob, false, lobRequest); err != nil {
 			return err
 		}
@@ -1191,18 +1791,9 @@ func (c *conn) _decodeLob(descr *p.LobOutDescr, wr io.Writer, countChars func(b
 		}); err != nil {
 			return err
 		}
-
-		if lobReply.ID != lobRequest.ID {
-			return fmt.Errorf("internal error: invalid lob locator %d - expected %d", lobReply.ID, lobRequest.ID)
-		}
-
-		size, numChar = countChars(lobReply.B)
-		if _, err := wr.Write(lobReply.B[:size]); err != nil {
-			return err
-		}
-		eof = lobReply.Opt.IsLastData()
-	}
-	return nil
+		c.collector.msgCh <- counterMsg{idx: counterLobBytesRead, v: uint64(len(lobReply.B))}
+		return nil
+	})
 }
 
 func assertEqual[T comparable](s string, a, b T) {
@@ -1211,8 +1802,16 @@ func assertEqual[T comparable](s string, a, b T) {
 	}
 }
 
-// encodeLobs encodes (write to db) input lob parameters.
-func (c *conn) encodeLobs(cr *callResult, ids []p.LocatorID, inPrmFields []*p.ParameterField, nvargs []driver.NamedValue) error {
+/*
+encodeLobs encodes (write to db) input lob parameters.
+
+The lob readers are streamed in chunks of lobChunkSize bytes: each chunk is sent in a write lob request
+appending the data to the lob (offset -1) and the last chunk of a lob is flagged as last data. So only
+one chunk per lob is held in memory at a time. The context is checked before each chunk is fetched -
+as the database statement cannot be completed after a cancellation the returned error marks the
+connection as bad.
+*/
+func (c *conn) encodeLobs(ctx context.Context, cr *callResult, ids []p.LocatorID, inPrmFields []*p.ParameterField, nvargs []driver.NamedValue) error {
 	assertEqual("lob streaming can only be done for one (the last) record", len(inPrmFields), len(nvargs))
 
 	descrs := make([]*p.WriteLobDescr, 0, len(ids))
@@ -1235,9 +1834,10 @@ func (c *conn) encodeLobs(cr *callResult, ids []p.LocatorID, inPrmFields []*p.Pa
 
 	writeLobRequest := &p.WriteLobRequest{}
 
-	ctx := context.Background()
-
 	for len(descrs) != 0 {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("%w: %w", errCancelled, err)
+		}
 
 		if len(descrs) != len(ids) {
 			return fmt.Errorf("protocol error: invalid number of lob parameter ids %d - expected %d", len(descrs), len(ids))
@@ -1260,6 +1860,7 @@ func (c *conn) encodeLobs(cr *callResult, ids []p.LocatorID, inPrmFields []*p.Pa
 		if err := c.pw.Write(ctx, c.sessionID, p.MtReadLob, false, writeLobRequest); err != nil {
 			return err
 		}
+		c.collector.msgCh <- counterMsg{idx: counterLobBytesWritten, v: uint64(writeLobRequest.NumByte())}
 
 		lobReply := &p.WriteLobReply{}
 		outPrms := &p.OutputParameters{}
@@ -1291,3 +1892,13 @@ func (c *conn) encodeLobs(cr *callResult, ids []p.LocatorID, inPrmFields []*p.Pa
 	}
 	return nil
 }
+
+/*
+Stats implements the Conn interface.
+
+It returns the statistics of this connection only, e.g. to identify a pooled connection generating unexpected
+traffic. The connection statistics are rolled up into the statistics of the connector / database object
+(see DB ExStats) and the driver (see Driver Stats). As statistics are collected asynchronously, the latest
+database calls might not be reflected yet.
+*/
+func (c *conn) Stats() *Stats { return c.metrics.stats() }
@@ -6,7 +6,10 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"strconv"
 	"testing"
+	"time"
 )
 
 func testCancelContext(t *testing.T, db *sql.DB) {
@@ -67,6 +70,257 @@ func testCheckCallStmt(t *testing.T, db *sql.DB) {
 	}
 }
 
+func testTopology(t *testing.T, db *sql.DB) {
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	var topology []TopologyHost
+	if err := conn.Raw(func(driverConn any) error {
+		topology = driverConn.(Conn).Topology()
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(topology) == 0 {
+		t.Skip("no topology information provided by database")
+	}
+	for _, host := range topology {
+		if host.IsCurrentSession {
+			return
+		}
+	}
+	t.Fatalf("host of current session not found in topology %v", topology)
+}
+
+func testConnStats(t *testing.T, db *sql.DB) {
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if err := conn.PingContext(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// statistics are collected asynchronously.
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		var stats *Stats
+		if err := conn.Raw(func(driverConn any) error {
+			stats = driverConn.(Conn).Stats()
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if stats.ReadBytes != 0 && stats.WrittenBytes != 0 {
+			if stats.OpenConnections != 1 {
+				t.Fatalf("open connections %d - expected %d", stats.OpenConnections, 1)
+			}
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("read bytes %d written bytes %d - expected not zero", stats.ReadBytes, stats.WrittenBytes)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func testTransactionID(t *testing.T, db *sql.DB) {
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	transactionID := func() (id int64, ok bool) {
+		if err := conn.Raw(func(driverConn any) error {
+			id, ok = driverConn.(Conn).TransactionID()
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	if id, ok := transactionID(); ok {
+		t.Fatalf("transaction id %d available outside of transaction", id)
+	}
+
+	tx, err := conn.BeginTx(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, ok := transactionID()
+	if !ok {
+		t.Fatal("transaction id not available within transaction")
+	}
+	if id2, _ := transactionID(); id2 != id {
+		t.Fatalf("transaction id %d - expected %d", id2, id)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+
+	if id, ok := transactionID(); ok {
+		t.Fatalf("transaction id %d available after end of transaction", id)
+	}
+}
+
+func TestCancelSession(t *testing.T) {
+	t.Parallel()
+
+	connector := MT.NewConnector()
+	connector.SetCancelSession(true)
+	db := sql.OpenDB(connector)
+
+	// long running query
+	const query = "select count(*) from objects a, objects b, objects c, objects d"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var count int64
+	start := time.Now()
+	if err := conn.QueryRowContext(ctx, query).Scan(&count); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("error %v - expected %v", err, context.DeadlineExceeded)
+	}
+	// the cancelled call returns without waiting for the drain
+	if d := time.Since(start); d > 5*time.Second {
+		t.Fatalf("cancelled db call took %s", d)
+	}
+	// drained cancelled connection should be reusable
+	if err := conn.QueryRowContext(context.Background(), "select 1 from dummy").Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// closing the db waits for the cancelled connection to finish the db call
+	// which should return as soon as the session got cancelled via the control connection
+	start = time.Now()
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Since(start); d > 30*time.Second {
+		t.Fatalf("closing cancelled connection took %s", d)
+	}
+}
+
+func TestKeepAliveCheckout(t *testing.T) {
+	t.Parallel()
+
+	connector := MT.NewConnector()
+	connector.SetKeepAliveInterval(time.Millisecond)
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	sqlConn, err := db.Conn(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlConn.Close()
+
+	if err := sqlConn.Raw(func(driverConn any) error {
+		c := driverConn.(*conn)
+		for i := 0; i < 100; i++ {
+			if !c.IsValid() { // connection returned to pool: keep-alive pings are started
+				t.Fatal("connection is not valid")
+			}
+			time.Sleep(time.Millisecond)
+			// connection reused without session reset: keep-alive pings need to be stopped by the db call
+			rows, err := c.QueryContext(context.Background(), dummyQuery, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rows.Close()
+			c.keepAlive.mu.Lock()
+			idle := c.keepAlive.timer != nil
+			c.keepAlive.mu.Unlock()
+			if idle {
+				t.Fatal("connection in use is pinged")
+			}
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStreamResultsets(t *testing.T) {
+	t.Parallel()
+
+	const numRow = 1000
+	query := fmt.Sprintf("select generated_period_start, to_nvarchar(generated_period_start) from series_generate_integer(1, 0, %d) order by 1", numRow)
+
+	connector := MT.NewConnector()
+	connector.SetStreamResultsets(true)
+	connector.SetFetchSize(64) // multiple fetches
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	rows, err := db.Query(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	i := int64(0)
+	for rows.Next() {
+		var n int64
+		var s string
+		if err := rows.Scan(&n, &s); err != nil {
+			t.Fatal(err)
+		}
+		if n != i || s != strconv.FormatInt(i, 10) {
+			t.Fatalf("row %d: values %d %s - expected %d", i, n, s, i)
+		}
+		i++
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if i != numRow {
+		t.Fatalf("number of rows %d - expected %d", i, numRow)
+	}
+}
+
+func TestSessionTimezone(t *testing.T) {
+	t.Parallel()
+
+	connector := MT.NewConnector()
+	connector.SetSessionTimezone(true)
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	var ts time.Time
+	var utc string
+	var offset int
+	if err := db.QueryRow("select current_timestamp, to_varchar(current_utctimestamp, 'YYYY-MM-DD HH24:MI:SS.FF7'), seconds_between(current_utctimestamp, current_timestamp) from dummy").Scan(&ts, &utc, &offset); err != nil {
+		t.Fatal(err)
+	}
+	utcTime, err := time.Parse("2006-01-02 15:04:05.0000000", utc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// current timestamp interpreted in session time zone equals current utc timestamp
+	if !ts.Equal(utcTime) {
+		t.Fatalf("timestamp %v - expected %v", ts, utcTime)
+	}
+	if _, tsOffset := ts.Zone(); tsOffset != offset {
+		t.Fatalf("timestamp offset %d - expected %d", tsOffset, offset)
+	}
+}
+
 func TestConnection(t *testing.T) {
 	t.Parallel()
 
@@ -76,6 +330,9 @@ func TestConnection(t *testing.T) {
 	}{
 		{"cancelContext", testCancelContext},
 		{"checkCallStmt", testCheckCallStmt},
+		{"topology", testTopology},
+		{"transactionID", testTransactionID},
+		{"connStats", testConnStats},
 	}
 
 	db := MT.DB()
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path"
 	"sync"
+	"sync/atomic"
 
 	"github.com/SAP/go-hdb/driver/internal/protocol/auth"
 )
@@ -28,6 +29,10 @@ type Connector struct {
 	*authAttrs
 
 	metrics *metrics
+
+	warmUp warmUpConns // connections opened by the first connect (see SetWarmUpConns)
+
+	connectNo atomic.Uint64 // number of connects (host policy round robin)
 }
 
 // NewConnector returns a new Connector instance with default values.
@@ -81,6 +86,14 @@ func NewJWTAuthConnector(host, token string) *Connector {
 	return c
 }
 
+// NewAuthenticatorConnector creates a connector for authentication via custom authentication methods.
+func NewAuthenticatorConnector(host string, authenticator Authenticator) *Connector {
+	c := NewConnector()
+	c._host = host
+	c._authenticator = authenticator
+	return c
+}
+
 func newDSNConnector(dsn *DSN) (*Connector, error) {
 	c := NewConnector()
 	c._host = dsn.host
@@ -88,6 +101,13 @@ func newDSNConnector(dsn *DSN) (*Connector, error) {
 	c._pingInterval = dsn.pingInterval
 	c._defaultSchema = dsn.defaultSchema
 	c.setTimeout(dsn.timeout)
+	if dsn.durationUnit != 0 {
+		c._durationUnit = dsn.durationUnit
+	}
+	if dsn.lobReadChunkSize != 0 {
+		c.setLobReadChunkSize(dsn.lobReadChunkSize)
+	}
+	c._hostPolicy = dsn.hostPolicy
 	if dsn.tls != nil {
 		if err := c.connAttrs.setTLS(dsn.tls.ServerName, dsn.tls.InsecureSkipVerify, dsn.tls.RootCAFiles); err != nil {
 			return nil, err
@@ -111,6 +131,7 @@ func NewDSNConnector(dsnStr string) (*Connector, error) {
 func (c *Connector) NativeDriver() Driver { return stdHdbDriver }
 
 // Host returns the host of the connector.
+// In case of multiple hosts a comma separated host list is returned (see SetHostPolicy).
 func (c *Connector) Host() string { return c._host }
 
 // DatabaseName returns the tenant database name of the connector.
@@ -125,7 +146,9 @@ func (c *Connector) redirect(ctx context.Context) (driver.Conn, error) {
 		}
 	}
 
-	redirectHost, err := fetchRedirectHost(ctx, c._host, c._databaseName, c.metrics, connAttrs)
+	redirectHost, err := tryHosts(ctx, c.hosts(connAttrs._hostPolicy), func(host string) (string, error) {
+		return fetchRedirectHost(ctx, host, c._databaseName, c.metrics, connAttrs)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -141,10 +164,26 @@ func (c *Connector) redirect(ctx context.Context) (driver.Conn, error) {
 
 // Connect implements the database/sql/driver/Connector interface.
 func (c *Connector) Connect(ctx context.Context) (driver.Conn, error) {
+	return c.warmUp.connect(ctx, c.WarmUpConns(), c.connect)
+}
+
+// Close implements the io.Closer interface and closes the warm-up connections not handed out yet (see SetWarmUpConns).
+// Close is called by sql.DB Close.
+func (c *Connector) Close() error { return c.warmUp.close() }
+
+func (c *Connector) connect(ctx context.Context) (driver.Conn, error) {
 	if c._databaseName != "" {
 		return c.redirect(ctx)
 	}
-	return connect(ctx, c._host, c.metrics, c.connAttrs.clone(), c.authAttrs)
+	connAttrs := c.connAttrs.clone()
+	return tryHosts(ctx, c.hosts(connAttrs._hostPolicy), func(host string) (driver.Conn, error) {
+		return connect(ctx, host, c.metrics, connAttrs, c.authAttrs)
+	})
+}
+
+// hosts returns the hosts of the connector in the order defined by the host policy.
+func (c *Connector) hosts(policy HostPolicy) []string {
+	return policy.order(splitHosts(c._host), c.connectNo.Add(1)-1)
 }
 
 // Driver implements the database/sql/driver/Connector interface.
@@ -3,6 +3,7 @@
 package driver
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"testing"
@@ -94,6 +95,44 @@ func testSessionVariables(t *testing.T) {
 	// check if session variables are set after connect to db.
 	testExistSessionVariables(t, sv1, sv2)
 	testNotExistSessionVariables(t, []string{"k4"}, sv2)
+
+	// set and read session variables of a single connection
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if err := conn.Raw(func(driverConn any) error {
+		c := driverConn.(Conn)
+		ctx := context.Background()
+		if err := c.SetSessionVariable(ctx, "k4", "v4"); err != nil {
+			return err
+		}
+		v, err := c.SessionVariable(ctx, "k4")
+		if err != nil {
+			return err
+		}
+		if v != "v4" {
+			t.Fatalf("session variable value for k4 is %s - expected v4", v)
+		}
+		// override session variable for a single statement
+		if v, err = c.SessionVariable(WithSessionVariables(ctx, SessionVariables{"k4": "o4"}), "k4"); err != nil {
+			return err
+		}
+		if v != "o4" {
+			t.Fatalf("session variable value for k4 is %s - expected o4", v)
+		}
+		if v, err = c.SessionVariable(ctx, "k4"); err != nil {
+			return err
+		}
+		if v != "v4" {
+			t.Fatalf("session variable value for k4 is %s - expected v4", v)
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
 }
 
 func printInvalidConnectAttempts(t *testing.T, username string) {
@@ -25,7 +25,7 @@ func isNilArg(v any) bool {
 	return isNilArg(rv.Elem().Interface())
 }
 
-// TODO: test.
+// reorderNVArgs moves the argument named name to position pos shifting the arguments in between.
 func reorderNVArgs(pos int, name string, nvargs []driver.NamedValue) {
 	for i := pos; i < len(nvargs); i++ {
 		if nvargs[i].Name != "" && nvargs[i].Name == name {
@@ -38,6 +38,24 @@ func reorderNVArgs(pos int, name string, nvargs []driver.NamedValue) {
 	}
 }
 
+// checkNamedArgs returns an error in case nvargs mix named and positional arguments or contain duplicate names.
+func checkNamedArgs(nvargs []driver.NamedValue) error {
+	names := make(map[string]bool, len(nvargs))
+	for _, nvarg := range nvargs {
+		if nvarg.Name == "" {
+			continue
+		}
+		if names[nvarg.Name] {
+			return fmt.Errorf("invalid argument name %s - duplicate name", nvarg.Name)
+		}
+		names[nvarg.Name] = true
+	}
+	if len(names) != 0 && len(names) != len(nvargs) {
+		return fmt.Errorf("invalid arguments - named (%d) and positional (%d) arguments cannot be mixed", len(names), len(nvargs)-len(names))
+	}
+	return nil
+}
+
 func convertArg(field *p.ParameterField, arg driver.Value, cesu8Encoder transform.Transformer) (any, error) {
 	// let fields with own value converter convert themselves first (e.g. NullInt64, ...)
 	// .check nested Value converters as well (e.g. sql.Null[T] has driver.Decimal as value)
@@ -63,6 +81,12 @@ convertExecArgs
 */
 func convertExecArgs(fields []*p.ParameterField, nvargs []driver.NamedValue, cesu8Encoder transform.Transformer, lobChunkSize int) ([]int, error) {
 	numField := len(fields)
+	if numField == 0 { // statement without parameters: execute once
+		if len(nvargs) != 0 {
+			return nil, fmt.Errorf("invalid number of arguments %d - expected %d", len(nvargs), numField)
+		}
+		return []int{0}, nil
+	}
 	if (len(nvargs) % numField) != 0 {
 		return nil, fmt.Errorf("invalid number of arguments %d - multiple of %d expected", len(nvargs), numField)
 	}
@@ -167,6 +191,9 @@ func convertCallArgs(fields []*p.ParameterField, nvargs []driver.NamedValue, ces
 	}
 
 	prmnvargs := nvargs[:len(fields)]
+	if err := checkNamedArgs(prmnvargs); err != nil {
+		return nil, err
+	}
 
 	for i, field := range fields {
 		reorderNVArgs(i, field.Name(), prmnvargs)
@@ -182,27 +209,26 @@ func convertCallArgs(fields []*p.ParameterField, nvargs []driver.NamedValue, ces
 
 		out, isOut := nvarg.Value.(sql.Out)
 
-		var err error
 		if field.In() {
+			v := nvarg.Value
 			if isOut {
 				if !out.In {
 					return nil, fmt.Errorf("argument field %s mismatch - use in argument with out field", field)
 				}
-				if out.Dest, err = convertArg(field, out.Dest, cesu8Encoder); err != nil {
-					return nil, fmt.Errorf("field %s conversion error - %w", field, err)
-				}
-			} else {
-				if nvarg.Value, err = convertArg(field, nvarg.Value, cesu8Encoder); err != nil {
-					return nil, fmt.Errorf("field %s conversion error - %w", field, err)
-				}
+				v = out.Dest // input value of inout argument (out argument keeps the destination)
+			}
+			inArg := *nvarg
+			var err error
+			if inArg.Value, err = convertArg(field, v, cesu8Encoder); err != nil {
+				return nil, fmt.Errorf("field %s conversion error - %w", field, err)
 			}
 			// fetch first lob chunk
-			if lobInDescr, ok := nvarg.Value.(*p.LobInDescr); ok {
+			if lobInDescr, ok := inArg.Value.(*p.LobInDescr); ok {
 				if err := lobInDescr.FetchNext(lobChunkSize); err != nil {
 					return nil, err
 				}
 			}
-			callArgs.inArgs = append(callArgs.inArgs, *nvarg)
+			callArgs.inArgs = append(callArgs.inArgs, inArg)
 			callArgs.inFields = append(callArgs.inFields, field)
 		}
 
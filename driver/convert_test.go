@@ -0,0 +1,42 @@
+package driver
+
+import (
+	"database/sql/driver"
+	"slices"
+	"testing"
+)
+
+func TestReorderNVArgs(t *testing.T) {
+	nvargs := []driver.NamedValue{{Name: "C", Value: 3}, {Name: "A", Value: 1}, {Name: "B", Value: 2}}
+	for i, name := range []string{"A", "B", "C"} {
+		reorderNVArgs(i, name, nvargs)
+	}
+	names := make([]string, len(nvargs))
+	for i, nvarg := range nvargs {
+		names[i] = nvarg.Name
+		if nvarg.Value != i+1 {
+			t.Fatalf("argument %s value %v - expected %d", nvarg.Name, nvarg.Value, i+1)
+		}
+	}
+	if expected := []string{"A", "B", "C"}; !slices.Equal(names, expected) {
+		t.Fatalf("argument names %v - expected %v", names, expected)
+	}
+}
+
+func TestCheckNamedArgs(t *testing.T) {
+	testData := []struct {
+		nvargs []driver.NamedValue
+		valid  bool
+	}{
+		{nil, true},
+		{[]driver.NamedValue{{Value: 1}, {Value: 2}}, true},
+		{[]driver.NamedValue{{Name: "A"}, {Name: "B"}}, true},
+		{[]driver.NamedValue{{Name: "A"}, {Value: 2}}, false},
+		{[]driver.NamedValue{{Name: "A"}, {Name: "A"}}, false},
+	}
+	for i, d := range testData {
+		if err := checkNamedArgs(d.nvargs); (err == nil) != d.valid {
+			t.Fatalf("%d: error %v - expected valid %t", i, err, d.valid)
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"math/big"
 	"slices"
 	"testing"
+	"time"
 )
 
 // TestNull tests go1.22 using generic Null type with go-hdb types.
@@ -218,3 +219,60 @@ func TestNullDataType(t *testing.T) { //nolint:gocyclo
 		t.Fatal(err)
 	}
 }
+
+// TestNullArgs tests binding database/sql null types (converted via their driver.Valuer implementation).
+func TestNullArgs(t *testing.T) {
+	t.Parallel()
+
+	db := MT.DB()
+
+	tableName := RandomIdentifier("nullargs_")
+	if _, err := db.Exec(fmt.Sprintf("create table %s (no integer, b boolean, i bigint, f double, s nvarchar(20), t timestamp, g bigint)", tableName)); err != nil {
+		t.Fatal(err)
+	}
+
+	timeValue := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	testData := []struct {
+		b sql.NullBool
+		i sql.NullInt64
+		f sql.NullFloat64
+		s sql.NullString
+		t sql.NullTime
+		g sql.Null[int64]
+	}{
+		{},
+		{
+			b: sql.NullBool{Bool: true, Valid: true},
+			i: sql.NullInt64{Int64: 42, Valid: true},
+			f: sql.NullFloat64{Float64: 42.42, Valid: true},
+			s: sql.NullString{String: "Hello World", Valid: true},
+			t: sql.NullTime{Time: timeValue, Valid: true},
+			g: sql.Null[int64]{V: 4242, Valid: true},
+		},
+	}
+
+	for i, r := range testData {
+		if _, err := db.Exec(fmt.Sprintf("insert into %s values (?, ?, ?, ?, ?, ?, ?)", tableName), i, r.b, r.i, r.f, r.s, r.t, r.g); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i, r := range testData {
+		var (
+			b  sql.NullBool
+			n  sql.NullInt64
+			f  sql.NullFloat64
+			s  sql.NullString
+			tv sql.NullTime
+			g  sql.Null[int64]
+		)
+		if err := db.QueryRow(fmt.Sprintf("select b, i, f, s, t, g from %s where no = ?", tableName), i).Scan(&b, &n, &f, &s, &tv, &g); err != nil {
+			t.Fatal(err)
+		}
+		tv.Time = tv.Time.UTC()
+		if b != r.b || n != r.i || f != r.f || s != r.s || tv.Valid != r.t.Valid || !tv.Time.Equal(r.t.Time) || g != r.g {
+			t.Fatalf("row %d: got %v %v %v %v %v %v - expected %v %v %v %v %v %v", i, b, n, f, s, tv, g, r.b, r.i, r.f, r.s, r.t, r.g)
+		}
+	}
+}
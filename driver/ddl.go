@@ -0,0 +1,44 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+)
+
+// ErrDDLInTransaction is returned if a DDL statement is executed within an explicit transaction and the DDL policy is DDLPolicyError.
+var ErrDDLInTransaction = errors.New("DDL statement in transaction would commit the transaction implicitly")
+
+// DDLPolicy defines how DDL statements are handled within an explicit transaction.
+// As DDL statements are committed automatically by the database, a DDL statement commits the transaction in progress implicitly.
+type DDLPolicy int
+
+// DDLPolicy constants.
+const (
+	DDLPolicyAllow DDLPolicy = iota // DDL statements are executed (default).
+	DDLPolicyWarn                   // DDL statements are executed and a warning is logged.
+	DDLPolicyError                  // DDL statements are not executed and ErrDDLInTransaction is returned.
+)
+
+var ddlPolicyStrs = [...]string{"allow", "warn", "error"}
+
+func (dp DDLPolicy) String() string {
+	if int(dp) < 0 || int(dp) >= len(ddlPolicyStrs) {
+		return ""
+	}
+	return ddlPolicyStrs[dp]
+}
+
+// checkDDL checks a prepared statement against the DDL policy in case the connection is in a transaction.
+func (c *conn) checkDDL(ctx context.Context, pr *prepareResult, query string) error {
+	if !c.inTx || !pr.isDDL() {
+		return nil
+	}
+	switch c.attrs._ddlPolicy {
+	case DDLPolicyWarn:
+		c.logger.LogAttrs(ctx, slog.LevelWarn, "DDL statement commits transaction implicitly", slog.String("query", query))
+	case DDLPolicyError:
+		return ErrDDLInTransaction
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package driver
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	p "github.com/SAP/go-hdb/driver/internal/protocol"
+)
+
+func TestCheckDDL(t *testing.T) {
+	const query = "create table t (i integer)"
+
+	ddl := &prepareResult{fc: p.FcDDL}
+	dml := &prepareResult{fc: 2} // insert
+
+	testData := []struct {
+		policy DDLPolicy
+		inTx   bool
+		pr     *prepareResult
+		err    error
+		warn   bool
+	}{
+		{DDLPolicyAllow, true, ddl, nil, false},
+		{DDLPolicyWarn, true, ddl, nil, true},
+		{DDLPolicyError, true, ddl, ErrDDLInTransaction, false},
+		{DDLPolicyError, false, ddl, nil, false}, // no transaction
+		{DDLPolicyError, true, dml, nil, false},  // no DDL
+	}
+
+	for _, r := range testData {
+		buf := new(bytes.Buffer)
+		attrs := newConnAttrs()
+		attrs.SetDDLPolicy(r.policy)
+		c := &conn{attrs: attrs, inTx: r.inTx, logger: slog.New(slog.NewTextHandler(buf, nil))}
+
+		err := c.checkDDL(context.Background(), r.pr, query)
+		if !errors.Is(err, r.err) {
+			t.Fatalf("policy %s: error %v - expected %v", r.policy, err, r.err)
+		}
+		if warn := strings.Contains(buf.String(), "level=WARN"); warn != r.warn {
+			t.Fatalf("policy %s: warning logged %t - expected %t", r.policy, warn, r.warn)
+		}
+	}
+}
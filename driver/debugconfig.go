@@ -0,0 +1,147 @@
+package driver
+
+import (
+	"crypto/tls"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/SAP/go-hdb/driver/unicode/cesu8"
+	"golang.org/x/text/transform"
+)
+
+// redacted is the placeholder for secrets in the debug configuration.
+const redacted = "***"
+
+func redactString(s string) string {
+	if s == "" {
+		return ""
+	}
+	return redacted
+}
+
+func isSet(b bool) string {
+	if b {
+		return "set"
+	}
+	return ""
+}
+
+func transformerName(fn, defaultFn func() transform.Transformer) string {
+	if reflect.ValueOf(fn).Pointer() == reflect.ValueOf(defaultFn).Pointer() {
+		return "default"
+	}
+	return "custom"
+}
+
+func (c *connAttrs) debugConfig(m map[string]string) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	m["timeout"] = c._timeout.String()
+	m["pingInterval"] = c._pingInterval.String()
+	m["keepAliveInterval"] = c._keepAliveInterval.String()
+	m["bufferSize"] = strconv.Itoa(c._bufferSize)
+	m["bulkSize"] = strconv.Itoa(c._bulkSize)
+	m["tcpKeepAlive"] = c._tcpKeepAlive.String()
+	if c._tlsConfig != nil {
+		m["tls"] = "true"
+		m["tlsServerName"] = c._tlsConfig.ServerName
+		m["tlsInsecureSkipVerify"] = strconv.FormatBool(c._tlsConfig.InsecureSkipVerify)
+		m["tlsMinVersion"] = tls.VersionName(c._tlsConfig.MinVersion)
+		m["tlsRootCAs"] = isSet(c._tlsConfig.RootCAs != nil)
+	} else {
+		m["tls"] = "false"
+	}
+	m["defaultSchema"] = c._defaultSchema
+	m["connectInitSQL"] = strconv.Itoa(len(c._connectInitSQL)) // statements might contain sensitive data
+	m["dialer"] = fmt.Sprintf("%T", c._dialer)
+	if c._proxyURL != nil {
+		m["proxyURL"] = c._proxyURL.Redacted() // mask password
+	}
+	m["applicationName"] = c._applicationName
+	sessionVariables := make([]string, 0, len(c._sessionVariables))
+	for k := range c._sessionVariables {
+		sessionVariables = append(sessionVariables, k) // values might be sensitive
+	}
+	slices.Sort(sessionVariables)
+	m["sessionVariables"] = fmt.Sprintf("%v", sessionVariables)
+	m["locale"] = c._locale
+	m["fetchSize"] = strconv.Itoa(c._fetchSize)
+	m["fetchSizeBounds"] = fmt.Sprintf("[%d %d]", c._fetchSizeMin, c._fetchSizeMax)
+	m["memoryPressure"] = isSet(c._memoryPressure != nil)
+	m["lobChunkSize"] = strconv.Itoa(c._lobChunkSize)
+	m["lobReadChunkSize"] = strconv.Itoa(c.lobReadChunkSize())
+	m["lobPrefetch"] = strconv.FormatBool(c._lobPrefetch)
+	m["streamResultsets"] = strconv.FormatBool(c._streamResultsets)
+	m["handshakeTimeout"] = c._handshakeTimeout.String()
+	m["dfv"] = strconv.Itoa(c._dfv)
+	m["cesu8Decoder"] = transformerName(c._cesu8Decoder, cesu8.DefaultDecoder)
+	m["cesu8Encoder"] = transformerName(c._cesu8Encoder, cesu8.DefaultEncoder)
+	m["emptyDateAsNull"] = strconv.FormatBool(c._emptyDateAsNull)
+	m["emptyStringAsNull"] = strconv.FormatBool(c._emptyStringAsNull)
+	m["failOnStandby"] = strconv.FormatBool(c._failOnStandby)
+	m["cancelSession"] = strconv.FormatBool(c._cancelSession)
+	m["compression"] = strconv.FormatBool(c._compression)
+	m["onUnknownPart"] = isSet(c._onUnknownPart != nil)
+	m["logUnknownParts"] = strconv.FormatBool(c._logUnknownParts)
+	m["checkConcurrentUse"] = strconv.FormatBool(c._checkConcurrentUse)
+	m["rawColumn"] = strconv.Itoa(c._rawColumn)
+	m["onRawColumn"] = isSet(c._onRawColumn != nil)
+	m["errorPolicy"] = c._errorPolicy.String()
+	m["maxWarnings"] = strconv.Itoa(c._maxWarnings)
+	m["onWarning"] = isSet(c._onWarning != nil)
+	m["onResetSession"] = isSet(c._onResetSession != nil)
+	m["resetSessionVariables"] = strconv.FormatBool(c._resetSessionVars)
+	m["retryPolicy"] = c._retryPolicy.String()
+	m["stmtCacheSize"] = strconv.Itoa(c._stmtCacheSize)
+	m["warmUpConns"] = strconv.Itoa(c._warmUpConns)
+	m["ddlPolicy"] = c._ddlPolicy.String()
+	m["hostPolicy"] = c._hostPolicy.String()
+	m["commandInfo"] = strconv.FormatBool(c._commandInfo)
+	m["durationUnit"] = c._durationUnit.String()
+	m["nullFloatAsNaN"] = strconv.FormatBool(c._nullFloatAsNaN)
+	m["decimalAsText"] = strconv.FormatBool(c._decimalAsText)
+	m["sessionTimezone"] = strconv.FormatBool(c._sessionTimezone)
+	m["protTraceWriter"] = isSet(c._protTraceWriter != nil)
+}
+
+func (c *authAttrs) debugConfig(m map[string]string) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	m["username"] = c._username
+	m["password"] = redactString(c._password)
+	m["clientCert"] = isSet(c._certKey != nil)
+	m["token"] = redactString(c._token)
+	m["refreshPassword"] = isSet(c._refreshPassword != nil)
+	m["refreshClientCert"] = isSet(c._refreshClientCert != nil)
+	m["refreshToken"] = isSet(c._refreshToken != nil)
+	m["sessionCookie"] = isSet(c.hasCookie.Load())
+	m["sessionCookieDisabled"] = strconv.FormatBool(c._noSessionCookie)
+}
+
+/*
+DebugConfig returns the effective configuration of the connector for debugging and support purposes.
+Secrets like passwords, tokens and session cookies are masked, the values of session variables are omitted.
+*/
+func (c *Connector) DebugConfig() map[string]string {
+	m := map[string]string{
+		"host":                 c._host,
+		"databaseName":         c._databaseName,
+		"driverVersion":        DriverVersion,
+		"protTrace":            strconv.FormatBool(protTrace.Load()),
+		"protTraceRedact":      strconv.FormatBool(protTraceRedact.Load()),
+		"protTraceStructured":  strconv.FormatBool(protTraceStructured.Load()),
+		"protTraceOmitValues":  strconv.FormatBool(protTraceOmitValues.Load()),
+		"sqlTrace":             strconv.FormatBool(sqlTrace.Load()),
+		"statsTimeUnit":        statsCfg.TimeUnit,
+		"statsTimeUpperBounds": strings.Trim(fmt.Sprint(statsCfg.TimeUpperBounds), "[]"),
+		"statsSQLTimeSamples":  strconv.Itoa(SQLTimeSamples()),
+	}
+	c.connAttrs.debugConfig(m)
+	c.authAttrs.debugConfig(m)
+	return m
+}
@@ -0,0 +1,26 @@
+package driver
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDebugConfig(t *testing.T) {
+	const secret = "secret"
+
+	for _, connector := range []*Connector{
+		NewBasicAuthConnector("host:30015", "user", secret),
+		NewJWTAuthConnector("host:30015", secret),
+	} {
+		connector.SetSessionVariables(SessionVariables{"key": secret})
+		config := connector.DebugConfig()
+		if config["host"] != "host:30015" {
+			t.Fatalf("host %s - expected %s", config["host"], "host:30015")
+		}
+		for k, v := range config {
+			if strings.Contains(v, secret) {
+				t.Fatalf("secret not masked in %s: %s", k, v)
+			}
+		}
+	}
+}
@@ -10,12 +10,20 @@ import (
 type Decimal big.Rat
 
 // Scan implements the database/sql/Scanner interface.
+// Besides *big.Rat values decimal text values are supported (see SetDecimalAsText).
 func (d *Decimal) Scan(src any) error {
-	r, ok := src.(*big.Rat)
-	if !ok {
+	switch src := src.(type) {
+	case *big.Rat:
+		(*big.Rat)(d).Set(src)
+	case string:
+		if _, ok := (*big.Rat)(d).SetString(src); !ok {
+			return fmt.Errorf("decimal: invalid value %s", src)
+		}
+	case []byte:
+		return d.Scan(string(src))
+	default:
 		return fmt.Errorf("decimal: invalid data type %T", src)
 	}
-	(*big.Rat)(d).Set(r)
 	return nil
 }
 
@@ -34,19 +42,17 @@ type NullDecimal struct {
 
 // Scan implements the Scanner interface.
 func (n *NullDecimal) Scan(value any) error {
+	n.Valid = false
 	if value == nil {
-		n.Valid = false
 		return nil
 	}
-	r, ok := value.(*big.Rat)
-	if !ok {
-		return fmt.Errorf("decimal: invalid data type %T", value)
-	}
-	n.Valid = true
 	if n.Decimal == nil {
 		n.Decimal = &Decimal{}
 	}
-	(*big.Rat)(n.Decimal).Set(r)
+	if err := n.Decimal.Scan(value); err != nil {
+		return err
+	}
+	n.Valid = true
 	return nil
 }
 
@@ -60,3 +66,18 @@ func (n NullDecimal) Value() (driver.Value, error) {
 	}
 	return (*big.Rat)(n.Decimal), nil
 }
+
+// decimalDigits returns the number of fractional digits needed to represent a decimal with denominator denom exactly.
+func decimalDigits(denom *big.Int) int {
+	twos := int(denom.TrailingZeroBits())
+	d := new(big.Int).Rsh(denom, uint(twos))
+	q, r, five := new(big.Int), new(big.Int), big.NewInt(5)
+	fives := 0
+	for {
+		if q.QuoRem(d, five, r); r.Sign() != 0 {
+			return max(twos, fives)
+		}
+		d, q = q, d
+		fives++
+	}
+}
@@ -0,0 +1,26 @@
+package driver
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestNullDecimalScan(t *testing.T) {
+	var n NullDecimal
+
+	if err := n.Scan("1.5"); err != nil {
+		t.Fatal(err)
+	}
+	if !n.Valid || (*big.Rat)(n.Decimal).Cmp(big.NewRat(3, 2)) != 0 {
+		t.Fatalf("value %v valid %t - expected %v", n.Decimal, n.Valid, big.NewRat(3, 2))
+	}
+	if err := n.Scan(true); err == nil {
+		t.Fatal("invalid data type: error expected")
+	}
+	if n.Valid {
+		t.Fatal("invalid data type: valid value")
+	}
+	if err := n.Scan(nil); err != nil || n.Valid {
+		t.Fatalf("null value: error %v valid %t", err, n.Valid)
+	}
+}
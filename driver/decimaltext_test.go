@@ -0,0 +1,62 @@
+//go:build !unit
+
+package driver
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+)
+
+// testTextDecimal is a decimal type scanning text values only (like third party decimal libraries).
+type testTextDecimal struct{ s string }
+
+func (d *testTextDecimal) Scan(src any) error {
+	s, ok := src.(string)
+	if !ok {
+		return fmt.Errorf("unsupported source type %T", src)
+	}
+	d.s = s
+	return nil
+}
+
+func TestDecimalAsText(t *testing.T) {
+	t.Parallel()
+
+	connector := MT.NewConnector()
+	connector.SetDecimalAsText(true)
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	testData := []struct {
+		sqlType string
+		v       any
+		text    string
+	}{
+		{"decimal(10,2)", "-1.5", "-1.50"},
+		{"decimal(10,0)", "42", "42"},
+		{"decimal(10,3)", "0", "0.000"},
+		{"decimal(38,0)", "99999999999999999999999999999999999999", "99999999999999999999999999999999999999"},
+		{"decimal(38,38)", "-0.99999999999999999999999999999999999999", "-0.99999999999999999999999999999999999999"},
+		{"decimal(38,10)", "-1234567890123456789012345678.0123456789", "-1234567890123456789012345678.0123456789"},
+	}
+
+	for _, d := range testData {
+		var td testTextDecimal
+		if err := db.QueryRow(fmt.Sprintf("select cast(? as %s) from dummy", d.sqlType), d.v).Scan(&td); err != nil {
+			t.Fatal(err)
+		}
+		if td.s != d.text {
+			t.Fatalf("%s value %v: text %s - expected %s", d.sqlType, d.v, td.s, d.text)
+		}
+
+		// Decimal supports text values.
+		var dec NullDecimal
+		if err := db.QueryRow(fmt.Sprintf("select cast(? as %s) from dummy", d.sqlType), d.v).Scan(&dec); err != nil {
+			t.Fatal(err)
+		}
+		if !dec.Valid {
+			t.Fatalf("%s value %v: invalid decimal", d.sqlType, d.v)
+		}
+	}
+}
@@ -18,6 +18,31 @@ type Dialer interface {
 	DialContext(ctx context.Context, address string, options DialerOptions) (net.Conn, error)
 }
 
+/*
+DialerFunc is an adapter to use a function with the signature of net.Dialer DialContext as Dialer, e.g. to
+connect via unix domain sockets or a service mesh, or to inject a custom net.Conn implementation in tests:
+
+	connector.SetDialer(dial.DialerFunc(func(ctx context.Context, network, address string) (net.Conn, error) {
+		var d net.Dialer
+		return d.DialContext(ctx, "unix", "/var/run/hana.sock")
+	}))
+
+The network passed to the function is "tcp". The timeout of the dialer options is applied as context deadline,
+the TCP keep-alive option is ignored. Read and write errors of the returned connection are handled by the driver
+like errors of a TCP connection (see driver.ErrBadConn).
+*/
+type DialerFunc func(ctx context.Context, network, address string) (net.Conn, error)
+
+// DialContext implements the Dialer interface.
+func (f DialerFunc) DialContext(ctx context.Context, address string, options DialerOptions) (net.Conn, error) {
+	if options.Timeout != 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+	}
+	return f(ctx, "tcp", address)
+}
+
 // DefaultDialer is the default driver Dialer implementation.
 var DefaultDialer Dialer = &dialer{}
 
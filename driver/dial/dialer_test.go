@@ -0,0 +1,30 @@
+package dial
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestDialerFunc(t *testing.T) {
+	const address = "hanahost:30015"
+
+	var d Dialer = DialerFunc(func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if network != "tcp" || addr != address {
+			t.Fatalf("network %s address %s - expected tcp %s", network, addr, address)
+		}
+		if _, ok := ctx.Deadline(); !ok {
+			t.Fatal("context deadline expected")
+		}
+		client, server := net.Pipe()
+		server.Close()
+		return client, nil
+	})
+
+	conn, err := d.DialContext(context.Background(), address, DialerOptions{Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.Close()
+}
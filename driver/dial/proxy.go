@@ -0,0 +1,284 @@
+package dial
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Proxy errors.
+var (
+	ErrUnsupportedProxyScheme = errors.New("unsupported proxy scheme")
+	ErrProxyHandshake         = errors.New("proxy handshake failed")
+)
+
+// proxy URL schemes.
+const (
+	schemeSOCKS5  = "socks5"
+	schemeSOCKS5H = "socks5h"
+	schemeHTTP    = "http"
+)
+
+const defaultHTTPProxyPort = "80"
+const defaultSOCKS5ProxyPort = "1080"
+
+/*
+NewProxyDialer returns a Dialer tunneling the database connection through the proxy defined by proxyURL.
+
+Supported are SOCKS5 proxies (URL scheme socks5 or socks5h) and HTTP proxies supporting the CONNECT method
+(URL scheme http). User credentials of the URL are used to authenticate at the proxy (username / password
+authentication for SOCKS5, basic authentication for HTTP). The database host name is resolved by the proxy.
+
+The connection to the proxy is established by forward (DefaultDialer if nil). The proxy handshake respects
+the context deadline and cancellation as well as the timeout of the dialer options.
+*/
+func NewProxyDialer(proxyURL *url.URL, forward Dialer) (Dialer, error) {
+	if proxyURL == nil {
+		return nil, fmt.Errorf("%w: missing proxy URL", ErrUnsupportedProxyScheme)
+	}
+	if forward == nil {
+		forward = DefaultDialer
+	}
+	d := &proxyDialer{forward: forward, user: proxyURL.User}
+	var defaultPort string
+	switch proxyURL.Scheme {
+	case schemeSOCKS5, schemeSOCKS5H:
+		d.handshake, defaultPort = socks5Connect, defaultSOCKS5ProxyPort
+	case schemeHTTP:
+		d.handshake, defaultPort = httpConnect, defaultHTTPProxyPort
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedProxyScheme, proxyURL.Scheme)
+	}
+	d.address = proxyURL.Host
+	if proxyURL.Port() == "" {
+		d.address = net.JoinHostPort(proxyURL.Hostname(), defaultPort)
+	}
+	return d, nil
+}
+
+type proxyDialer struct {
+	forward   Dialer
+	address   string
+	user      *url.Userinfo
+	handshake func(rw *bufio.ReadWriter, address string, user *url.Userinfo) error
+}
+
+func (d *proxyDialer) DialContext(ctx context.Context, address string, options DialerOptions) (net.Conn, error) {
+	conn, err := d.forward.DialContext(ctx, d.address, options)
+	if err != nil {
+		return nil, err
+	}
+	if err := d.connect(ctx, conn, address, options.Timeout); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("proxy %s: %w", d.address, err)
+	}
+	return conn, nil
+}
+
+func (d *proxyDialer) connect(ctx context.Context, conn net.Conn, address string, timeout time.Duration) (err error) {
+	if timeout != 0 {
+		conn.SetDeadline(time.Now().Add(timeout)) //nolint:errcheck
+		defer conn.SetDeadline(time.Time{})       //nolint:errcheck
+	}
+	// interrupt handshake in case the context is done (cancelled or deadline exceeded).
+	stop := context.AfterFunc(ctx, func() { conn.SetDeadline(time.Unix(1, 0)) }) //nolint:errcheck
+	defer func() {
+		if !stop() {
+			err = ctx.Err()
+		}
+	}()
+
+	rd := bufio.NewReader(conn)
+	if err := d.handshake(bufio.NewReadWriter(rd, bufio.NewWriter(conn)), address, d.user); err != nil {
+		return err
+	}
+	if rd.Buffered() != 0 { // database server does not send data before the client
+		return fmt.Errorf("%w: unexpected data after handshake", ErrProxyHandshake)
+	}
+	return nil
+}
+
+// httpConnect establishes a tunnel via the HTTP CONNECT method.
+func httpConnect(rw *bufio.ReadWriter, address string, user *url.Userinfo) error {
+	fmt.Fprintf(rw, "CONNECT %s HTTP/1.1\r\nHost: %s\r\n", address, address)
+	if user != nil {
+		password, _ := user.Password()
+		fmt.Fprintf(rw, "Proxy-Authorization: Basic %s\r\n", base64.StdEncoding.EncodeToString([]byte(user.Username()+":"+password)))
+	}
+	rw.WriteString("\r\n") //nolint:errcheck
+	if err := rw.Flush(); err != nil {
+		return err
+	}
+
+	tp := textproto.NewReader(rw.Reader)
+	line, err := tp.ReadLine()
+	if err != nil {
+		return err
+	}
+	// status line: HTTP/1.1 200 Connection established
+	_, status, ok := strings.Cut(line, " ")
+	if !ok || !strings.HasPrefix(line, "HTTP/") {
+		return fmt.Errorf("%w: invalid status line %q", ErrProxyHandshake, line)
+	}
+	if code, _, _ := strings.Cut(status, " "); code != "200" {
+		return fmt.Errorf("%w: %s", ErrProxyHandshake, status)
+	}
+	if _, err := tp.ReadMIMEHeader(); err != nil { // skip header
+		return err
+	}
+	return nil
+}
+
+// SOCKS5 protocol constants (see RFC 1928 and RFC 1929).
+const (
+	socks5Version = 0x05
+
+	socks5AuthNone         = 0x00
+	socks5AuthPassword     = 0x02
+	socks5AuthNoAcceptable = 0xff
+
+	socks5AuthPasswordVersion = 0x01
+
+	socks5CmdConnect = 0x01
+
+	socks5AtypIPv4   = 0x01
+	socks5AtypDomain = 0x03
+	socks5AtypIPv6   = 0x04
+)
+
+var socks5Replies = []string{
+	"succeeded",
+	"general SOCKS server failure",
+	"connection not allowed by ruleset",
+	"network unreachable",
+	"host unreachable",
+	"connection refused",
+	"TTL expired",
+	"command not supported",
+	"address type not supported",
+}
+
+func socks5Reply(code byte) string {
+	if int(code) < len(socks5Replies) {
+		return socks5Replies[code]
+	}
+	return "unknown reply " + strconv.Itoa(int(code))
+}
+
+// socks5Connect establishes a tunnel via the SOCKS5 CONNECT command.
+func socks5Connect(rw *bufio.ReadWriter, address string, user *url.Userinfo) error {
+	host, portStr, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return fmt.Errorf("invalid port %s: %w", portStr, err)
+	}
+
+	// authentication method negotiation
+	method := byte(socks5AuthNone)
+	if user != nil {
+		method = socks5AuthPassword
+	}
+	rw.Write([]byte{socks5Version, 1, method}) //nolint:errcheck
+	if err := rw.Flush(); err != nil {
+		return err
+	}
+	buf := make([]byte, 2)
+	if _, err := io.ReadFull(rw, buf); err != nil {
+		return err
+	}
+	if buf[0] != socks5Version {
+		return fmt.Errorf("%w: invalid SOCKS version %d", ErrProxyHandshake, buf[0])
+	}
+	switch buf[1] {
+	case method:
+	case socks5AuthNoAcceptable:
+		return fmt.Errorf("%w: no acceptable authentication method", ErrProxyHandshake)
+	default:
+		return fmt.Errorf("%w: unexpected authentication method %d", ErrProxyHandshake, buf[1])
+	}
+
+	if method == socks5AuthPassword {
+		username := user.Username()
+		password, _ := user.Password()
+		if len(username) > 255 || len(password) > 255 {
+			return fmt.Errorf("%w: username or password too long", ErrProxyHandshake)
+		}
+		rw.WriteByte(socks5AuthPasswordVersion) //nolint:errcheck
+		rw.WriteByte(byte(len(username)))       //nolint:errcheck
+		rw.WriteString(username)                //nolint:errcheck
+		rw.WriteByte(byte(len(password)))       //nolint:errcheck
+		rw.WriteString(password)                //nolint:errcheck
+		if err := rw.Flush(); err != nil {
+			return err
+		}
+		if _, err := io.ReadFull(rw, buf); err != nil {
+			return err
+		}
+		if buf[1] != 0 {
+			return fmt.Errorf("%w: authentication failed", ErrProxyHandshake)
+		}
+	}
+
+	// connect request
+	rw.Write([]byte{socks5Version, socks5CmdConnect, 0}) //nolint:errcheck
+	if ip := net.ParseIP(host); ip != nil {
+		if ip4 := ip.To4(); ip4 != nil {
+			rw.WriteByte(socks5AtypIPv4) //nolint:errcheck
+			rw.Write(ip4)                //nolint:errcheck
+		} else {
+			rw.WriteByte(socks5AtypIPv6) //nolint:errcheck
+			rw.Write(ip.To16())          //nolint:errcheck
+		}
+	} else {
+		if len(host) > 255 {
+			return fmt.Errorf("%w: host name too long", ErrProxyHandshake)
+		}
+		rw.WriteByte(socks5AtypDomain) //nolint:errcheck
+		rw.WriteByte(byte(len(host)))  //nolint:errcheck
+		rw.WriteString(host)           //nolint:errcheck
+	}
+	rw.Write([]byte{byte(port >> 8), byte(port)}) //nolint:errcheck
+	if err := rw.Flush(); err != nil {
+		return err
+	}
+
+	// connect reply: version, reply, reserved, address type, bound address, bound port
+	buf = make([]byte, 4)
+	if _, err := io.ReadFull(rw, buf); err != nil {
+		return err
+	}
+	if buf[1] != 0 {
+		return fmt.Errorf("%w: %s", ErrProxyHandshake, socks5Reply(buf[1]))
+	}
+	var addrLen int
+	switch buf[3] {
+	case socks5AtypIPv4:
+		addrLen = net.IPv4len
+	case socks5AtypIPv6:
+		addrLen = net.IPv6len
+	case socks5AtypDomain:
+		n, err := rw.ReadByte()
+		if err != nil {
+			return err
+		}
+		addrLen = int(n)
+	default:
+		return fmt.Errorf("%w: invalid address type %d", ErrProxyHandshake, buf[3])
+	}
+	if _, err := io.ReadFull(rw, make([]byte, addrLen+2)); err != nil { // skip bound address and port
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package dial
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"net"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testProxyUser     = "user"
+	testProxyPassword = "secret"
+	testProxyTarget   = "hanahost:30015"
+)
+
+// testSOCKS5Proxy handles a SOCKS5 handshake with username / password authentication.
+func testSOCKS5Proxy(rw *bufio.ReadWriter) (string, bool) {
+	buf := make([]byte, 3)
+	if _, err := io.ReadFull(rw, buf); err != nil || buf[2] != socks5AuthPassword {
+		return "", false
+	}
+	rw.Write([]byte{socks5Version, socks5AuthPassword}) //nolint:errcheck
+	rw.Flush()                                          //nolint:errcheck
+
+	readString := func() string {
+		n, _ := rw.ReadByte()
+		b := make([]byte, n)
+		io.ReadFull(rw, b) //nolint:errcheck
+		return string(b)
+	}
+	rw.ReadByte() //nolint:errcheck // version
+	if readString() != testProxyUser || readString() != testProxyPassword {
+		rw.Write([]byte{socks5AuthPasswordVersion, 1}) //nolint:errcheck
+		rw.Flush()                                     //nolint:errcheck
+		return "", false
+	}
+	rw.Write([]byte{socks5AuthPasswordVersion, 0}) //nolint:errcheck
+	rw.Flush()                                     //nolint:errcheck
+
+	buf = make([]byte, 4)
+	if _, err := io.ReadFull(rw, buf); err != nil || buf[3] != socks5AtypDomain {
+		return "", false
+	}
+	host := readString()
+	port := make([]byte, 2)
+	io.ReadFull(rw, port)                                                     //nolint:errcheck
+	rw.Write([]byte{socks5Version, 0, 0, socks5AtypIPv4, 127, 0, 0, 1, 0, 0}) //nolint:errcheck
+	rw.Flush()                                                                //nolint:errcheck
+	return net.JoinHostPort(host, strconv.Itoa(int(port[0])<<8|int(port[1]))), true
+}
+
+// testHTTPProxy handles a HTTP CONNECT request with basic authentication.
+func testHTTPProxy(rw *bufio.ReadWriter) (string, bool) {
+	tp := textproto.NewReader(rw.Reader)
+	line, err := tp.ReadLine()
+	if err != nil {
+		return "", false
+	}
+	header, err := tp.ReadMIMEHeader()
+	if err != nil {
+		return "", false
+	}
+	if header.Get("Proxy-Authorization") != "Basic "+base64.StdEncoding.EncodeToString([]byte(testProxyUser+":"+testProxyPassword)) {
+		rw.WriteString("HTTP/1.1 407 Proxy Authentication Required\r\n\r\n") //nolint:errcheck
+		rw.Flush()                                                           //nolint:errcheck
+		return "", false
+	}
+	rw.WriteString("HTTP/1.1 200 Connection established\r\n\r\n") //nolint:errcheck
+	rw.Flush()                                                    //nolint:errcheck
+	fields := strings.Fields(line)                                // CONNECT <address> HTTP/1.1
+	if len(fields) != 3 {
+		return "", false
+	}
+	return fields[1], true
+}
+
+// testProxy starts a proxy server which echos the data of a tunneled connection.
+func testProxy(t *testing.T, handshake func(rw *bufio.ReadWriter) (string, bool)) (string, <-chan string) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	targetCh := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		rw := bufio.NewReadWriter(bufio.NewReader(conn), bufio.NewWriter(conn))
+		target, ok := handshake(rw)
+		targetCh <- target
+		if !ok {
+			return
+		}
+		io.Copy(conn, rw) //nolint:errcheck
+	}()
+	return ln.Addr().String(), targetCh
+}
+
+func TestProxyDialer(t *testing.T) {
+	testData := []struct {
+		scheme    string
+		handshake func(rw *bufio.ReadWriter) (string, bool)
+	}{
+		{schemeSOCKS5, testSOCKS5Proxy},
+		{schemeHTTP, testHTTPProxy},
+	}
+
+	for _, r := range testData {
+		t.Run(r.scheme, func(t *testing.T) {
+			t.Run("connect", func(t *testing.T) {
+				address, targetCh := testProxy(t, r.handshake)
+				d, err := NewProxyDialer(&url.URL{Scheme: r.scheme, Host: address, User: url.UserPassword(testProxyUser, testProxyPassword)}, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+				conn, err := d.DialContext(context.Background(), testProxyTarget, DialerOptions{Timeout: 5 * time.Second})
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer conn.Close()
+				if target := <-targetCh; target != testProxyTarget {
+					t.Fatalf("target %s - expected %s", target, testProxyTarget)
+				}
+				const msg = "ping"
+				if _, err := conn.Write([]byte(msg)); err != nil {
+					t.Fatal(err)
+				}
+				b := make([]byte, len(msg))
+				if _, err := io.ReadFull(conn, b); err != nil {
+					t.Fatal(err)
+				}
+				if string(b) != msg {
+					t.Fatalf("message %s - expected %s", b, msg)
+				}
+			})
+
+			t.Run("authentication", func(t *testing.T) {
+				address, _ := testProxy(t, r.handshake)
+				d, err := NewProxyDialer(&url.URL{Scheme: r.scheme, Host: address, User: url.UserPassword(testProxyUser, "invalid")}, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if _, err := d.DialContext(context.Background(), testProxyTarget, DialerOptions{Timeout: 5 * time.Second}); !errors.Is(err, ErrProxyHandshake) {
+					t.Fatalf("error %v - expected %v", err, ErrProxyHandshake)
+				}
+			})
+
+			t.Run("cancel", func(t *testing.T) {
+				ln, err := net.Listen("tcp", "127.0.0.1:0") // proxy not answering
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer ln.Close()
+				d, err := NewProxyDialer(&url.URL{Scheme: r.scheme, Host: ln.Addr().String()}, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+				ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+				defer cancel()
+				if _, err := d.DialContext(ctx, testProxyTarget, DialerOptions{}); !errors.Is(err, context.DeadlineExceeded) {
+					t.Fatalf("error %v - expected %v", err, context.DeadlineExceeded)
+				}
+			})
+		})
+	}
+
+	if _, err := NewProxyDialer(&url.URL{Scheme: "https", Host: "proxy"}, nil); !errors.Is(err, ErrUnsupportedProxyScheme) {
+		t.Fatalf("error %v - expected %v", err, ErrUnsupportedProxyScheme)
+	}
+}
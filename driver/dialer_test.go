@@ -0,0 +1,32 @@
+package driver
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/SAP/go-hdb/driver/dial"
+)
+
+func TestDialerFunc(t *testing.T) {
+	const prologSize = 14
+
+	connector := NewBasicAuthConnector("hanahost:30015", "user", "password")
+	connector.SetTimeout(5 * time.Second)
+	connector.SetDialer(dial.DialerFunc(func(ctx context.Context, network, address string) (net.Conn, error) {
+		client, server := net.Pipe()
+		go func() { // fake server closing the connection after reading the prolog.
+			defer server.Close()
+			io.ReadFull(server, make([]byte, prologSize)) //nolint:errcheck
+		}()
+		return client, nil
+	}))
+
+	if _, err := connector.Connect(context.Background()); !errors.Is(err, driver.ErrBadConn) {
+		t.Fatalf("error %v - expected %v", err, driver.ErrBadConn)
+	}
+}
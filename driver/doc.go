@@ -1,4 +1,59 @@
 // Package driver is a native Go SAP HANA driver implementation for the database/sql package.
 // For the SAP HANA SQL Command Network Protocol Reference please see:
 // https://help.sap.com/viewer/7e4aba181371442d9e4395e7ff71b777/2.0.03/en-US/9b9d8c894343424fac157c96dcb0a592.html
+//
+// # Array types
+//
+// Array data types (e.g. BOOLEAN ARRAY) are not supported: array values are neither encoded nor decoded by
+// the driver, so that binding slices like []bool to array parameters or scanning array columns is not possible.
+//
+// # Time zones
+//
+// SAP HANA does not provide a TIMESTAMP WITH TIME ZONE data type: DATE, TIME, SECONDDATE and
+// TIMESTAMP values are stored without any time zone or offset information. Therefore time.Time
+// parameters are converted to UTC before they are sent to the database and time.Time values are
+// returned in UTC. The point in time is preserved, the location or offset is not. If the original
+// offset is needed, it has to be stored in an additional column and restored via time.FixedZone and time.Time.In.
+//
+// # Table types
+//
+// Table output parameters of stored procedures are returned as *sql.Rows (one nesting level). Nested table
+// types (columns of a table type) are not supported: the column metadata is available via sql.ColumnType,
+// whereas the wire format of the values is not known to the driver, so that reading such values fails with
+// ErrUnsupportedType. The same applies to any other data type code not supported by the driver.
+//
+// Rows of table output parameters can be collected into a slice of structs by ScanStructs. Table typed input
+// parameters cannot be bound to arguments (ErrUnsupportedType), as the protocol encoding of table values is not
+// known to the driver. Instead, the name of a table having the columns of the table type (e.g. a local temporary
+// table filled by a bulk insert) can be passed in the call statement in place of the placeholder:
+//
+//	call proc(#input_table, ?)
+//
+// # Slice arguments
+//
+// Slice arguments (e.g. []int64 or []string, but not []byte) of queries and statements executed via sql.DB,
+// sql.Conn or sql.Tx are expanded into one argument per element, so that a slice can be passed for an IN predicate:
+//
+//	db.Query("select * from t where id in (?)", []int64{1, 2, 3}) // executed as: id in (?, ?, ?)
+//
+// Nil elements are passed as NULL values, empty slices are rejected with ErrEmptySliceArg. As every element
+// counts as one statement parameter, the slice length is limited by the maximum number of parameters per statement
+// supported by the database server (32767). Statements prepared explicitly (sql.Stmt) do not expand slice arguments.
+//
+// # Named arguments
+//
+// Arguments of procedure calls can be passed by name (sql.Named): they are matched to the procedure parameters by
+// the parameter names provided by the database server on prepare (case sensitive, i.e. upper case for parameter names
+// not quoted in the procedure definition), so that the order of the arguments does not need to match the parameter
+// order. Named and positional arguments cannot be mixed in one call. Queries and other statements support positional
+// arguments only.
+//
+// # Distributed transactions
+//
+// Distributed (X/Open XA) transactions are not supported. The protocol defines the XA message types, but the
+// encoding of the XA transaction information (xid, flags and the recover reply) is not part of the public protocol
+// specification, so that the driver neither negotiates the XA connect option nor implements a transaction branch
+// interface (start, end, prepare, commit, rollback, forget and recover). Applications coordinating the database
+// with other resources need to rely on application level patterns (e.g. a transactional outbox or compensating
+// transactions) using local transactions and the retry support for serialization failures (see IsSerializationFailure).
 package driver
@@ -37,7 +37,7 @@ func init() {
 		panic(err) // invalid configuration file
 	}
 	// create driver
-	stdHdbDriver = &hdbDriver{metrics: newMetrics(nil, statsCfg.TimeUnit, statsCfg.TimeUpperBounds)}
+	stdHdbDriver = &hdbDriver{metrics: newMetrics(nil, statsCfg.TimeUnit, statsCfg.TimeUpperBounds, statsCfg.SQLTimeUpperBounds)}
 	// register driver
 	sql.Register(DriverName, stdHdbDriver)
 }
@@ -96,7 +96,7 @@ type DB struct {
 
 // OpenDB opens and returns a database. It also calls the OpenDB method of the sql package and stores an embedded *sql.DB object.
 func OpenDB(c *Connector) *DB {
-	metrics := newMetrics(stdHdbDriver.metrics, statsCfg.TimeUnit, statsCfg.TimeUpperBounds)
+	metrics := newMetrics(stdHdbDriver.metrics, statsCfg.TimeUnit, statsCfg.TimeUpperBounds, statsCfg.SQLTimeUpperBounds)
 	nc := c.clone()
 	nc.metrics = metrics
 	return &DB{
@@ -92,7 +92,22 @@ end
 		t.Fatal(err)
 	}
 
-	if _, err := db.Exec(fmt.Sprintf("call %s", procedure)); err != nil {
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(context.Background(), fmt.Sprintf("call %s", procedure)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := conn.Raw(func(driverConn any) error {
+		if warnings := driverConn.(driver.Conn).Warnings(); len(warnings) == 0 || !warnings[0].IsWarning() {
+			t.Fatalf("warnings %v - expected database warning", warnings)
+		}
+		return nil
+	}); err != nil {
 		t.Fatal(err)
 	}
 }
@@ -1,6 +1,8 @@
 package driver
 
 import (
+	"errors"
+
 	p "github.com/SAP/go-hdb/driver/internal/protocol"
 )
 
@@ -11,6 +13,33 @@ const (
 	HdbFatalError = 2
 )
 
+// HDB error codes of transactions rolled back by the database server due to conflicts with concurrent transactions.
+const (
+	HdbErrTxRolledBack            = 129 // transaction rolled back by an internal error (e.g. a serialization conflict)
+	HdbErrTxRolledBackLockTimeout = 131 // transaction rolled back by lock wait timeout
+	HdbErrTxRolledBackDeadlock    = 133 // transaction rolled back by detected deadlock
+)
+
+/*
+Lock conflict errors: errors.Is reports whether an error returned by the driver contains a database error
+with error code HdbErrTxRolledBackLockTimeout (ErrLockWaitTimeout) or HdbErrTxRolledBackDeadlock (ErrDeadlock).
+
+	if errors.Is(err, driver.ErrDeadlock) {
+		// retry transaction
+	}
+
+The database server does not provide information about the blocking transaction in a structured way: details
+reported by the server are part of the error text (see DBError Text). For a detailed analysis of lock conflicts
+please refer to the monitoring views M_BLOCKED_TRANSACTIONS and M_OBJECT_LOCKS.
+*/
+var (
+	ErrLockWaitTimeout = p.ErrLockWaitTimeout
+	ErrDeadlock        = p.ErrDeadlock
+)
+
+// HdbErrUniqueConstraintViolated is the HDB error code of statements violating a unique constraint (e.g. duplicate primary key).
+const HdbErrUniqueConstraintViolated = 301
+
 // DBError represents a single error returned by the database server.
 type DBError interface {
 	Error() string   // Implements the golang error interface.
@@ -37,3 +66,75 @@ var (
 	_ DBError = (*p.HdbError)(nil)
 	_ Error   = (*p.HdbErrors)(nil)
 )
+
+// ErrorPolicy defines which errors are returned in case a database reply contains more than one error.
+type ErrorPolicy int
+
+// ErrorPolicy constants.
+const (
+	ErrorPolicyAll        ErrorPolicy = iota // All errors including warnings are returned as error collection (default).
+	ErrorPolicyFirst                         // Only the first error (warnings excluded) is returned.
+	ErrorPolicyMostSevere                    // Only the error with the highest error level is returned (the first one in case of equal levels).
+)
+
+var errorPolicyStrs = [...]string{"all", "first", "mostSevere"}
+
+func (ep ErrorPolicy) String() string {
+	if int(ep) < 0 || int(ep) >= len(errorPolicyStrs) {
+		return ""
+	}
+	return errorPolicyStrs[ep]
+}
+
+// AllErrors returns all database errors including warnings contained in err, nil if err does not contain database errors.
+func AllErrors(err error) []DBError {
+	var hdbErrors *p.HdbErrors
+	if !errors.As(err, &hdbErrors) {
+		return nil
+	}
+	all := hdbErrors.All()
+	errs := make([]DBError, len(all))
+	for i, err := range all {
+		errs[i] = err
+	}
+	return errs
+}
+
+// isSerializationFailureCode returns true if the error code is a serialization failure error code.
+func isSerializationFailureCode(code int) bool {
+	switch code {
+	case HdbErrTxRolledBack, HdbErrTxRolledBackLockTimeout, HdbErrTxRolledBackDeadlock:
+		return true
+	default:
+		return false
+	}
+}
+
+/*
+IsSerializationFailure returns true if err contains a database error reporting that the transaction got rolled back
+due to a conflict with a concurrent transaction (e.g. deadlock or lock wait timeout), false otherwise.
+
+Serialization failures are classic retry candidates: as the database server rolled back the complete transaction,
+the application needs to retry the complete transaction (not only the failing statement).
+*/
+func IsSerializationFailure(err error) bool { return hasErrorCode(err, isSerializationFailureCode) }
+
+/*
+IsUniqueConstraintViolation returns true if err contains a database error reporting a unique constraint violation
+(e.g. insert of a duplicate primary key), false otherwise.
+
+Other conditions can be classified the same way by inspecting the code of the errors returned by AllErrors.
+*/
+func IsUniqueConstraintViolation(err error) bool {
+	return hasErrorCode(err, func(code int) bool { return code == HdbErrUniqueConstraintViolated })
+}
+
+// hasErrorCode returns true if err contains a database error (warnings excluded) with a code matching fn.
+func hasErrorCode(err error, fn func(code int) bool) bool {
+	for _, dbErr := range AllErrors(err) {
+		if !dbErr.IsWarning() && fn(dbErr.Code()) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,38 @@
+package driver
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSerializationFailureCode(t *testing.T) {
+	testData := []struct {
+		code    int
+		failure bool
+	}{
+		{HdbErrTxRolledBack, true},
+		{HdbErrTxRolledBackLockTimeout, true},
+		{HdbErrTxRolledBackDeadlock, true},
+		{130, false},
+		{259, false}, // invalid table name
+		{301, false}, // unique constraint violated
+	}
+	for _, d := range testData {
+		if failure := isSerializationFailureCode(d.code); failure != d.failure {
+			t.Fatalf("code %d: serialization failure %t - expected %t", d.code, failure, d.failure)
+		}
+	}
+
+	// no database errors
+	for _, err := range []error{nil, errors.New("test error"), errCancelled} {
+		if IsSerializationFailure(err) {
+			t.Fatalf("error %v: unexpected serialization failure", err)
+		}
+		if IsUniqueConstraintViolation(err) {
+			t.Fatalf("error %v: unexpected unique constraint violation", err)
+		}
+		if errors.Is(err, ErrLockWaitTimeout) || errors.Is(err, ErrDeadlock) {
+			t.Fatalf("error %v: unexpected lock conflict", err)
+		}
+	}
+}
@@ -12,9 +12,10 @@ import (
 )
 
 /*
-ExampleBulkInsert inserts 2000 rows into a database table:
-  - 1000 rows are inserted via an extended argument list and
-  - 1000 rows are inserted with the help of a argument function
+ExampleBulkInsert inserts 3000 rows into a database table:
+  - 1000 rows are inserted via an extended argument list,
+  - 1000 rows are inserted with the help of a argument function and
+  - 1000 rows are inserted with the help of an iterator (iter.Seq[[]any] compatible function)
 */
 func Example_bulkInsert() {
 	// Number of rows to be inserted into table.
@@ -59,6 +60,19 @@ func Example_bulkInsert() {
 		log.Panic(err)
 	}
 
+	// Bulk insert via iterator.
+	// Rows are pulled from the iterator on demand, so that rows do not need to be materialized at once.
+	// An iterator yielding errors (iter.Seq2[[]any, error] compatible function) is supported as well.
+	if _, err := stmt.Exec(func(yield func([]any) bool) {
+		for i := 0; i < numRow; i++ {
+			if !yield([]any{i, float64(i)}) {
+				return
+			}
+		}
+	}); err != nil {
+		log.Panic(err)
+	}
+
 	// Select number of inserted rows.
 	if err := db.QueryRow(fmt.Sprintf("select count(*) from %s", tableName)).Scan(&numRow); err != nil {
 		log.Panic(err)
@@ -70,5 +84,5 @@ func Example_bulkInsert() {
 		log.Panic(err)
 	}
 
-	// output: 2000
+	// output: 3000
 }
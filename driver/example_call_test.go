@@ -3,6 +3,7 @@
 package driver_test
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
@@ -103,3 +104,131 @@ end
 	// SAP HANA
 	// Go driver
 }
+
+/*
+ExampleCallInOut creates a stored procedure with an inout parameter, an output parameter and a table output
+parameter and executes it. The values of the inout and output parameters are assigned to the sql.Out destinations,
+the value of an inout parameter is sent to the database from the destination as well (sql.Out In).
+*/
+func Example_callInOut() {
+	const procInOut = `create procedure %[1]s (inout counter integer, out message nvarchar(100), out t %[2]s)
+language SQLSCRIPT as
+begin
+  counter := :counter + 1;
+  message := 'counter incremented';
+  t = select :counter as x from dummy union all select :counter * 2 as x from dummy;
+end
+`
+	db := sql.OpenDB(driver.MT.Connector())
+	defer db.Close()
+
+	tableType := driver.RandomIdentifier("TableType_")
+	procedure := driver.RandomIdentifier("ProcInOut_")
+
+	if _, err := db.Exec(fmt.Sprintf("create type %s as table (x integer)", tableType)); err != nil { // Create table type.
+		log.Panic(err)
+	}
+	if _, err := db.Exec(fmt.Sprintf(procInOut, procedure, tableType)); err != nil { // Create stored procedure.
+		log.Panic(err)
+	}
+
+	counter := 41
+	var message string
+	var tableRows sql.Rows
+
+	// Call stored procedure via prepare as the table output parameter needs to be retrieved.
+	stmt, err := db.Prepare(fmt.Sprintf("call %s(?, ?, ?)", procedure))
+	if err != nil {
+		log.Panic(err)
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(
+		sql.Named("COUNTER", sql.Out{Dest: &counter, In: true}),
+		sql.Named("MESSAGE", sql.Out{Dest: &message}),
+		sql.Named("T", sql.Out{Dest: &tableRows}),
+	); err != nil {
+		log.Panic(err)
+	}
+
+	fmt.Println(counter, message)
+
+	for tableRows.Next() {
+		var x int
+		if err := tableRows.Scan(&x); err != nil {
+			log.Panic(err)
+		}
+		fmt.Println(x)
+	}
+	if err := tableRows.Err(); err != nil {
+		log.Panic(err)
+	}
+
+	// output: 42 counter incremented
+	// 42
+	// 84
+}
+
+/*
+ExampleScanStructs creates a stored procedure with a table input and a table output parameter and executes it.
+The table input parameter is provided by a local temporary table, the table output parameter values are collected
+into a slice of structs.
+*/
+func ExampleScanStructs() {
+	const procTableInOut = `create procedure %[1]s (in i %[2]s, out o %[2]s)
+language SQLSCRIPT as
+begin
+  o = select x * 10 as x from :i order by x;
+end
+`
+	ctx := context.Background()
+
+	db := sql.OpenDB(driver.MT.Connector())
+	defer db.Close()
+
+	tableType := driver.RandomIdentifier("TableType_")
+	procedure := driver.RandomIdentifier("ProcTableInOut_")
+
+	if _, err := db.Exec(fmt.Sprintf("create type %s as table (x integer)", tableType)); err != nil { // Create table type.
+		log.Panic(err)
+	}
+	if _, err := db.Exec(fmt.Sprintf(procTableInOut, procedure, tableType)); err != nil { // Create stored procedure.
+		log.Panic(err)
+	}
+
+	// Local temporary tables are visible in the database session only: use a dedicated connection.
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		log.Panic(err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "create local temporary table #input (x integer)"); err != nil {
+		log.Panic(err)
+	}
+	for _, x := range []int{3, 1, 2} {
+		if _, err := conn.ExecContext(ctx, "insert into #input values (?)", x); err != nil {
+			log.Panic(err)
+		}
+	}
+
+	stmt, err := conn.PrepareContext(ctx, fmt.Sprintf("call %s(#input, ?)", procedure))
+	if err != nil {
+		log.Panic(err)
+	}
+	defer stmt.Close()
+
+	var tableRows sql.Rows
+	if _, err := stmt.ExecContext(ctx, sql.Out{Dest: &tableRows}); err != nil {
+		log.Panic(err)
+	}
+
+	type row struct{ X int }
+	rows, err := driver.ScanStructs[row](&tableRows)
+	if err != nil {
+		log.Panic(err)
+	}
+	fmt.Println(rows)
+
+	// output: [{10} {20} {30}]
+}
@@ -0,0 +1,54 @@
+//go:build !unit
+
+package driver_test
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/SAP/go-hdb/driver"
+)
+
+// Example_sliceArgs demonstrates passing a slice as argument for an IN predicate.
+func Example_sliceArgs() {
+	db := sql.OpenDB(driver.MT.Connector())
+	defer db.Close()
+
+	tableName := driver.RandomIdentifier("table_")
+
+	if _, err := db.Exec(fmt.Sprintf("create table %s (id integer, name nvarchar(10))", tableName)); err != nil {
+		log.Panic(err)
+	}
+	for i, name := range []string{"alpha", "beta", "gamma", "delta"} {
+		if _, err := db.Exec(fmt.Sprintf("insert into %s values (?, ?)", tableName), i+1, name); err != nil {
+			log.Panic(err)
+		}
+	}
+
+	// The slice argument is expanded into one placeholder per element: in (?, ?, ?).
+	if _, err := db.Exec(fmt.Sprintf("delete from %s where id in (?)", tableName), []int{1, 3, 99}); err != nil {
+		log.Panic(err)
+	}
+
+	rows, err := db.Query(fmt.Sprintf("select name from %s where name in (?) order by id", tableName), []string{"alpha", "beta", "delta"})
+	if err != nil {
+		log.Panic(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			log.Panic(err)
+		}
+		fmt.Println(name)
+	}
+	if err := rows.Err(); err != nil {
+		log.Panic(err)
+	}
+
+	// output:
+	// beta
+	// delta
+}
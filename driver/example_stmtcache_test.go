@@ -0,0 +1,34 @@
+//go:build !unit
+
+package driver_test
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/SAP/go-hdb/driver"
+)
+
+// ExampleConnector_SetStmtCacheSize demonstrates the reuse of prepared statements by the connection statement cache.
+func ExampleConnector_SetStmtCacheSize() {
+	connector := driver.MT.NewConnector()
+	connector.SetStmtCacheSize(10)
+	db := driver.OpenDB(connector)
+	db.SetMaxOpenConns(1) // single connection
+
+	for i := 0; i < 3; i++ {
+		var v int
+		// queries with arguments are prepared by database/sql and the statement is closed after the query.
+		if err := db.QueryRow("select ? from dummy", i).Scan(&v); err != nil {
+			log.Panic(err)
+		}
+	}
+
+	if err := db.Close(); err != nil { // close connection to collect the statistics
+		log.Panic(err)
+	}
+	stats := db.ExStats()
+	fmt.Printf("hits %d misses %d size %d", stats.StmtCacheHits, stats.StmtCacheMisses, stats.StmtCacheSize)
+
+	// output: hits 2 misses 1 size 0
+}
@@ -0,0 +1,93 @@
+package driver
+
+import (
+	"database/sql/driver"
+	"slices"
+
+	p "github.com/SAP/go-hdb/driver/internal/protocol"
+)
+
+// Rows affected values of statements not providing the number of affected rows.
+const (
+	RowsAffectedSuccessNoInfo   = p.RaSuccessNoInfo   // Statement executed successfully, number of affected rows unknown.
+	RowsAffectedExecutionFailed = p.RaExecutionFailed // Statement execution failed.
+)
+
+// FunctionCode is the kind of statement reported by the database server for an executed statement.
+type FunctionCode int16
+
+// FunctionCode constants (values as defined by the protocol).
+const (
+	FcDDL                     FunctionCode = 1
+	FcInsert                  FunctionCode = 2
+	FcUpdate                  FunctionCode = 3
+	FcDelete                  FunctionCode = 4
+	FcSelect                  FunctionCode = 5
+	FcSelectForUpdate         FunctionCode = 6
+	FcProcedureCall           FunctionCode = 8
+	FcProcedureCallWithResult FunctionCode = 9
+)
+
+func (fc FunctionCode) String() string { return p.FunctionCode(fc).String() }
+
+/*
+ExecResult is the driver.Result of executed statements providing the details of the affected rows.
+
+As package database/sql wraps the driver result, sql.Result cannot be type asserted to ExecResult. Instead,
+the statement needs to be executed on the driver connection via sql.Conn.Raw. In case of a database error
+the result is returned together with the error, so that the failed rows of bulk statements can be determined.
+*/
+type ExecResult interface {
+	driver.Result
+	// FunctionCode returns the kind of the executed statement.
+	FunctionCode() FunctionCode
+	// RowsAffectedPerStmt returns the number of affected rows per executed statement (bulk: per row) which might be
+	// RowsAffectedSuccessNoInfo or RowsAffectedExecutionFailed.
+	RowsAffectedPerStmt() []int64
+	// FailedStmts returns the indexes of the statements (bulk: rows) with value RowsAffectedExecutionFailed.
+	FailedStmts() []int
+}
+
+// check if execResult implements all required interfaces.
+var _ ExecResult = (*execResult)(nil)
+
+type execResult struct {
+	fc   p.FunctionCode
+	rows []int64
+}
+
+// add adds the rows affected of r (e.g. of a bulk statement executed in packages).
+func (er *execResult) add(r driver.Result) {
+	if r, ok := r.(*execResult); ok {
+		er.fc = r.fc
+		er.rows = append(er.rows, r.rows...)
+	}
+}
+
+func (er *execResult) LastInsertId() (int64, error) { return driver.RowsAffected(0).LastInsertId() }
+
+func (er *execResult) RowsAffected() (int64, error) {
+	if er.fc.IsDDL() {
+		return driver.ResultNoRows.RowsAffected()
+	}
+	total := int64(0)
+	for _, rows := range er.rows {
+		if rows > 0 {
+			total += rows
+		}
+	}
+	return total, nil
+}
+
+func (er *execResult) FunctionCode() FunctionCode   { return FunctionCode(er.fc) }
+func (er *execResult) RowsAffectedPerStmt() []int64 { return slices.Clone(er.rows) }
+
+func (er *execResult) FailedStmts() []int {
+	var failed []int
+	for i, rows := range er.rows {
+		if rows == RowsAffectedExecutionFailed {
+			failed = append(failed, i)
+		}
+	}
+	return failed
+}
@@ -0,0 +1,33 @@
+package driver
+
+import (
+	"slices"
+	"testing"
+
+	p "github.com/SAP/go-hdb/driver/internal/protocol"
+)
+
+func TestExecResult(t *testing.T) {
+	r := &execResult{fc: p.FunctionCode(FcInsert)}
+	r.add(&execResult{fc: p.FunctionCode(FcInsert), rows: []int64{1, RowsAffectedExecutionFailed}})
+	r.add(nil)
+	r.add(&execResult{fc: p.FunctionCode(FcInsert), rows: []int64{RowsAffectedSuccessNoInfo, 1, RowsAffectedExecutionFailed}})
+
+	if rows, err := r.RowsAffected(); err != nil || rows != 2 {
+		t.Fatalf("rows affected %d error %v - expected %d", rows, err, 2)
+	}
+	if rows := r.RowsAffectedPerStmt(); !slices.Equal(rows, []int64{1, RowsAffectedExecutionFailed, RowsAffectedSuccessNoInfo, 1, RowsAffectedExecutionFailed}) {
+		t.Fatalf("rows affected per statement %v", rows)
+	}
+	if failed := r.FailedStmts(); !slices.Equal(failed, []int{1, 4}) {
+		t.Fatalf("failed statements %v - expected %v", failed, []int{1, 4})
+	}
+	if fc := r.FunctionCode(); fc != FcInsert || fc.String() != "fcInsert" {
+		t.Fatalf("function code %s - expected %s", fc, "fcInsert")
+	}
+
+	ddl := &execResult{fc: p.FunctionCode(FcDDL)}
+	if _, err := ddl.RowsAffected(); err == nil {
+		t.Fatal("rows affected error for ddl statement expected")
+	}
+}
@@ -0,0 +1,121 @@
+package driver
+
+import (
+	"expvar"
+	"strconv"
+	"sync"
+)
+
+// expvar names.
+const (
+	expvarName       = "go-hdb"
+	expvarDriverName = "driver"
+	expvarDBName     = "db"
+)
+
+var (
+	expvarOnce  sync.Once
+	expvarDBMap *expvar.Map
+)
+
+// expvarHistogram is the JSON serializable representation of a StatsHistogram
+// (JSON object keys need to be strings).
+type expvarHistogram struct {
+	Count   uint64            `json:"count"`
+	Sum     float64           `json:"sum"`
+	Buckets map[string]uint64 `json:"buckets"`
+}
+
+func newExpvarHistogram(h *StatsHistogram) *expvarHistogram {
+	buckets := make(map[string]uint64, len(h.Buckets))
+	for upperBound, count := range h.Buckets {
+		buckets[strconv.FormatFloat(upperBound, 'g', -1, 64)] = count
+	}
+	return &expvarHistogram{Count: h.Count, Sum: h.Sum, Buckets: buckets}
+}
+
+// expvarStats is the JSON serializable representation of Stats.
+type expvarStats struct {
+	OpenConnections          int                         `json:"openConnections"`
+	OpenTransactions         int                         `json:"openTransactions"`
+	OpenStatements           int                         `json:"openStatements"`
+	StmtCacheSize            int                         `json:"stmtCacheSize"`
+	ReadBytes                uint64                      `json:"readBytes"`
+	WrittenBytes             uint64                      `json:"writtenBytes"`
+	LobReadBytes             uint64                      `json:"lobReadBytes"`
+	LobWrittenBytes          uint64                      `json:"lobWrittenBytes"`
+	UncompressedReadBytes    uint64                      `json:"uncompressedReadBytes"`
+	UncompressedWrittenBytes uint64                      `json:"uncompressedWrittenBytes"`
+	DroppedWarnings          uint64                      `json:"droppedWarnings"`
+	Retries                  uint64                      `json:"retries"`
+	StmtCacheHits            uint64                      `json:"stmtCacheHits"`
+	StmtCacheMisses          uint64                      `json:"stmtCacheMisses"`
+	SQLErrors                map[string]uint64           `json:"sqlErrors"`
+	TimeUnit                 string                      `json:"timeUnit"`
+	ReadTime                 *expvarHistogram            `json:"readTime"`
+	WriteTime                *expvarHistogram            `json:"writeTime"`
+	AuthTime                 *expvarHistogram            `json:"authTime"`
+	ServerTime               *expvarHistogram            `json:"serverTime"`
+	ClientTime               *expvarHistogram            `json:"clientTime"`
+	SQLTimes                 map[string]*expvarHistogram `json:"sqlTimes"`
+	SQLTimeSamples           map[string][]float64        `json:"sqlTimeSamples,omitempty"`
+	RowsPerQuery             *expvarHistogram            `json:"rowsPerQuery"`
+}
+
+func newExpvarStats(stats *Stats) *expvarStats {
+	sqlTimes := make(map[string]*expvarHistogram, len(stats.SQLTimes))
+	for k, v := range stats.SQLTimes {
+		sqlTimes[k] = newExpvarHistogram(v)
+	}
+	return &expvarStats{
+		OpenConnections:          stats.OpenConnections,
+		OpenTransactions:         stats.OpenTransactions,
+		OpenStatements:           stats.OpenStatements,
+		StmtCacheSize:            stats.StmtCacheSize,
+		ReadBytes:                stats.ReadBytes,
+		WrittenBytes:             stats.WrittenBytes,
+		LobReadBytes:             stats.LobReadBytes,
+		LobWrittenBytes:          stats.LobWrittenBytes,
+		UncompressedReadBytes:    stats.UncompressedReadBytes,
+		UncompressedWrittenBytes: stats.UncompressedWrittenBytes,
+		DroppedWarnings:          stats.DroppedWarnings,
+		Retries:                  stats.Retries,
+		StmtCacheHits:            stats.StmtCacheHits,
+		StmtCacheMisses:          stats.StmtCacheMisses,
+		SQLErrors:                stats.SQLErrors,
+		TimeUnit:                 stats.TimeUnit,
+		ReadTime:                 newExpvarHistogram(stats.ReadTime),
+		WriteTime:                newExpvarHistogram(stats.WriteTime),
+		AuthTime:                 newExpvarHistogram(stats.AuthTime),
+		ServerTime:               newExpvarHistogram(stats.ServerTime),
+		ClientTime:               newExpvarHistogram(stats.ClientTime),
+		SQLTimes:                 sqlTimes,
+		SQLTimeSamples:           stats.SQLTimeSamples,
+		RowsPerQuery:             newExpvarHistogram(stats.RowsPerQuery),
+	}
+}
+
+func publishExpvar() {
+	expvarOnce.Do(func() {
+		m := expvar.NewMap(expvarName)
+		m.Set(expvarDriverName, expvar.Func(func() any { return newExpvarStats(stdHdbDriver.Stats()) }))
+		expvarDBMap = new(expvar.Map)
+		m.Set(expvarDBName, expvarDBMap)
+	})
+}
+
+/*
+PublishStats publishes the driver statistics via package expvar under the name "go-hdb.driver".
+Calling PublishStats more than once is safe.
+*/
+func PublishStats() { publishExpvar() }
+
+/*
+PublishExStats publishes the extended database statistics of db via package expvar under the name "go-hdb.db.<name>".
+The driver statistics are published as well (see PublishStats).
+Calling PublishExStats with an already published name replaces the published database statistics.
+*/
+func PublishExStats(name string, db *DB) {
+	publishExpvar()
+	expvarDBMap.Set(name, expvar.Func(func() any { return newExpvarStats(db.ExStats()) }))
+}
@@ -0,0 +1,44 @@
+package driver
+
+import (
+	"encoding/json"
+	"expvar"
+	"testing"
+)
+
+func TestExpvar(t *testing.T) {
+	const dbName = "testDB"
+
+	connector := NewConnector()
+	db1 := OpenDB(connector)
+	defer db1.Close()
+	db2 := OpenDB(connector)
+	defer db2.Close()
+
+	PublishStats()
+	PublishStats()
+	PublishExStats(dbName, db1)
+	PublishExStats(dbName, db2) // same name: replace
+
+	v := expvar.Get(expvarName)
+	if v == nil {
+		t.Fatalf("expvar %s not published", expvarName)
+	}
+
+	var stats struct {
+		Driver *expvarStats            `json:"driver"`
+		DB     map[string]*expvarStats `json:"db"`
+	}
+	if err := json.Unmarshal([]byte(v.String()), &stats); err != nil {
+		t.Fatal(err)
+	}
+	if stats.Driver == nil || stats.Driver.ReadTime == nil || len(stats.Driver.SQLTimes) != numSQLTime {
+		t.Fatalf("invalid driver stats %v", stats.Driver)
+	}
+	if len(stats.DB) != 1 || stats.DB[dbName] == nil {
+		t.Fatalf("invalid db stats %v", stats.DB)
+	}
+	if len(stats.DB[dbName].ReadTime.Buckets) != len(statsCfg.TimeUpperBounds) {
+		t.Fatalf("number of buckets %d - expected %d", len(stats.DB[dbName].ReadTime.Buckets), len(statsCfg.TimeUpperBounds))
+	}
+}
@@ -0,0 +1,77 @@
+package driver
+
+import (
+	"context"
+	runtimemetrics "runtime/metrics"
+)
+
+// fetchSizeCtxKey is the context key of a query fetch size.
+type fetchSizeCtxKey struct{}
+
+/*
+WithFetchSize returns a copy of ctx with fetch size n for queries executed with the returned context.
+
+The fetch size overrides the connection fetch size (see SetFetchSize) and adaptive fetch sizing
+(see SetMemoryPressure) for fetching the resultset of the query only. Larger fetch sizes reduce
+the number of round trips e.g. for analytical scans of huge resultsets. Fetch sizes exceeding the
+maximum supported by the server (advertised in the connect options) are limited to the maximum.
+In case n is less than 1 the connection fetch size is used.
+*/
+func WithFetchSize(ctx context.Context, n int) context.Context {
+	return context.WithValue(ctx, fetchSizeCtxKey{}, n)
+}
+
+// queryFetchSize returns the fetch size provided by ctx (see WithFetchSize) and true, or false if not provided.
+func queryFetchSize(ctx context.Context) (int, bool) {
+	if ctx == nil {
+		return 0, false
+	}
+	n, ok := ctx.Value(fetchSizeCtxKey{}).(int)
+	if !ok || n < minFetchSize {
+		return 0, false
+	}
+	return n, true
+}
+
+// adaptiveFetchSize adapts the fetch size to memory pressure: as long as memory pressure is
+// signaled the fetch size is halved, if the pressure eases the fetch size is doubled again.
+type adaptiveFetchSize struct {
+	size int // current fetch size (zero: not started yet)
+}
+
+// next returns the fetch size for the next fetch within the bounds [minSize, maxSize].
+// The first fetch starts with fetchSize.
+func (a *adaptiveFetchSize) next(fetchSize, minSize, maxSize int, pressure bool) int {
+	switch {
+	case a.size == 0: // first fetch
+		a.size = fetchSize
+		if pressure {
+			a.size /= 2
+		}
+	case pressure:
+		a.size /= 2
+	default:
+		a.size *= 2
+	}
+	a.size = max(minSize, min(maxSize, a.size))
+	return a.size
+}
+
+const heapObjectsMetric = "/memory/classes/heap/objects:bytes"
+
+/*
+HeapMemoryPressure returns a memory pressure function (see SetMemoryPressure) reporting memory
+pressure as long as the memory occupied by live and not yet swept heap objects exceeds limit bytes.
+
+The heap size is read via runtime/metrics, which does not stop the world like runtime.ReadMemStats.
+*/
+func HeapMemoryPressure(limit uint64) func() bool {
+	return func() bool {
+		sample := []runtimemetrics.Sample{{Name: heapObjectsMetric}}
+		runtimemetrics.Read(sample)
+		if sample[0].Value.Kind() != runtimemetrics.KindUint64 {
+			return false
+		}
+		return sample[0].Value.Uint64() > limit
+	}
+}
@@ -0,0 +1,88 @@
+package driver
+
+import (
+	"context"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestAdaptiveFetchSize(t *testing.T) {
+	pressure := false
+
+	attrs := newConnAttrs()
+	attrs.SetFetchSize(128)
+	attrs.SetFetchSizeBounds(8, 512)
+	attrs.SetMemoryPressure(func() bool { return pressure })
+	c := &conn{attrs: attrs}
+
+	fetch := func(n int) []int {
+		sizes := make([]int, n)
+		for i := range sizes {
+			sizes[i] = c.nextFetchSize()
+		}
+		return sizes
+	}
+
+	testData := []struct {
+		pressure bool
+		sizes    []int
+	}{
+		{false, []int{128, 256, 512, 512}},        // grow up to max
+		{true, []int{256, 128, 64, 32, 16, 8, 8}}, // shrink down to min
+		{false, []int{16, 32, 64}},                // grow back when pressure eases
+		{true, []int{32}},
+	}
+	for i, d := range testData {
+		pressure = d.pressure
+		if sizes := fetch(len(d.sizes)); !slices.Equal(sizes, d.sizes) {
+			t.Fatalf("test %d pressure %t: fetch sizes %v - expected %v", i, d.pressure, sizes, d.sizes)
+		}
+	}
+
+	// max bound defaults to fetch size
+	attrs.SetFetchSizeBounds(0, 0)
+	c = &conn{attrs: attrs}
+	pressure = false
+	if sizes := fetch(3); !slices.Equal(sizes, []int{128, 128, 128}) {
+		t.Fatalf("fetch sizes %v - expected %v", sizes, []int{128, 128, 128})
+	}
+
+	// adaptive fetch sizing is off without memory pressure function
+	attrs.SetMemoryPressure(nil)
+	pressure = true
+	if sizes := fetch(2); !slices.Equal(sizes, []int{128, 128}) {
+		t.Fatalf("fetch sizes %v - expected %v", sizes, []int{128, 128})
+	}
+}
+
+func TestHeapMemoryPressure(t *testing.T) {
+	if !HeapMemoryPressure(0)() {
+		t.Fatal("expected memory pressure for heap limit 0")
+	}
+	if HeapMemoryPressure(math.MaxUint64)() {
+		t.Fatal("unexpected memory pressure for maximal heap limit")
+	}
+}
+
+func TestQueryFetchSize(t *testing.T) {
+	ctx := context.Background()
+
+	testData := []struct {
+		ctx  context.Context
+		size int
+		ok   bool
+	}{
+		{nil, 0, false},
+		{ctx, 0, false},
+		{WithFetchSize(ctx, 0), 0, false},  // fall back to connection fetch size
+		{WithFetchSize(ctx, -1), 0, false}, // fall back to connection fetch size
+		{WithFetchSize(ctx, 10000), 10000, true},
+		{WithFetchSize(ctx, math.MaxInt32), math.MaxInt32, true}, // limited to the server maximum on fetch
+	}
+	for i, d := range testData {
+		if size, ok := queryFetchSize(d.ctx); size != d.size || ok != d.ok {
+			t.Fatalf("test %d: fetch size %d %t - expected %d %t", i, size, ok, d.size, d.ok)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/SAP/go-hdb/driver/dial"
+)
+
+// stallingDialer returns connections to a mock server which accepts the connection,
+// answers the protocol prolog and never completes the authentication.
+type stallingDialer struct {
+	closed chan struct{}
+}
+
+func (d *stallingDialer) DialContext(ctx context.Context, address string, options dial.DialerOptions) (net.Conn, error) {
+	client, server := net.Pipe()
+	go func() {
+		defer close(d.closed)
+		defer server.Close()
+		prolog := make([]byte, 14)
+		if _, err := io.ReadFull(server, prolog); err != nil {
+			return
+		}
+		if _, err := server.Write(make([]byte, 8)); err != nil { // prolog reply
+			return
+		}
+		io.Copy(io.Discard, server) //nolint:errcheck // consume auth request until the client closes the connection
+	}()
+	return client, nil
+}
+
+func TestHandshakeTimeout(t *testing.T) {
+	const handshakeTimeout = 200 * time.Millisecond
+
+	dialer := &stallingDialer{closed: make(chan struct{})}
+
+	connector := NewBasicAuthConnector("host:30015", "user", "password")
+	connector.SetDialer(dialer)
+	connector.SetHandshakeTimeout(handshakeTimeout)
+
+	start := time.Now()
+	_, err := connector.Connect(context.Background())
+	if !errors.Is(err, ErrConnectTimeout) {
+		t.Fatalf("error %v - expected %v", err, ErrConnectTimeout)
+	}
+	if d := time.Since(start); d > 10*handshakeTimeout {
+		t.Fatalf("connect returned after %s - expected about %s", d, handshakeTimeout)
+	}
+
+	select { // socket closed
+	case <-dialer.closed:
+	case <-time.After(time.Second):
+		t.Fatal("connection not closed")
+	}
+}
@@ -0,0 +1,97 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// HostPolicy defines the order in which the hosts of a connector with multiple hosts are tried on connect.
+type HostPolicy int
+
+// HostPolicy constants.
+const (
+	HostPolicySequential HostPolicy = iota // Hosts are tried in the order provided (default).
+	HostPolicyRandom                       // Hosts are tried in random order.
+	HostPolicyRoundRobin                   // Hosts are tried in the order provided starting with the next host for each connect.
+)
+
+var hostPolicyStrs = [...]string{"sequential", "random", "roundRobin"}
+
+func (hp HostPolicy) String() string {
+	if int(hp) < 0 || int(hp) >= len(hostPolicyStrs) {
+		return ""
+	}
+	return hostPolicyStrs[hp]
+}
+
+func parseHostPolicy(s string) (HostPolicy, bool) {
+	for i, hs := range hostPolicyStrs {
+		if hs == s {
+			return HostPolicy(i), true
+		}
+	}
+	return 0, false
+}
+
+// splitHosts returns the hosts of a comma separated host list.
+func splitHosts(host string) []string {
+	hosts := []string{}
+	for _, h := range strings.Split(host, ",") {
+		if h = strings.TrimSpace(h); h != "" {
+			hosts = append(hosts, h)
+		}
+	}
+	return hosts
+}
+
+// order returns the hosts in the order they are tried for the connect with number no.
+func (hp HostPolicy) order(hosts []string, no uint64) []string {
+	if len(hosts) <= 1 {
+		return hosts
+	}
+	ordered := make([]string, len(hosts))
+	switch hp {
+	case HostPolicyRandom:
+		for i, j := range rand.Perm(len(hosts)) {
+			ordered[i] = hosts[j]
+		}
+	case HostPolicyRoundRobin:
+		ofs := int(no % uint64(len(hosts)))
+		copy(ordered, hosts[ofs:])
+		copy(ordered[len(hosts)-ofs:], hosts[:ofs])
+	default:
+		copy(ordered, hosts)
+	}
+	return ordered
+}
+
+/*
+tryHosts calls fn for the hosts one after another until fn succeeds for one host.
+
+Authentication errors and errors caused by the context end the failover, as trying the next host would not succeed.
+In case fn fails for all hosts the errors of all hosts are returned.
+*/
+func tryHosts[T any](ctx context.Context, hosts []string, fn func(host string) (T, error)) (T, error) {
+	if len(hosts) == 1 {
+		return fn(hosts[0])
+	}
+	var zero T
+	var errs []error
+	for _, host := range hosts {
+		r, err := fn(host)
+		if err == nil {
+			return r, nil
+		}
+		if isAuthError(err) || ctx.Err() != nil {
+			return zero, err
+		}
+		errs = append(errs, fmt.Errorf("host %s: %w", host, err))
+	}
+	if len(errs) == 0 {
+		return zero, errors.New("no host provided")
+	}
+	return zero, errors.Join(errs...)
+}
@@ -0,0 +1,91 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/SAP/go-hdb/driver/dial"
+)
+
+func TestHostPolicyOrder(t *testing.T) {
+	hosts := splitHosts("host1:30015, host2:30015,,host3:30015")
+	if expected := []string{"host1:30015", "host2:30015", "host3:30015"}; !slices.Equal(hosts, expected) {
+		t.Fatalf("hosts %v - expected %v", hosts, expected)
+	}
+
+	if ordered := HostPolicySequential.order(hosts, 1); !slices.Equal(ordered, hosts) {
+		t.Fatalf("sequential order %v - expected %v", ordered, hosts)
+	}
+
+	for no, expected := range [][]string{
+		{"host1:30015", "host2:30015", "host3:30015"},
+		{"host2:30015", "host3:30015", "host1:30015"},
+		{"host3:30015", "host1:30015", "host2:30015"},
+		{"host1:30015", "host2:30015", "host3:30015"},
+	} {
+		if ordered := HostPolicyRoundRobin.order(hosts, uint64(no)); !slices.Equal(ordered, expected) {
+			t.Fatalf("round robin order %v for connect %d - expected %v", ordered, no, expected)
+		}
+	}
+
+	ordered := HostPolicyRandom.order(hosts, 0)
+	slices.Sort(ordered)
+	if !slices.Equal(ordered, hosts) {
+		t.Fatalf("random order %v - expected permutation of %v", ordered, hosts)
+	}
+}
+
+// failingDialer refuses connections and records the dialed addresses.
+type failingDialer struct {
+	mu        sync.Mutex
+	addresses []string
+}
+
+var errConnectionRefused = errors.New("connection refused")
+
+func (d *failingDialer) DialContext(ctx context.Context, address string, options dial.DialerOptions) (net.Conn, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.addresses = append(d.addresses, address)
+	return nil, errConnectionRefused
+}
+
+func TestHostFailover(t *testing.T) {
+	hosts := []string{"host1:30015", "host2:30015", "host3:30015"}
+
+	dialer := &failingDialer{}
+	connector := NewBasicAuthConnector("host1:30015,host2:30015,host3:30015", "user", "password")
+	connector.SetDialer(dialer)
+	connector.SetHostPolicy(HostPolicyRoundRobin)
+
+	for no := 0; no < 2; no++ {
+		dialer.addresses = nil
+		_, err := connector.Connect(context.Background())
+		if !errors.Is(err, errConnectionRefused) {
+			t.Fatalf("error %v - expected %v", err, errConnectionRefused)
+		}
+		if expected := HostPolicyRoundRobin.order(hosts, uint64(no)); !slices.Equal(dialer.addresses, expected) {
+			t.Fatalf("dialed hosts %v - expected %v", dialer.addresses, expected)
+		}
+	}
+
+	// failover ends on successful connect
+	calls := 0
+	host, err := tryHosts(context.Background(), hosts, func(host string) (string, error) {
+		calls++
+		if host == "host2:30015" {
+			return host, nil
+		}
+		return "", errConnectionRefused
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if host != "host2:30015" || calls != 2 {
+		t.Fatalf("connected to %s after %d attempts - expected %s after %d attempts", host, calls, "host2:30015", 2)
+	}
+}
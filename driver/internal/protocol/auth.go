@@ -38,6 +38,12 @@ func (a *AuthHnd) AddJWT(token string) { a.methods[auth.MtJWT] = auth.NewJWT(tok
 // AddX509 adds X509 authentication method.
 func (a *AuthHnd) AddX509(certKey *auth.CertKey) { a.methods[auth.MtX509] = auth.NewX509(certKey) }
 
+// AddExchange adds an authentication method of type typ exchanging the method specific data via ex.
+// An already added method of the same type is replaced.
+func (a *AuthHnd) AddExchange(typ string, ex auth.Exchanger) {
+	a.methods[typ] = auth.NewExchange(typ, auth.MoExchange, ex)
+}
+
 // Selected returns the selected authentication method.
 func (a *AuthHnd) Selected() auth.Method { return a.selected }
 
@@ -83,8 +89,9 @@ type AuthInitRequest struct {
 	prms *auth.Prms
 }
 
-func (r *AuthInitRequest) String() string { return r.prms.String() }
-func (r *AuthInitRequest) size() int      { return r.prms.Size() }
+func (r *AuthInitRequest) String() string         { return r.prms.String() }
+func (r *AuthInitRequest) redactedString() string { return r.prms.RedactedString() }
+func (r *AuthInitRequest) size() int              { return r.prms.Size() }
 func (r *AuthInitRequest) decode(dec *encoding.Decoder) error {
 	return r.prms.Decode(dec)
 }
@@ -96,12 +103,12 @@ type AuthInitReply struct {
 }
 
 func (r *AuthInitReply) String() string { return r.authHnd.String() }
-func (r *AuthInitReply) decode(dec *encoding.Decoder) error {
+func (r *AuthInitReply) decodeBufLen(dec *encoding.Decoder, bufLen int) error {
 	if r.authHnd == nil {
 		return nil
 	}
 
-	d := auth.NewDecoder(dec)
+	d := auth.NewDecoder(dec, bufLen)
 
 	if err := d.NumPrm(2); err != nil {
 		return err
@@ -122,8 +129,9 @@ type AuthFinalRequest struct {
 	prms *auth.Prms
 }
 
-func (r *AuthFinalRequest) String() string { return r.prms.String() }
-func (r *AuthFinalRequest) size() int      { return r.prms.Size() }
+func (r *AuthFinalRequest) String() string         { return r.prms.String() }
+func (r *AuthFinalRequest) redactedString() string { return r.prms.RedactedString() }
+func (r *AuthFinalRequest) size() int              { return r.prms.Size() }
 func (r *AuthFinalRequest) decode(dec *encoding.Decoder) error {
 	return nil
 	// panic("not implemented yet")
@@ -136,12 +144,18 @@ type AuthFinalReply struct {
 }
 
 func (r *AuthFinalReply) String() string { return r.method.String() }
-func (r *AuthFinalReply) decode(dec *encoding.Decoder) error {
+func (r *AuthFinalReply) redactedString() string {
+	if r.method == nil {
+		return "<nil>"
+	}
+	return "method type " + r.method.Typ()
+}
+func (r *AuthFinalReply) decodeBufLen(dec *encoding.Decoder, bufLen int) error {
 	if r.method == nil {
 		return nil
 	}
 
-	if err := r.method.FinalRepDecode(auth.NewDecoder(dec)); err != nil {
+	if err := r.method.FinalRepDecode(auth.NewDecoder(dec, bufLen)); err != nil {
 		return err
 	}
 	return dec.Error()
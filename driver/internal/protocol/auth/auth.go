@@ -31,6 +31,7 @@ const (
 	MoSessionCookie byte = iota
 	MoX509
 	MoJWT
+	MoExchange
 	MoSCRAMPBKDF2SHA256
 	MoSCRAMSHA256
 )
@@ -70,6 +71,9 @@ var (
 	_ Method = (*JWT)(nil)
 	_ Method = (*X509)(nil)
 	_ Method = (*SessionCookie)(nil)
+	_ Method = (*Exchange)(nil)
+
+	_ Exchanger = (*SCRAMSHA256)(nil)
 )
 
 // subPrmsSize is the type used to encode and decode the size of sub parameters.
@@ -119,14 +123,18 @@ func (s *subPrmsSize) decode(d *encoding.Decoder) {
 
 // Decoder represents an authentication decoder.
 type Decoder struct {
-	d *encoding.Decoder
+	d   *encoding.Decoder
+	end int // byte read counter value at the end of the payload
 }
 
-// NewDecoder returns a new decoder instance.
-func NewDecoder(d *encoding.Decoder) *Decoder {
-	return &Decoder{d: d}
+// NewDecoder returns a new decoder instance for a payload of size bytes.
+func NewDecoder(d *encoding.Decoder, size int) *Decoder {
+	return &Decoder{d: d, end: d.Cnt() + size}
 }
 
+// remaining returns the number of unread payload bytes.
+func (d *Decoder) remaining() int { return d.end - d.d.Cnt() }
+
 // NumPrm ckecks the number of parameters and returns an error if not equal expected, nil otherwise.
 func (d *Decoder) NumPrm(expected int) error {
 	numPrm := int(d.d.Int16())
@@ -159,6 +167,23 @@ type Prms struct {
 
 func (p *Prms) String() string { return fmt.Sprintf("%v", p.prms) }
 
+// RedactedString returns the string representation of the parameters with masked values.
+// Only the size of the values is kept.
+func (p *Prms) RedactedString() string {
+	s := make([]string, len(p.prms))
+	for i, e := range p.prms {
+		switch e := e.(type) {
+		case []byte:
+			s[i] = fmt.Sprintf("***(%d)", len(e))
+		case string:
+			s[i] = fmt.Sprintf("***(%d)", len(e))
+		case *Prms:
+			s[i] = e.RedactedString()
+		}
+	}
+	return fmt.Sprintf("%v", s)
+}
+
 // AddCESU8String adds a CESU8 string parameter.
 func (p *Prms) AddCESU8String(s string) { p.prms = append(p.prms, s) } // unicode string
 func (p *Prms) addEmpty()               { p.prms = append(p.prms, []byte{}) }
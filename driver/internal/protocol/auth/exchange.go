@@ -0,0 +1,142 @@
+package auth
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/SAP/go-hdb/driver/internal/protocol/encoding"
+	"github.com/SAP/go-hdb/driver/unicode/cesu8"
+)
+
+// An Exchanger provides the method specific data of an authentication method.
+//
+// The data is opaque to the authentication handler: for methods using sub parameters (like the SCRAM methods)
+// the data contains the encoded sub parameters.
+type Exchanger interface {
+	// InitData returns the client data of the initial authentication request.
+	InitData() ([]byte, error)
+	// FinalData returns the logon name and the client data of the final authentication request
+	// based on the server data of the initial authentication reply.
+	FinalData(initReply []byte) (logonname string, data []byte, err error)
+}
+
+// Exchange implements the Method interface for an Exchanger.
+type Exchange struct {
+	typ        string
+	order      byte
+	ex         Exchanger
+	initReply  []byte
+	finalReply []byte
+}
+
+// NewExchange creates a new Exchange instance.
+func NewExchange(typ string, order byte, ex Exchanger) *Exchange {
+	return &Exchange{typ: typ, order: order, ex: ex}
+}
+
+func (a *Exchange) String() string { return fmt.Sprintf("method type %s", a.typ) }
+
+// FinalReply returns the server data of the final authentication reply.
+func (a *Exchange) FinalReply() []byte { return a.finalReply }
+
+// Typ implements the Method interface.
+func (a *Exchange) Typ() string { return a.typ }
+
+// Order implements the Method interface.
+func (a *Exchange) Order() byte { return a.order }
+
+// PrepareInitReq implements the Method interface.
+func (a *Exchange) PrepareInitReq(prms *Prms) error {
+	data, err := a.ex.InitData()
+	if err != nil {
+		return err
+	}
+	prms.addString(a.typ)
+	prms.addBytes(data)
+	return nil
+}
+
+// InitRepDecode implements the Method interface.
+func (a *Exchange) InitRepDecode(d *Decoder) error {
+	var err error
+	a.initReply, err = d.rawBytes()
+	return err
+}
+
+// PrepareFinalReq implements the Method interface.
+func (a *Exchange) PrepareFinalReq(prms *Prms) error {
+	logonname, data, err := a.ex.FinalData(a.initReply)
+	if err != nil {
+		return err
+	}
+	prms.AddCESU8String(logonname)
+	prms.addString(a.typ)
+	prms.addBytes(data)
+	return nil
+}
+
+// FinalRepDecode implements the Method interface.
+func (a *Exchange) FinalRepDecode(d *Decoder) error {
+	if err := d.NumPrm(2); err != nil {
+		return err
+	}
+	mt := d.String()
+	if err := checkAuthMethodType(mt, a.typ); err != nil {
+		return err
+	}
+	var err error
+	a.finalReply, err = d.rawBytes()
+	return err
+}
+
+// length indicators of raw authentication parameters.
+const (
+	rawSizeMedium = 246 // 2 byte little endian size
+	rawSizeBig    = 247 // 4 byte little endian size
+)
+
+// rawBytes decodes a parameter value whether encoded as length indicated value or as sub parameters.
+func (d *Decoder) rawBytes() ([]byte, error) {
+	var size int
+	switch b := d.d.Byte(); {
+	case b <= maxSubPrmsSize1ByteLen:
+		size = int(b)
+	case b == rawSizeMedium:
+		size = int(d.d.Uint16())
+	case b == rawSizeBig:
+		size = int(d.d.Uint32())
+	case b == subPrmsSize2ByteIndicator:
+		size = int(d.d.Uint16ByteOrder(binary.BigEndian))
+	default:
+		return nil, fmt.Errorf("invalid parameter size indicator %d", b)
+	}
+	if err := d.d.Error(); err != nil {
+		return nil, err
+	}
+	if remaining := d.remaining(); size > remaining {
+		return nil, fmt.Errorf("invalid parameter size %d - exceeds remaining payload size %d", size, remaining)
+	}
+	b := make([]byte, size)
+	d.d.Bytes(b)
+	return b, d.d.Error()
+}
+
+// encodePrms returns the encoded parameters (e.g. to be used as Exchanger data).
+func encodePrms(prms *Prms) ([]byte, error) {
+	buf := bytes.Buffer{}
+	enc := encoding.NewEncoder(&buf, cesu8.DefaultEncoder)
+	if err := prms.Encode(enc); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decodePrms decodes the encoded parameters (e.g. Exchanger reply data) calling fn for the decoding.
+func decodePrms(b []byte, fn func(d *Decoder) error) error {
+	dec := encoding.NewDecoder(bytes.NewReader(b), cesu8.DefaultDecoder)
+	if err := fn(NewDecoder(dec, len(b))); err != nil {
+		return err
+	}
+	return dec.Error()
+}
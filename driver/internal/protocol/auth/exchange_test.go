@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRawBytes(t *testing.T) {
+	testData := []struct {
+		name  string
+		data  []byte
+		value []byte
+		fails bool
+	}{
+		{"1 byte size", []byte("\x03abc"), []byte("abc"), false},
+		{"medium size", []byte("\xf6\x03\x00abc"), []byte("abc"), false},
+		{"big size", []byte("\xf7\x03\x00\x00\x00abc"), []byte("abc"), false},
+		{"sub parameters size", []byte("\xff\x00\x03abc"), []byte("abc"), false},
+		{"invalid size indicator", []byte("\xf8abc"), nil, true},
+		{"truncated size", []byte("\xf7\x03\x00"), nil, true},
+		{"size exceeding payload", []byte("\x04abc"), nil, true},
+		{"negative signed medium size", []byte("\xf6\xff\xffabc"), nil, true},
+		{"negative signed big size", []byte("\xf7\xff\xff\xff\xffabc"), nil, true},
+	}
+
+	for _, r := range testData {
+		t.Run(r.name, func(t *testing.T) {
+			var value []byte
+			err := decodePrms(r.data, func(d *Decoder) error {
+				var err error
+				value, err = d.rawBytes()
+				return err
+			})
+			switch {
+			case r.fails && err == nil:
+				t.Fatalf("got value %v - expected error", value)
+			case !r.fails && err != nil:
+				t.Fatal(err)
+			case !bytes.Equal(value, r.value):
+				t.Fatalf("got value %v - expected %v", value, r.value)
+			}
+		})
+	}
+}
@@ -7,7 +7,11 @@ import (
 )
 
 // SCRAMSHA256 implements SCRAMSHA256 authentication.
+//
+// SCRAMSHA256 is implemented as Exchanger and is the reference for authentication methods
+// provided by the application.
 type SCRAMSHA256 struct {
+	*Exchange
 	username, password       string
 	clientChallenge          []byte
 	salt, serverChallenge    []byte
@@ -16,76 +20,68 @@ type SCRAMSHA256 struct {
 
 // NewSCRAMSHA256 creates a new authSCRAMSHA256 instance.
 func NewSCRAMSHA256(username, password string) *SCRAMSHA256 {
-	return &SCRAMSHA256{username: username, password: password, clientChallenge: clientChallenge()}
+	a := &SCRAMSHA256{username: username, password: password, clientChallenge: clientChallenge()}
+	a.Exchange = NewExchange(MtSCRAMSHA256, MoSCRAMSHA256, a)
+	return a
 }
 
 func (a *SCRAMSHA256) String() string {
 	return fmt.Sprintf("method type %s clientChallenge %v", a.Typ(), a.clientChallenge)
 }
 
-// Typ implements the Method interface.
-func (a *SCRAMSHA256) Typ() string { return MtSCRAMSHA256 }
+// InitData implements the Exchanger interface.
+func (a *SCRAMSHA256) InitData() ([]byte, error) { return a.clientChallenge, nil }
 
-// Order implements the Method interface.
-func (a *SCRAMSHA256) Order() byte { return MoSCRAMSHA256 }
-
-// PrepareInitReq implements the Method interface.
-func (a *SCRAMSHA256) PrepareInitReq(prms *Prms) error {
-	prms.addString(a.Typ())
-	prms.addBytes(a.clientChallenge)
-	return nil
-}
-
-// InitRepDecode implements the Method interface.
-func (a *SCRAMSHA256) InitRepDecode(d *Decoder) error {
-	d.subSize() // sub parameters
-	if err := d.NumPrm(2); err != nil {
-		return err
+// FinalData implements the Exchanger interface.
+func (a *SCRAMSHA256) FinalData(initReply []byte) (string, []byte, error) {
+	if err := decodePrms(initReply, func(d *Decoder) error {
+		if err := d.NumPrm(2); err != nil {
+			return err
+		}
+		a.salt = d.bytes()
+		a.serverChallenge = d.bytes()
+		return nil
+	}); err != nil {
+		return "", nil, err
 	}
-	a.salt = d.bytes()
-	a.serverChallenge = d.bytes()
 	if err := checkSalt(a.salt); err != nil {
-		return err
+		return "", nil, err
 	}
 	if err := checkServerChallenge(a.serverChallenge); err != nil {
-		return err
+		return "", nil, err
 	}
-	return nil
-}
 
-// PrepareFinalReq implements the Method interface.
-func (a *SCRAMSHA256) PrepareFinalReq(prms *Prms) error {
 	key := scramsha256Key([]byte(a.password), a.salt)
 	a.clientProof = clientProof(key, a.salt, a.serverChallenge, a.clientChallenge)
 	if err := checkClientProof(a.clientProof); err != nil {
-		return err
+		return "", nil, err
 	}
 
-	prms.AddCESU8String(a.username)
-	prms.addString(a.Typ())
-	subPrms := prms.addPrms()
+	subPrms := &Prms{}
 	subPrms.addBytes(a.clientProof)
-
-	return nil
+	data, err := encodePrms(subPrms)
+	if err != nil {
+		return "", nil, err
+	}
+	return a.username, data, nil
 }
 
 // FinalRepDecode implements the Method interface.
 func (a *SCRAMSHA256) FinalRepDecode(d *Decoder) error {
-	if err := d.NumPrm(2); err != nil {
-		return err
-	}
-	mt := d.String()
-	if err := checkAuthMethodType(mt, a.Typ()); err != nil {
+	if err := a.Exchange.FinalRepDecode(d); err != nil {
 		return err
 	}
-	if d.subSize() == 0 { // mnSCRAMSHA256: server does not return server proof parameter
+	finalReply := a.FinalReply()
+	if len(finalReply) == 0 { // mnSCRAMSHA256: server does not return server proof parameter
 		return nil
 	}
-	if err := d.NumPrm(1); err != nil {
-		return err
-	}
-	a.serverProof = d.bytes()
-	return nil
+	return decodePrms(finalReply, func(d *Decoder) error {
+		if err := d.NumPrm(1); err != nil {
+			return err
+		}
+		a.serverProof = d.bytes()
+		return nil
+	})
 }
 
 func scramsha256Key(password, salt []byte) []byte {
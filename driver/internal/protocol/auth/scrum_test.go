@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"bytes"
 	"testing"
 )
 
@@ -53,3 +54,42 @@ func TestSCRUM(t *testing.T) {
 		}
 	}
 }
+
+func TestSCRAMSHA256Exchange(t *testing.T) {
+	salt := []byte{214, 199, 255, 118, 92, 174, 94, 190, 197, 225, 57, 154, 157, 109, 119, 245}
+	serverChallenge := []byte{224, 22, 242, 18, 237, 99, 6, 28, 162, 248, 96, 7, 115, 152, 134, 65, 141, 65, 168, 126, 168, 86, 87, 72, 16, 119, 12, 91, 227, 123, 51, 194, 203, 168, 56, 133, 70, 236, 230, 214, 89, 167, 130, 123, 132, 178, 211, 186}
+	clientChallenge := []byte{219, 141, 27, 200, 255, 90, 182, 125, 133, 151, 127, 36, 26, 106, 213, 31, 57, 89, 50, 201, 237, 11, 158, 110, 8, 13, 2, 71, 9, 235, 213, 27, 64, 43, 181, 181, 147, 140, 10, 63, 156, 133, 133, 165, 171, 67, 187, 250, 41, 145, 176, 164, 137, 54, 72, 42, 47, 112, 252, 77, 102, 152, 220, 223}
+	expectedProof := []byte{23, 243, 209, 70, 117, 54, 25, 92, 21, 173, 194, 108, 63, 25, 188, 185, 230, 61, 124, 190, 73, 80, 225, 126, 191, 119, 32, 112, 231, 72, 184, 199}
+
+	a := NewSCRAMSHA256("user", "Admin1234")
+	a.clientChallenge = clientChallenge
+
+	prms := &Prms{}
+	prms.addBytes(salt)
+	prms.addBytes(serverChallenge)
+	initReply, err := encodePrms(prms)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logonname, data, err := a.FinalData(initReply)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if logonname != "user" {
+		t.Fatalf("expected logonname %s - got %s", "user", logonname)
+	}
+	var proof []byte
+	if err := decodePrms(data, func(d *Decoder) error {
+		if err := d.NumPrm(1); err != nil {
+			return err
+		}
+		proof = d.bytes()
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(proof, expectedProof) {
+		t.Fatalf("got proof %v - expected %v", proof, expectedProof)
+	}
+}
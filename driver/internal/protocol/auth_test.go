@@ -20,10 +20,10 @@ func authEncodeStep(t *testing.T, part writablePart) []byte {
 	return buf.Bytes()
 }
 
-func authDecodeStep(t *testing.T, part defPart, data []byte) {
+func authDecodeStep(t *testing.T, part bufLenPart, data []byte) {
 	dec := encoding.NewDecoder(bytes.NewBuffer(data), cesu8.DefaultDecoder)
 
-	if err := part.decode(dec); err != nil {
+	if err := part.decodeBufLen(dec, len(data)); err != nil {
 		t.Fatal(err)
 	}
 }
@@ -100,12 +100,152 @@ func testJWTAuth(t *testing.T) {
 	}
 }
 
+func testSessionCookieAuth(t *testing.T) {
+	a := NewAuthHnd("USER123")
+	a.AddSessionCookie([]byte("5be8f43e064e0589ce07ba9de6fce107"), "USER123", "4711@host")
+
+	successful := t.Run("init request", func(t *testing.T) {
+		initRequest, err := a.InitRequest()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		actual := authEncodeStep(t, initRequest)
+		expected := []byte("\x03\x00\x07USER123\x0DSessionCookie\x295be8f43e064e0589ce07ba9de6fce1074711@host")
+
+		if !bytes.Equal(expected, actual) {
+			t.Fatalf("expected %q, got %q", string(expected), string(actual))
+		}
+	})
+
+	if successful {
+		successful = t.Run("init reply", func(t *testing.T) {
+			initReply, err := a.InitReply()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			authDecodeStep(t, initReply, []byte("\x02\x00\x0DSessionCookie\x00"))
+
+			if _, ok := a.Selected().(*auth.SessionCookie); !ok {
+				t.Fatalf("expected session cookie method, got %s", a.Selected())
+			}
+		})
+	}
+
+	if successful {
+		successful = t.Run("final request", func(t *testing.T) {
+			finalRequest, err := a.FinalRequest()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			actual := authEncodeStep(t, finalRequest)
+			expected := []byte("\x03\x00\x07USER123\x0DSessionCookie\x00")
+
+			if !bytes.Equal(expected, actual) {
+				t.Fatalf("expected %q, got %q", string(expected), string(actual))
+			}
+		})
+	}
+
+	if successful {
+		t.Run("final reply", func(t *testing.T) {
+			finalReply, err := a.FinalReply()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			authDecodeStep(t, finalReply, []byte("\x02\x00\x0DSessionCookie\x00"))
+		})
+	}
+}
+
+type testExchanger struct {
+	initReply []byte
+}
+
+func (e *testExchanger) InitData() ([]byte, error) { return []byte("ticket"), nil }
+func (e *testExchanger) FinalData(initReply []byte) (string, []byte, error) {
+	e.initReply = initReply
+	return "USER123", []byte("proof"), nil
+}
+
+func testExchangeAuth(t *testing.T) {
+	a := NewAuthHnd("")
+	ex := &testExchanger{}
+	a.AddExchange("SSO", ex)
+
+	successful := t.Run("init request", func(t *testing.T) {
+		initRequest, err := a.InitRequest()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		actual := authEncodeStep(t, initRequest)
+		expected := []byte("\x03\x00\x00\x03SSO\x06ticket")
+
+		if !bytes.Equal(expected, actual) {
+			t.Fatalf("expected %q, got %q", string(expected), string(actual))
+		}
+	})
+
+	if successful {
+		successful = t.Run("init reply", func(t *testing.T) {
+			initReply, err := a.InitReply()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			authDecodeStep(t, initReply, []byte("\x02\x00\x03SSO\x09challenge"))
+		})
+	}
+
+	if successful {
+		successful = t.Run("final request", func(t *testing.T) {
+			finalRequest, err := a.FinalRequest()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(ex.initReply) != "challenge" {
+				t.Fatalf("expected init reply %q, got %q", "challenge", string(ex.initReply))
+			}
+
+			actual := authEncodeStep(t, finalRequest)
+			expected := []byte("\x03\x00\x07USER123\x03SSO\x05proof")
+
+			if !bytes.Equal(expected, actual) {
+				t.Fatalf("expected %q, got %q", string(expected), string(actual))
+			}
+		})
+	}
+
+	if successful {
+		t.Run("final reply", func(t *testing.T) {
+			finalReply, err := a.FinalReply()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// server data encoded as sub parameters with 2 byte size indicator
+			authDecodeStep(t, finalReply, []byte("\x02\x00\x03SSO\xff\x00\x04done"))
+
+			if actual := string(a.Selected().(*auth.Exchange).FinalReply()); actual != "done" {
+				t.Fatalf("expected %q, got %q", "done", actual)
+			}
+		})
+	}
+}
+
 func TestAuth(t *testing.T) {
 	tests := []struct {
 		name string
 		fct  func(t *testing.T)
 	}{
 		{"testJWTAuth", testJWTAuth},
+		{"testSessionCookieAuth", testSessionCookieAuth},
+		{"testExchangeAuth", testExchangeAuth},
 	}
 
 	for _, test := range tests {
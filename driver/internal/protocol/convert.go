@@ -1,15 +1,21 @@
 package protocol
 
 import (
+	stdencoding "encoding"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"math"
 	"math/big"
+	"net"
+	"net/netip"
 	"reflect"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/SAP/go-hdb/driver/internal/protocol/julian"
+	"github.com/SAP/go-hdb/driver/spatial"
 )
 
 // ErrUint64OutOfRange means that a uint64 exceeds the size of a int64.
@@ -24,6 +30,9 @@ var ErrFloatOutOfRange = errors.New("float out of range error")
 // ErrDecimalOutOfRange means that a big.Rat exceeds the size of hdb decimal fields.
 var ErrDecimalOutOfRange = errors.New("decimal out of range error")
 
+// ErrInvalidIPAddr means that an ip address is zero or invalid.
+var ErrInvalidIPAddr = errors.New("invalid ip address")
+
 // A ConvertError is returned by conversion methods if a go datatype to hdb datatype conversion fails.
 type ConvertError struct {
 	err error
@@ -44,6 +53,13 @@ func newConvertError(ft fieldType, v any, err error) *ConvertError {
 	return &ConvertError{ft: ft, v: v, err: err}
 }
 
+func durationUnitOrDefault(unit time.Duration) time.Duration {
+	if unit <= 0 {
+		return DefaultDurationUnit
+	}
+	return unit
+}
+
 /*
 Conversion routines hdb parameters
   - return value is any to avoid allocations in case
@@ -88,11 +104,19 @@ func convertBool(ft fieldType, v any) (any, error) {
 	return nil, newConvertError(ft, v, nil)
 }
 
-func convertInteger(ft fieldType, v any, min, max int64) (any, error) {
+func convertInteger(ft fieldType, v any, min, max int64, unit time.Duration) (any, error) {
 	if v == nil {
 		return v, nil
 	}
 
+	if d, ok := v.(time.Duration); ok { // convert to duration unit (truncated)
+		i64 := int64(d / durationUnitOrDefault(unit))
+		if i64 > max || i64 < min {
+			return nil, newConvertError(ft, v, ErrIntegerOutOfRange)
+		}
+		return i64, nil
+	}
+
 	rv := reflect.ValueOf(v)
 	switch rv.Kind() {
 	// conversions without allocations (return v)
@@ -139,20 +163,24 @@ func convertInteger(ft fieldType, v any, min, max int64) (any, error) {
 		if rv.IsNil() {
 			return nil, nil
 		}
-		return convertInteger(ft, rv.Elem().Interface(), min, max)
+		return convertInteger(ft, rv.Elem().Interface(), min, max, unit)
 	}
 	// last resort (try via string)
 	if rv.Type().ConvertibleTo(stringReflectType) {
-		return convertInteger(ft, rv.Convert(stringReflectType).Interface(), min, max)
+		return convertInteger(ft, rv.Convert(stringReflectType).Interface(), min, max, unit)
 	}
 	return nil, newConvertError(ft, v, nil)
 }
 
-func convertFloat(ft fieldType, v any, max float64) (any, error) {
+func convertFloat(ft fieldType, v any, max float64, unit time.Duration) (any, error) {
 	if v == nil {
 		return v, nil
 	}
 
+	if d, ok := v.(time.Duration); ok { // convert to duration unit (fractional)
+		return float64(d) / float64(durationUnitOrDefault(unit)), nil
+	}
+
 	rv := reflect.ValueOf(v)
 	switch rv.Kind() {
 	// conversions without allocations (return v)
@@ -177,11 +205,11 @@ func convertFloat(ft fieldType, v any, max float64) (any, error) {
 		if rv.IsNil() {
 			return nil, nil
 		}
-		return convertFloat(ft, rv.Elem().Interface(), max)
+		return convertFloat(ft, rv.Elem().Interface(), max, unit)
 	}
 	// last resort (try via string)
 	if rv.Type().ConvertibleTo(stringReflectType) {
-		return convertFloat(ft, rv.Convert(stringReflectType).Interface(), max)
+		return convertFloat(ft, rv.Convert(stringReflectType).Interface(), max, unit)
 	}
 	return nil, newConvertError(ft, v, nil)
 }
@@ -212,23 +240,62 @@ func convertTime(ft fieldType, v any) (any, error) {
 	return nil, newConvertError(ft, v, nil)
 }
 
+// maxTimeFraction is the maximum fractional second precision of hdb timestamps (100 nanoseconds).
+const maxTimeFraction = 7
+
+/*
+convertTimeFraction converts v like convertTime and truncates the result to the fractional
+second precision of the parameter (e.g. 3 for TIMESTAMP(3)) if fraction is greater than zero.
+A fraction of zero is the default parameter metadata value and does not truncate.
+*/
+func convertTimeFraction(ft fieldType, v any, fraction int) (any, error) {
+	cv, err := convertTime(ft, v)
+	if t, ok := cv.(time.Time); ok && fraction > 0 {
+		d := time.Second
+		for i := 0; i < fraction; i++ {
+			d /= 10
+		}
+		return t.Truncate(d), nil
+	}
+	return cv, err
+}
+
 /*
 Currently the min, max check is done during encoding, as the check is expensive and
 we want to avoid doing the conversion twice (convert + encode).
 These checks could be done in convert only, but then we would need a
 struct{m *big.Int, exp int} for decimals as intermediate format.
 
-We would be able to accept other datatypes as well, like
-int??, *big.Int, string, ...
-but as the user needs to use Decimal anyway (scan), we go with
-*big.Rat only for the time being.
+Besides *big.Rat (Decimal) *big.Int values are accepted to support large exact
+integer values without losing precision.
 */
 func convertDecimal(ft fieldType, v any) (any, error) {
 	if v == nil {
 		return nil, nil
 	}
-	if v, ok := v.(*big.Rat); ok {
+
+	switch v := v.(type) {
+	case *big.Rat:
+		if v == nil {
+			return nil, nil
+		}
 		return v, nil
+	case *big.Int:
+		if v == nil {
+			return nil, nil
+		}
+		return new(big.Rat).SetInt(v), nil
+	case string:
+		return parseDecimal(ft, v, v)
+	case []byte:
+		return parseDecimal(ft, v, string(v))
+	case stdencoding.TextMarshaler:
+		// custom decimal types (e.g. of third party decimal libraries) marshaling themselves
+		b, err := v.MarshalText()
+		if err != nil {
+			return nil, newConvertError(ft, v, err)
+		}
+		return parseDecimal(ft, v, string(b))
 	}
 
 	rv := reflect.ValueOf(v)
@@ -242,13 +309,47 @@ func convertDecimal(ft fieldType, v any) (any, error) {
 	}
 
 	if rv.Type().ConvertibleTo(ratReflectType) {
-		tv := rv.Convert(ratReflectType)
-		return tv.Interface().(big.Rat), nil
+		r := rv.Convert(ratReflectType).Interface().(big.Rat)
+		return new(big.Rat).Set(&r), nil
+	}
+	if rv.Type().ConvertibleTo(intReflectType) {
+		i := rv.Convert(intReflectType).Interface().(big.Int)
+		return new(big.Rat).SetInt(&i), nil
 	}
 
 	return nil, newConvertError(ft, v, nil)
 }
 
+// convertText converts values of character fields: in addition to convertBytes ip addresses and
+// custom types implementing encoding.TextMarshaler are converted to their text representation.
+func convertText(ft fieldType, v any) (any, error) {
+	switch v := v.(type) {
+	case netip.Addr:
+		if !v.IsValid() {
+			return nil, newConvertError(ft, v, ErrInvalidIPAddr)
+		}
+		return v.String(), nil
+	case net.IP:
+		if v.To16() == nil { // nil or invalid length
+			return nil, newConvertError(ft, v, ErrInvalidIPAddr)
+		}
+		return v.String(), nil
+	case stdencoding.TextMarshaler:
+		// custom types (e.g. UUID) marshaling themselves
+		b, err := v.MarshalText()
+		if err != nil {
+			return nil, newConvertError(ft, v, err)
+		}
+		return b, nil
+	}
+
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		// indirect pointers
+		return convertText(ft, rv.Elem().Interface())
+	}
+	return convertBytes(ft, v)
+}
+
 func convertBytes(ft fieldType, v any) (any, error) {
 	if v == nil {
 		return v, nil
@@ -285,6 +386,45 @@ func convertBytes(ft fieldType, v any) (any, error) {
 	return nil, newConvertError(ft, v, nil)
 }
 
+/*
+convertGeometry converts spatial values (ST_GEOMETRY, ST_POINT) to hex encoded "well known binary" values.
+Supported are
+  - geometries (see package spatial),
+  - binary (ewkb, wkb) values and
+  - hex encoded binary values.
+*/
+func convertGeometry(ft fieldType, v any) (any, error) {
+	switch v := v.(type) {
+	case spatial.Geometry:
+		b, err := spatial.EncodeWKB(v, false)
+		if err != nil {
+			return nil, newConvertError(ft, v, err)
+		}
+		return b, nil
+	case []byte:
+		if len(v) != 0 && (v[0] == spatial.XDR || v[0] == spatial.NDR) { // binary: first byte is byte order
+			return []byte(hex.EncodeToString(v)), nil
+		}
+	}
+	return convertBytes(ft, v)
+}
+
+// convertTextEmptyAsNull converts like convertText, but returns nil (NULL) for empty strings and byte slices.
+func convertTextEmptyAsNull(ft fieldType, v any) (any, error) {
+	cv, err := convertText(ft, v)
+	switch cv := cv.(type) {
+	case string:
+		if cv == "" {
+			return nil, err
+		}
+	case []byte:
+		if len(cv) == 0 {
+			return nil, err
+		}
+	}
+	return cv, err
+}
+
 // decimals.
 const (
 	// http://en.wikipedia.org/wiki/Decimal128_floating-point_format
@@ -318,6 +458,75 @@ const (
 	dfUnderflow
 )
 
+// parseDecimal converts the decimal text s (e.g. "-1.50", "1e-3") of value v exactly.
+func parseDecimal(ft fieldType, v any, s string) (any, error) {
+	if !isDecimalText(s) {
+		return nil, newConvertError(ft, v, nil)
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, newConvertError(ft, v, nil)
+	}
+	return r, nil
+}
+
+// isDecimalText returns true if s is a decimal number text ([+-]digits[.digits][(e|E)[+-]digits]).
+// In contrast to big.Rat SetString fractions (e.g. "1/3") and non decimal bases (e.g. "0x1p-2") are rejected.
+func isDecimalText(s string) bool {
+	i := 0
+	sign := func() {
+		if i < len(s) && (s[i] == '+' || s[i] == '-') {
+			i++
+		}
+	}
+	digits := func() int {
+		start := i
+		for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+			i++
+		}
+		return i - start
+	}
+
+	sign()
+	n := digits()
+	if i < len(s) && s[i] == '.' {
+		i++
+		n += digits()
+	}
+	if n == 0 {
+		return false
+	}
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		i++
+		sign()
+		if digits() == 0 {
+			return false
+		}
+	}
+	return i == len(s)
+}
+
+// formatDecimal returns the exact decimal text of m * 10^exp keeping the scale -exp (e.g. m 150, exp -2: "1.50").
+func formatDecimal(m *big.Int, exp int) string {
+	digits := m.String()
+	sign := ""
+	if digits[0] == '-' {
+		sign, digits = "-", digits[1:]
+	}
+	if exp >= 0 {
+		if digits == "0" {
+			return digits
+		}
+		return sign + digits + strings.Repeat("0", exp)
+	}
+	scale := -exp
+	if len(digits) <= scale { // leading zeros
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+	i := len(digits) - scale
+	return sign + digits[:i] + "." + digits[i:]
+}
+
 func convertDecimalToRat(m *big.Int, exp int) *big.Rat {
 	if m == nil {
 		return nil
@@ -2,11 +2,20 @@ package protocol
 
 import (
 	"bytes"
+	"encoding/hex"
 	"errors"
+	"fmt"
 	"math"
+	"math/big"
+	"net"
+	"net/netip"
 	"reflect"
 	"testing"
 	"time"
+
+	"github.com/SAP/go-hdb/driver/internal/protocol/encoding"
+	"github.com/SAP/go-hdb/driver/spatial"
+	"github.com/SAP/go-hdb/driver/unicode/cesu8"
 )
 
 func assertEqualInt(t *testing.T, ftc *FieldTypeCtx, tc typeCode, v any, r int64) { //nolint:unparam
@@ -140,6 +149,32 @@ func testConvertTime(t *testing.T, ftc *FieldTypeCtx) {
 	assertEqualTime(t, ftc, tcTimestamp, &timeValue, timeValue)
 }
 
+func testConvertTimeFraction(t *testing.T, ftc *FieldTypeCtx) {
+	timeValue := time.Date(2024, time.March, 1, 12, 30, 45, 123456789, time.UTC)
+
+	tests := []struct {
+		tc       typeCode
+		fraction int
+		r        time.Time
+	}{
+		{tcLongdate, 3, time.Date(2024, time.March, 1, 12, 30, 45, 123000000, time.UTC)},
+		{tcLongdate, 6, time.Date(2024, time.March, 1, 12, 30, 45, 123456000, time.UTC)},
+		{tcTimestamp, 3, time.Date(2024, time.March, 1, 12, 30, 45, 123000000, time.UTC)},
+		{tcTimestamp, 6, time.Date(2024, time.March, 1, 12, 30, 45, 123456000, time.UTC)},
+		{tcLongdate, 0, timeValue}, // no fraction provided - no truncation
+	}
+
+	for _, test := range tests {
+		cv, err := ftc.fieldType(test.tc, 0, test.fraction).(fieldConverter).convert(timeValue)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !cv.(time.Time).Equal(test.r) {
+			t.Fatalf("%s(%d): assert equal time failed %v - %v expected", test.tc, test.fraction, cv, test.r)
+		}
+	}
+}
+
 func assertEqualString(t *testing.T, ftc *FieldTypeCtx, tc typeCode, v any, r string) {
 	cv, err := ftc.fieldType(tc, 0, 0).(fieldConverter).convert(v)
 	if err != nil {
@@ -193,6 +228,232 @@ func testConvertBytes(t *testing.T, ftc *FieldTypeCtx) {
 	assertEqualBytes(t, ftc, tcBinary, &bytesValue, bytesValue)
 }
 
+func assertEqualRat(t *testing.T, ftc *FieldTypeCtx, tc typeCode, v any, r *big.Rat) {
+	cv, err := ftc.fieldType(tc, 0, 0).(fieldConverter).convert(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cv.(*big.Rat).Cmp(r) != 0 {
+		t.Fatalf("assert equal rat failed %v - %v expected", cv, r)
+	}
+}
+
+func testConvertDecimal(t *testing.T, ftc *FieldTypeCtx) {
+	type testCustomInt big.Int
+
+	intValue, _ := new(big.Int).SetString("123456789012345678901234567890", 10) // exceeds float64 precision
+	ratValue := new(big.Rat).SetInt(intValue)
+
+	// rat and int data types
+	assertEqualRat(t, ftc, tcDecimal, ratValue, ratValue)
+	assertEqualRat(t, ftc, tcDecimal, intValue, ratValue)
+
+	// custom int data type
+	assertEqualRat(t, ftc, tcDecimal, (*testCustomInt)(intValue), ratValue)
+
+	// int value
+	assertEqualRat(t, ftc, tcDecimal, *intValue, ratValue)
+
+	// precision and scale
+	tests := []struct {
+		tc          typeCode
+		prec, scale int
+		v           any
+		overflow    bool
+	}{
+		{tcFixed8, 5, 2, big.NewInt(999), false},
+		{tcFixed8, 5, 2, big.NewInt(1000), true},
+		{tcFixed8, 5, 2, big.NewRat(99999, 100), false},
+		{tcFixed16, 38, 0, intValue, false},
+		{tcFixed16, 38, 10, intValue, true},
+	}
+
+	// decimal text and text marshaler (e.g. third party decimal types)
+	assertEqualRat(t, ftc, tcDecimal, "-1.50", big.NewRat(-3, 2))
+	assertEqualRat(t, ftc, tcDecimal, []byte("1e-3"), big.NewRat(1, 1000))
+	assertEqualRat(t, ftc, tcFixed8, testDecimalText("-12.345"), big.NewRat(-12345, 1000))
+	assertEqualRat(t, ftc, tcDecimal, ".5", big.NewRat(1, 2))
+	assertEqualRat(t, ftc, tcDecimal, "+5.E+1", big.NewRat(50, 1))
+
+	// no decimal text
+	for _, s := range []string{"", "-", ".", "1e", "1e+", "1/3", "0x1p-2", "0b101", "0o17", "1_000", "1.5.0", "Inf", " 1"} {
+		if v, err := ftc.fieldType(tcDecimal, 0, 0).(fieldConverter).convert(s); err == nil {
+			t.Fatalf("decimal text %q: value %v - expected error", s, v)
+		}
+	}
+
+	for _, test := range tests {
+		ft := ftc.fieldType(test.tc, test.prec, test.scale)
+		cv, err := ft.(fieldConverter).convert(test.v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ft.encodePrm(encoding.NewEncoder(&bytes.Buffer{}, cesu8.DefaultEncoder), cv)
+		if overflow := errors.Is(err, ErrDecimalOutOfRange); overflow != test.overflow {
+			t.Fatalf("decimal(%d,%d) value %v: error %v - expected overflow %t", test.prec, test.scale, test.v, err, test.overflow)
+		}
+	}
+}
+
+type testDecimalText string
+
+func (d testDecimalText) MarshalText() ([]byte, error) { return []byte(d), nil }
+
+func TestDecimalText(t *testing.T) {
+	ftc := NewFieldTypeCtx(defaultDfv, false, false)
+	ftc.SetDecimalAsText(true)
+
+	tests := []struct {
+		tc          typeCode
+		prec, scale int
+		v           any
+		text        string
+	}{
+		{tcDecimal, 0, 0, "-1.50", "-1.5"},
+		{tcDecimal, 0, 0, "1e10", "10000000000"},
+		{tcDecimal, 0, 0, "-0.001", "-0.001"},
+		{tcDecimal, 0, 0, "0", "0"},
+		{tcFixed8, 10, 2, "-1.5", "-1.50"},
+		{tcFixed8, 10, 2, "0", "0.00"},
+		{tcFixed8, 10, 0, testDecimalText("42"), "42"},
+		{tcFixed8, 10, 0, "-42", "-42"},
+		{tcFixed12, 28, 5, "-0.00001", "-0.00001"},
+		{tcFixed16, 38, 0, "99999999999999999999999999999999999999", "99999999999999999999999999999999999999"},
+		{tcFixed16, 38, 38, "-0.99999999999999999999999999999999999999", "-0.99999999999999999999999999999999999999"},
+		{tcFixed16, 38, 10, "-1234567890123456789012345678.0123456789", "-1234567890123456789012345678.0123456789"},
+	}
+
+	for _, test := range tests {
+		ft := ftc.fieldType(test.tc, test.prec, test.scale)
+		cv, err := ft.(fieldConverter).convert(test.v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		buf := &bytes.Buffer{}
+		enc := encoding.NewEncoder(buf, cesu8.DefaultEncoder)
+		if test.tc != tcDecimal {
+			enc.Bool(true) // fixed types: not null indicator
+		}
+		if err := ft.encodePrm(enc, cv); err != nil {
+			t.Fatal(err)
+		}
+		v, err := ft.decodeRes(encoding.NewDecoder(buf, cesu8.DefaultDecoder))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v != test.text {
+			t.Fatalf("decimal(%d,%d) value %v: text %v - expected %s", test.prec, test.scale, test.v, v, test.text)
+		}
+	}
+
+	if _, err := ftc.fieldType(tcDecimal, 0, 0).(fieldConverter).convert("1.5x"); err == nil {
+		t.Fatal("conversion error expected")
+	}
+}
+
+func assertNull(t *testing.T, ftc *FieldTypeCtx, tc typeCode, v any) {
+	cv, err := ftc.fieldType(tc, 0, 0).(fieldConverter).convert(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cv != nil {
+		t.Fatalf("assert null failed %v - nil expected", cv)
+	}
+}
+
+// testTextMarshaler is a custom type marshaling itself to text.
+type testTextMarshaler [2]byte
+
+func (m testTextMarshaler) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%02x-%02x", m[0], m[1])), nil
+}
+
+func testConvertTextMarshaler(t *testing.T, ftc *FieldTypeCtx) {
+	v := testTextMarshaler{0x0a, 0xff}
+
+	// text marshaler
+	assertEqualBytes(t, ftc, tcString, v, []byte("0a-ff"))
+	assertEqualBytes(t, ftc, tcNstring, v, []byte("0a-ff"))
+
+	// text marshaler reference
+	assertEqualBytes(t, ftc, tcNstring, &v, []byte("0a-ff"))
+
+	// binary fields: no text conversion
+	if _, err := ftc.fieldType(tcVarbinary, 0, 0).(fieldConverter).convert(v); err == nil {
+		t.Fatal("conversion error expected")
+	}
+}
+
+func testConvertIPAddr(t *testing.T, ftc *FieldTypeCtx) {
+	ip4, ip6 := "192.168.0.1", "2001:db8::1"
+
+	// ip addresses
+	assertEqualString(t, ftc, tcVarchar, netip.MustParseAddr(ip4), ip4)
+	assertEqualString(t, ftc, tcNvarchar, netip.MustParseAddr(ip6), ip6)
+	assertEqualString(t, ftc, tcVarchar, net.ParseIP(ip4), ip4)
+	assertEqualString(t, ftc, tcNvarchar, net.ParseIP(ip6), ip6)
+
+	// zero and invalid ip addresses
+	for _, v := range []any{netip.Addr{}, net.IP(nil), net.IP{1, 2, 3}} {
+		if _, err := ftc.fieldType(tcVarchar, 0, 0).(fieldConverter).convert(v); !errors.Is(err, ErrInvalidIPAddr) {
+			t.Fatalf("ip address %v: error %v - expected %v", v, err, ErrInvalidIPAddr)
+		}
+	}
+
+	// binary fields: raw ip address bytes
+	ip := net.ParseIP(ip4)
+	assertEqualBytes(t, ftc, tcVarbinary, ip, ip)
+	assertEqualBytes(t, ftc, tcBinary, net.IP(nil), nil)
+}
+
+func testConvertDuration(t *testing.T, ftc *FieldTypeCtx) {
+	d := 90*time.Second + 500*time.Millisecond
+
+	// default unit (nanoseconds)
+	assertEqualInt(t, ftc, tcBigint, d, int64(d))
+	assertEqualInt(t, ftc, tcBigint, &d, int64(d))
+	assertEqualFloat(t, ftc, tcDouble, d, float64(d))
+
+	// second unit
+	secFtc := NewFieldTypeCtx(defaultDfv, false, false)
+	secFtc.SetDurationUnit(time.Second)
+	assertEqualInt(t, secFtc, tcInteger, d, 90)
+	assertEqualInt(t, secFtc, tcBigint, &d, 90)
+	assertEqualFloat(t, secFtc, tcDouble, d, 90.5)
+
+	// millisecond unit
+	msFtc := NewFieldTypeCtx(defaultDfv, false, false)
+	msFtc.SetDurationUnit(time.Millisecond)
+	assertEqualInt(t, msFtc, tcInteger, d, 90500)
+	assertEqualFloat(t, msFtc, tcDouble, d, 90500)
+
+	// out of range
+	assertEqualIntOutOfRangeError(t, ftc, tcInteger, time.Duration(maxInteger+1))
+	assertEqualIntOutOfRangeError(t, secFtc, tcSmallint, time.Duration(maxSmallint+1)*time.Second)
+	assertEqualIntOutOfRangeError(t, msFtc, tcInteger, time.Duration(maxInteger+1)*time.Millisecond)
+}
+
+func testConvertGeometry(t *testing.T, ftc *FieldTypeCtx) {
+	g := spatial.Point{X: 2.5, Y: 3.0}
+	wkb, err := spatial.EncodeWKB(g, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bin := make([]byte, hex.DecodedLen(len(wkb)))
+	if _, err := hex.Decode(bin, wkb); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []typeCode{tcStPoint, tcStGeometry} {
+		// geometry
+		assertEqualBytes(t, ftc, tc, g, wkb)
+		// binary wkb
+		assertEqualBytes(t, ftc, tc, bin, wkb)
+		// hex encoded wkb
+		assertEqualBytes(t, ftc, tc, wkb, wkb)
+	}
+}
+
 func TestConverter(t *testing.T) {
 	tests := []struct {
 		name string
@@ -201,11 +462,17 @@ func TestConverter(t *testing.T) {
 		{"convertInteger", testConvertInteger},
 		{"convertFloat", testConvertFloat},
 		{"convertTime", testConvertTime},
+		{"convertTimeFraction", testConvertTimeFraction},
 		{"convertString", testConvertString},
 		{"convertBytes", testConvertBytes},
+		{"convertDecimal", testConvertDecimal},
+		{"convertTextMarshaler", testConvertTextMarshaler},
+		{"convertIPAddr", testConvertIPAddr},
+		{"convertDuration", testConvertDuration},
+		{"convertGeometry", testConvertGeometry},
 	}
 
-	ftc := NewFieldTypeCtx(defaultDfv, false)
+	ftc := NewFieldTypeCtx(defaultDfv, false, false)
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -213,3 +480,73 @@ func TestConverter(t *testing.T) {
 		})
 	}
 }
+
+func TestEmptyStringAsNull(t *testing.T) {
+	tcs := []typeCode{tcVarchar, tcNvarchar, tcAlphanum}
+
+	for _, emptyStringAsNull := range []bool{false, true} {
+		ftc := NewFieldTypeCtx(defaultDfv, false, emptyStringAsNull)
+
+		for _, tc := range tcs {
+			ft := ftc.fieldType(tc, 0, 0)
+
+			// bind
+			cv, err := ft.(fieldConverter).convert("")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (cv == nil) != emptyStringAsNull {
+				t.Fatalf("%s: convert empty string %v - expected null %t", tc, cv, emptyStringAsNull)
+			}
+			if cv, err = ft.(fieldConverter).convert("Hello World"); err != nil || cv.(string) != "Hello World" {
+				t.Fatalf("%s: convert string %v error %v", tc, cv, err)
+			}
+
+			// scan
+			dec := encoding.NewDecoder(bytes.NewReader([]byte{0xff}), cesu8.DefaultDecoder) // null length indicator
+			v, err := ft.decodeRes(dec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if emptyStringAsNull {
+				if b, ok := v.([]byte); !ok || len(b) != 0 {
+					t.Fatalf("%s: decode null value %v - expected empty string", tc, v)
+				}
+			} else if v != nil {
+				t.Fatalf("%s: decode null value %v - expected nil", tc, v)
+			}
+		}
+	}
+}
+
+func TestNullFloatAsNaN(t *testing.T) {
+	testData := []struct {
+		tc   typeCode
+		null []byte
+	}{
+		{tcReal, bytes.Repeat([]byte{0xff}, 4)},
+		{tcDouble, bytes.Repeat([]byte{0xff}, 8)},
+	}
+
+	for _, nullFloatAsNaN := range []bool{false, true} {
+		ftc := NewFieldTypeCtx(defaultDfv, false, false)
+		ftc.SetNullFloatAsNaN(nullFloatAsNaN)
+
+		for _, d := range testData {
+			ft := ftc.fieldType(d.tc, 0, 0)
+
+			dec := encoding.NewDecoder(bytes.NewReader(d.null), cesu8.DefaultDecoder)
+			v, err := ft.decodeRes(dec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if nullFloatAsNaN {
+				if f, ok := v.(float64); !ok || !math.IsNaN(f) {
+					t.Fatalf("%s: decode null value %v - expected NaN", d.tc, v)
+				}
+			} else if v != nil {
+				t.Fatalf("%s: decode null value %v - expected nil", d.tc, v)
+			}
+		}
+	}
+}
@@ -2,10 +2,17 @@ package protocol
 
 import (
 	"database/sql"
+	stdencoding "encoding"
+	"io"
+	"math/big"
+	"net"
+	"net/netip"
 	"reflect"
+	"slices"
 	"time"
 
 	hdbreflect "github.com/SAP/go-hdb/driver/internal/reflect"
+	"github.com/SAP/go-hdb/driver/spatial"
 )
 
 // DataType is the type definition for data types supported by this package.
@@ -63,3 +70,42 @@ func (dt DataType) ScanType(nullable bool) reflect.Type {
 	}
 	return scanTypes[dt].scanType
 }
+
+// RegisterBindType registers driver owned datatype bind types (e.g. Decimal, Lob).
+func RegisterBindType(dt DataType, bindType ...reflect.Type) bool {
+	bindTypes[dt] = append(bindTypes[dt], bindType...)
+	return true
+}
+
+var (
+	integerBindTypes = []reflect.Type{hdbreflect.TypeFor[int64](), hdbreflect.TypeFor[uint64](), hdbreflect.TypeFor[float64](), hdbreflect.TypeFor[float32](), hdbreflect.TypeFor[bool](), hdbreflect.TypeFor[string](), hdbreflect.TypeFor[[]byte](), hdbreflect.TypeFor[time.Duration]()}
+	floatBindTypes   = []reflect.Type{hdbreflect.TypeFor[float64](), hdbreflect.TypeFor[float32](), hdbreflect.TypeFor[string](), hdbreflect.TypeFor[[]byte](), hdbreflect.TypeFor[time.Duration]()}
+)
+
+/*
+bindTypes are the types accepted by the parameter conversion of the corresponding data type.
+Numeric and boolean data types accept string and []byte values via parsing their text.
+The list is checked against the parameter conversion by TestBindTypes.
+*/
+var bindTypes = [][]reflect.Type{
+	DtUnknown:  nil,
+	DtBoolean:  {hdbreflect.TypeFor[bool](), hdbreflect.TypeFor[int64](), hdbreflect.TypeFor[uint64](), hdbreflect.TypeFor[float64](), hdbreflect.TypeFor[float32](), hdbreflect.TypeFor[string](), hdbreflect.TypeFor[[]byte](), hdbreflect.TypeFor[time.Duration]()},
+	DtTinyint:  integerBindTypes,
+	DtSmallint: integerBindTypes,
+	DtInteger:  integerBindTypes,
+	DtBigint:   integerBindTypes,
+	DtReal:     floatBindTypes,
+	DtDouble:   floatBindTypes,
+	DtTime:     {hdbreflect.TypeFor[time.Time]()},
+	DtString:   {hdbreflect.TypeFor[string](), hdbreflect.TypeFor[[]byte](), hdbreflect.TypeFor[stdencoding.TextMarshaler](), hdbreflect.TypeFor[netip.Addr](), hdbreflect.TypeFor[net.IP](), hdbreflect.TypeFor[spatial.Geometry]()},
+	DtBytes:    {hdbreflect.TypeFor[string](), hdbreflect.TypeFor[[]byte](), hdbreflect.TypeFor[net.IP]()},
+	DtDecimal:  {hdbreflect.TypeFor[*big.Rat](), hdbreflect.TypeFor[*big.Int](), hdbreflect.TypeFor[string](), hdbreflect.TypeFor[[]byte](), hdbreflect.TypeFor[stdencoding.TextMarshaler]()},
+	DtLob:      {hdbreflect.TypeFor[io.Reader](), hdbreflect.TypeFor[ReadProvider](), hdbreflect.TypeFor[string](), hdbreflect.TypeFor[[]byte](), hdbreflect.TypeFor[stdencoding.TextMarshaler](), hdbreflect.TypeFor[net.IP]()},
+	DtRows:     nil,
+}
+
+// BindTypes returns the types (reflect.Type) accepted as parameter values of the corresponding data type.
+// Pointers to and sql.Null variants of these types are accepted as well.
+func (dt DataType) BindTypes() []reflect.Type {
+	return slices.Clip(bindTypes[dt])
+}
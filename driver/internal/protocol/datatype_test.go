@@ -0,0 +1,102 @@
+package protocol
+
+import (
+	"bytes"
+	stdencoding "encoding"
+	"io"
+	"math/big"
+	"net"
+	"net/netip"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	hdbreflect "github.com/SAP/go-hdb/driver/internal/reflect"
+	"github.com/SAP/go-hdb/driver/spatial"
+	"github.com/SAP/go-hdb/driver/unicode/cesu8"
+)
+
+type testReadProvider struct{}
+
+func (testReadProvider) Reader() io.Reader { return bytes.NewReader([]byte("1")) }
+
+// testNumberText is a custom type marshaling itself to a number text (e.g. a third party decimal type).
+type testNumberText struct{}
+
+func (testNumberText) MarshalText() ([]byte, error) { return []byte("1"), nil }
+
+// TestBindTypes checks the bind types of all data types against the parameter conversion:
+// every listed type needs to be accepted and every accepted sample type needs to be listed.
+func TestBindTypes(t *testing.T) {
+	// bindSamples are the sample values of the types which might be accepted by the parameter conversion.
+	bindSamples := map[reflect.Type]any{
+		hdbreflect.TypeFor[bool]():                      true,
+		hdbreflect.TypeFor[int64]():                     int64(1),
+		hdbreflect.TypeFor[uint64]():                    uint64(1),
+		hdbreflect.TypeFor[float64]():                   float64(1),
+		hdbreflect.TypeFor[float32]():                   float32(1),
+		hdbreflect.TypeFor[string]():                    "1",
+		hdbreflect.TypeFor[[]byte]():                    []byte("1"),
+		hdbreflect.TypeFor[time.Time]():                 time.Now(),
+		hdbreflect.TypeFor[time.Duration]():             time.Duration(1),
+		hdbreflect.TypeFor[*big.Rat]():                  big.NewRat(1, 1),
+		hdbreflect.TypeFor[*big.Int]():                  big.NewInt(1),
+		hdbreflect.TypeFor[netip.Addr]():                netip.MustParseAddr("192.168.0.1"),
+		hdbreflect.TypeFor[net.IP]():                    net.ParseIP("192.168.0.1"),
+		hdbreflect.TypeFor[stdencoding.TextMarshaler](): testNumberText{},
+		hdbreflect.TypeFor[io.Reader]():                 bytes.NewReader([]byte("1")),
+		hdbreflect.TypeFor[ReadProvider]():              testReadProvider{},
+		hdbreflect.TypeFor[spatial.Geometry]():          spatial.Point{X: 1, Y: 1},
+	}
+
+	ftc := NewFieldTypeCtx(defaultDfv, false, false)
+
+	accepted := func(tc typeCode, v any) bool {
+		var err error
+		switch ft := ftc.fieldType(tc, 0, 0).(type) {
+		case fieldConverter:
+			_, err = ft.convert(v)
+		case cesu8FieldConverter:
+			_, err = ft.convertCESU8(cesu8.DefaultEncoder(), v)
+		}
+		return err == nil
+	}
+
+	// listed returns true if typ or an interface implemented by typ is a bind type.
+	listed := func(bindTypes []reflect.Type, typ reflect.Type) bool {
+		return slices.ContainsFunc(bindTypes, func(bindType reflect.Type) bool {
+			return bindType == typ || (bindType.Kind() == reflect.Interface && typ.Implements(bindType))
+		})
+	}
+
+	// type codes per data type
+	dtTypeCodes := map[DataType][]typeCode{}
+	for _, tc := range supportedTypeCodes {
+		dtTypeCodes[tc.dataType()] = append(dtTypeCodes[tc.dataType()], tc)
+	}
+
+	for dt, tcs := range dtTypeCodes {
+		bindTypes := dt.BindTypes()
+
+		// all listed bind types need to be accepted by at least one type code
+		for _, bindType := range bindTypes {
+			v, ok := bindSamples[bindType]
+			if !ok {
+				t.Fatalf("data type %d: missing sample value of bind type %s", dt, bindType)
+			}
+			if !slices.ContainsFunc(tcs, func(tc typeCode) bool { return accepted(tc, v) }) {
+				t.Fatalf("data type %d: bind type %s is not accepted by any of %v", dt, bindType, tcs)
+			}
+		}
+
+		// all accepted sample types need to be listed
+		for typ, v := range bindSamples {
+			for _, tc := range tcs {
+				if accepted(tc, v) && !listed(bindTypes, typ) {
+					t.Fatalf("data type %d: type %s is accepted by %s but not listed in bind types", dt, typ, tc)
+				}
+			}
+		}
+	}
+}
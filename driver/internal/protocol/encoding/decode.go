@@ -6,6 +6,7 @@ import (
 	"io"
 	"math"
 	"math/big"
+	"slices"
 
 	"github.com/SAP/go-hdb/driver/internal/unsafe"
 	"golang.org/x/text/transform"
@@ -24,6 +25,9 @@ type Decoder struct {
 	b   []byte // scratch buffer (used for skip, CESU8Bytes - define size not too small!)
 	tr  transform.Transformer
 	cnt int
+
+	capturing bool
+	capture   []byte // captured bytes (see StartCapture)
 }
 
 // NewDecoder creates a new Decoder instance based on an io.Reader.
@@ -44,6 +48,27 @@ func (d *Decoder) Error() error { return d.err }
 // ResetError resets reader error.
 func (d *Decoder) ResetError() { d.err = nil }
 
+// SetError sets err as fatal decoder error in case no error is set yet.
+// Used in case data cannot be decoded or skipped (e.g. data of unknown size).
+func (d *Decoder) SetError(err error) {
+	if d.err == nil {
+		d.err = err
+	}
+}
+
+// StartCapture starts capturing the bytes read by the decoder (e.g. for diagnostic purposes).
+func (d *Decoder) StartCapture() {
+	d.capturing = true
+	d.capture = d.capture[:0]
+}
+
+// StopCapture stops capturing and returns the bytes read since StartCapture.
+// The returned slice is owned by the caller.
+func (d *Decoder) StopCapture() []byte {
+	d.capturing = false
+	return slices.Clone(d.capture)
+}
+
 // readFull reads data from reader + read counter and error handling.
 func (d *Decoder) readFull(buf []byte) (int, error) {
 	if d.err != nil {
@@ -52,6 +77,9 @@ func (d *Decoder) readFull(buf []byte) (int, error) {
 	var n int
 	n, d.err = io.ReadFull(d.rd, buf)
 	d.cnt += n
+	if d.capturing {
+		d.capture = append(d.capture, buf[:n]...)
+	}
 	if d.err != nil {
 		return n, d.err
 	}
@@ -1,6 +1,7 @@
 package protocol
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/SAP/go-hdb/driver/internal/protocol/encoding"
@@ -37,8 +38,16 @@ const (
 
 // HANA Database errors.
 const (
-	HdbErrAuthenticationFailed = 10
-	HdbErrWhileParsingProtocol = 1033
+	HdbErrAuthenticationFailed    = 10
+	HdbErrTxRolledBackLockTimeout = 131
+	HdbErrTxRolledBackDeadlock    = 133
+	HdbErrWhileParsingProtocol    = 1033
+)
+
+// Lock conflict errors matching database errors via errors.Is (see HdbError Is).
+var (
+	ErrLockWaitTimeout = errors.New("lock wait timeout")
+	ErrDeadlock        = errors.New("deadlock")
 )
 
 type sqlState [sqlStateSize]byte
@@ -97,6 +106,32 @@ func (e *HdbError) IsError() bool { return e.errorLevel == errorLevelError }
 // IsFatal implements the driver.DBError interface.
 func (e *HdbError) IsFatal() bool { return e.errorLevel == errorLevelFatalError }
 
+// Is implements the errors.Is interface: a database error (warnings excluded) matches the lock conflict error
+// corresponding to its error code.
+func (e *HdbError) Is(target error) bool {
+	if e == nil || e.errorLevel == errorLevelWarning {
+		return false
+	}
+	switch target {
+	case ErrLockWaitTimeout:
+		return e.errorCode == HdbErrTxRolledBackLockTimeout
+	case ErrDeadlock:
+		return e.errorCode == HdbErrTxRolledBackDeadlock
+	default:
+		return false
+	}
+}
+
+// ErrorPolicy defines the errors returned in case a reply contains more than one error.
+type ErrorPolicy int8
+
+// ErrorPolicy constants.
+const (
+	ErrorPolicyAll        ErrorPolicy = iota // all errors including warnings are returned (default)
+	ErrorPolicyFirst                         // the first error (warnings excluded) is returned
+	ErrorPolicyMostSevere                    // the error with the highest error level is returned (first one in case of equal levels)
+)
+
 // HdbErrors represent the collection of errors return by the server.
 type HdbErrors struct {
 	onlyWarnings bool
@@ -138,6 +173,44 @@ func (e *HdbErrors) Unwrap() []error {
 	return errs
 }
 
+// All returns all errors including warnings.
+func (e *HdbErrors) All() []*HdbError { return e.errs }
+
+// warnings returns the warnings of the error collection.
+func (e *HdbErrors) warnings() []*HdbError {
+	var warnings []*HdbError
+	for _, err := range e.errs {
+		if err.IsWarning() {
+			warnings = append(warnings, err)
+		}
+	}
+	return warnings
+}
+
+// selectErrors returns the errors according to the error policy.
+func (e *HdbErrors) selectErrors(policy ErrorPolicy) *HdbErrors {
+	var selected *HdbError
+	switch policy {
+	case ErrorPolicyFirst:
+		for _, err := range e.errs {
+			if !err.IsWarning() {
+				selected = err
+				break
+			}
+		}
+	case ErrorPolicyMostSevere:
+		for _, err := range e.errs {
+			if selected == nil || err.errorLevel > selected.errorLevel {
+				selected = err
+			}
+		}
+	}
+	if selected == nil {
+		return e
+	}
+	return &HdbErrors{errs: []*HdbError{selected}, HdbError: selected}
+}
+
 // SetIdx implements the driver.Error interface.
 func (e *HdbErrors) SetIdx(idx int) {
 	if idx >= 0 && idx < len(e.errs) {
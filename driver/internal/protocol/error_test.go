@@ -0,0 +1,148 @@
+package protocol
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/SAP/go-hdb/driver/internal/protocol/encoding"
+	"github.com/SAP/go-hdb/driver/unicode/cesu8"
+)
+
+// errorPart returns an error part containing the given errors.
+func errorPart(errs ...*HdbError) rawPart {
+	buf := &bytes.Buffer{}
+	enc := encoding.NewEncoder(buf, cesu8.DefaultEncoder)
+	for _, err := range errs {
+		enc.Int32(err.errorCode)
+		enc.Int32(err.errorPosition)
+		enc.Int32(int32(len(err.errorText)))
+		enc.Int8(int8(err.errorLevel))
+		enc.Bytes(err.sqlState[:])
+		enc.Bytes(err.errorText)
+		enc.Zeroes(padBytes(fixLength + len(err.errorText)))
+	}
+	return rawPart{pk: PkError, n: len(errs), b: buf.Bytes()}
+}
+
+func TestErrorIs(t *testing.T) {
+	testData := []struct {
+		err                       *HdbError
+		lockWaitTimeout, deadlock bool
+	}{
+		{&HdbError{errorCode: HdbErrTxRolledBackLockTimeout, errorLevel: errorLevelError}, true, false},
+		{&HdbError{errorCode: HdbErrTxRolledBackDeadlock, errorLevel: errorLevelError}, false, true},
+		{&HdbError{errorCode: HdbErrTxRolledBackDeadlock, errorLevel: errorLevelWarning}, false, false},
+		{&HdbError{errorCode: 259, errorLevel: errorLevelError}, false, false},
+	}
+
+	for _, r := range testData {
+		// error collection with a leading unrelated error.
+		hdbErrors := &HdbErrors{errs: []*HdbError{{errorCode: 259, errorLevel: errorLevelError}, r.err}}
+		hdbErrors.HdbError = hdbErrors.errs[0]
+		err := fmt.Errorf("wrapped: %w", hdbErrors)
+
+		if is := errors.Is(err, ErrLockWaitTimeout); is != r.lockWaitTimeout {
+			t.Fatalf("code %d: lock wait timeout %t - expected %t", r.err.errorCode, is, r.lockWaitTimeout)
+		}
+		if is := errors.Is(err, ErrDeadlock); is != r.deadlock {
+			t.Fatalf("code %d: deadlock %t - expected %t", r.err.errorCode, is, r.deadlock)
+		}
+	}
+}
+
+func TestErrorPolicy(t *testing.T) {
+	ctx := context.Background()
+
+	part := errorPart(
+		&HdbError{errorCode: 1, errorLevel: errorLevelWarning, errorText: []byte("warning")},
+		&HdbError{errorCode: 2, errorLevel: errorLevelError, errorText: []byte("error")},
+		&HdbError{errorCode: 3, errorLevel: errorLevelFatalError, errorText: []byte("fatal error")},
+		&HdbError{errorCode: 4, errorLevel: errorLevelError, errorText: []byte("another error")},
+	)
+
+	testData := []struct {
+		policy ErrorPolicy
+		codes  []int
+	}{
+		{ErrorPolicyAll, []int{1, 2, 3, 4}},
+		{ErrorPolicyFirst, []int{2}},
+		{ErrorPolicyMostSevere, []int{3}},
+	}
+
+	for _, test := range testData {
+		buf := &bytes.Buffer{}
+		w := NewWriter(bufio.NewWriter(buf), false, false, nil, nil, cesu8.DefaultEncoder, nil)
+		if err := w.Write(ctx, 1, MtExecuteDirect, false, part); err != nil {
+			t.Fatal(err)
+		}
+
+		r := NewClientReader(buf, false, false, nil, nil, cesu8.DefaultDecoder)
+		r.ErrorPolicy = test.policy
+		err := r.IterateParts(ctx, nil)
+
+		var hdbErrors *HdbErrors
+		if !errors.As(err, &hdbErrors) {
+			t.Fatalf("policy %d: error %v - expected %T", test.policy, err, hdbErrors)
+		}
+		all := hdbErrors.All()
+		if len(all) != len(test.codes) || hdbErrors.NumError() != len(test.codes) {
+			t.Fatalf("policy %d: number of errors %d - expected %d", test.policy, len(all), len(test.codes))
+		}
+		for i, err := range all {
+			if err.Code() != test.codes[i] {
+				t.Fatalf("policy %d: error %d code %d - expected %d", test.policy, i, err.Code(), test.codes[i])
+			}
+		}
+		if hdbErrors.Code() != test.codes[0] {
+			t.Fatalf("policy %d: default error code %d - expected %d", test.policy, hdbErrors.Code(), test.codes[0])
+		}
+	}
+}
+
+func TestOnWarnings(t *testing.T) {
+	ctx := context.Background()
+
+	warning := &HdbError{errorCode: 1, errorLevel: errorLevelWarning, errorText: []byte("warning")}
+	rowsAffected := rawPart{pk: PkRowsAffected, b: []byte{1, 0, 0, 0}}
+
+	testData := []struct {
+		name     string
+		parts    []writablePart
+		warnings int
+		fails    bool
+	}{
+		{"warnings only", []writablePart{errorPart(warning, warning)}, 2, false},
+		{"rows affected", []writablePart{rowsAffected, errorPart(warning)}, 1, false},
+		{"errors", []writablePart{errorPart(warning, &HdbError{errorCode: 2, errorLevel: errorLevelError, errorText: []byte("error")})}, 1, true},
+		{"no warnings", []writablePart{rowsAffected}, 0, false},
+	}
+
+	for _, test := range testData {
+		buf := &bytes.Buffer{}
+		w := NewWriter(bufio.NewWriter(buf), false, false, nil, nil, cesu8.DefaultEncoder, nil)
+		if err := w.Write(ctx, 1, MtExecuteDirect, false, test.parts...); err != nil {
+			t.Fatal(err)
+		}
+
+		var warnings []*HdbError
+		r := NewClientReader(buf, false, false, slog.New(slog.NewTextHandler(io.Discard, nil)), nil, cesu8.DefaultDecoder)
+		r.OnWarnings = func(errs []*HdbError) { warnings = append(warnings, errs...) }
+		if err := r.IterateParts(ctx, nil); (err != nil) != test.fails {
+			t.Fatalf("%s: unexpected error %v", test.name, err)
+		}
+		if len(warnings) != test.warnings {
+			t.Fatalf("%s: number of warnings %d - expected %d", test.name, len(warnings), test.warnings)
+		}
+		for _, warning := range warnings {
+			if !warning.IsWarning() {
+				t.Fatalf("%s: %v is not a warning", test.name, warning)
+			}
+		}
+	}
+}
@@ -3,6 +3,7 @@ package protocol
 import (
 	"bytes"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"math"
@@ -54,6 +55,7 @@ var (
 	bytesReflectType  = hdbreflect.TypeFor[[]byte]()
 	stringReflectType = hdbreflect.TypeFor[string]()
 	ratReflectType    = hdbreflect.TypeFor[big.Rat]()
+	intReflectType    = hdbreflect.TypeFor[big.Int]()
 )
 
 const lobInputParametersSize = 9
@@ -66,15 +68,40 @@ type cesu8FieldConverter interface {
 	convertCESU8(t transform.Transformer, v any) (any, error)
 }
 
+// DefaultDurationUnit is the default unit time.Duration values are converted to for numeric fields.
+// Nanoseconds keep the conversion of time.Duration values as plain int64 values.
+const DefaultDurationUnit = time.Nanosecond
+
 // FieldTypeCtx represents a field type context for creating field types.
 type FieldTypeCtx struct {
-	dfv             int
-	emptyDateAsNull bool
+	dfv               int
+	emptyDateAsNull   bool
+	emptyStringAsNull bool
+	durationUnit      time.Duration
+	nullFloatAsNaN    bool
+	location          *time.Location
+	decimalAsText     bool
 }
 
 // NewFieldTypeCtx returns a new field type context instance.
-func NewFieldTypeCtx(dfv int, emptyDateAsNull bool) *FieldTypeCtx {
-	return &FieldTypeCtx{dfv: dfv, emptyDateAsNull: emptyDateAsNull}
+func NewFieldTypeCtx(dfv int, emptyDateAsNull, emptyStringAsNull bool) *FieldTypeCtx {
+	return &FieldTypeCtx{dfv: dfv, emptyDateAsNull: emptyDateAsNull, emptyStringAsNull: emptyStringAsNull, durationUnit: DefaultDurationUnit}
+}
+
+// SetNullFloatAsNaN sets if NULL floating point values are decoded as NaN instead of nil.
+func (ctx *FieldTypeCtx) SetNullFloatAsNaN(nullFloatAsNaN bool) { ctx.nullFloatAsNaN = nullFloatAsNaN }
+
+// SetDecimalAsText sets if decimal values are decoded as exact decimal text (e.g. "-1.50") instead of *big.Rat.
+func (ctx *FieldTypeCtx) SetDecimalAsText(decimalAsText bool) { ctx.decimalAsText = decimalAsText }
+
+// SetLocation sets the location timestamp values without time zone are interpreted in (nil: UTC).
+func (ctx *FieldTypeCtx) SetLocation(loc *time.Location) { ctx.location = loc }
+
+// SetDurationUnit sets the unit time.Duration values are converted to for numeric fields (e.g. time.Millisecond).
+func (ctx *FieldTypeCtx) SetDurationUnit(unit time.Duration) {
+	if unit > 0 {
+		ctx.durationUnit = unit
+	}
 }
 
 func (ctx *FieldTypeCtx) fieldType(tc typeCode, length, fraction int) fieldType {
@@ -83,26 +110,41 @@ func (ctx *FieldTypeCtx) fieldType(tc typeCode, length, fraction int) fieldType
 	case tcBoolean:
 		return booleanType
 	case tcTinyint:
-		return tinyintType
+		return _tinyintType{durationUnit: ctx.durationUnit}
 	case tcSmallint:
-		return smallintType
+		return _smallintType{durationUnit: ctx.durationUnit}
 	case tcInteger:
-		return integerType
+		return _integerType{durationUnit: ctx.durationUnit}
 	case tcBigint:
-		return bigintType
+		return _bigintType{durationUnit: ctx.durationUnit}
 	case tcReal:
-		return realType
+		return _realType{durationUnit: ctx.durationUnit, nullAsNaN: ctx.nullFloatAsNaN}
 	case tcDouble:
-		return doubleType
+		return _doubleType{durationUnit: ctx.durationUnit, nullAsNaN: ctx.nullFloatAsNaN}
 	case tcDate:
 		return dateType
 	case tcTime:
 		return timeType
 	case tcTimestamp:
+		if fraction > 0 && fraction < maxTimeFraction {
+			return _timestampType{fraction: fraction, loc: ctx.location} // timestamp(n): truncate to declared precision
+		}
+		if ctx.location != nil {
+			return _timestampType{loc: ctx.location}
+		}
 		return timestampType
 	case tcLongdate:
+		if fraction > 0 && fraction < maxTimeFraction {
+			return _longdateType{fraction: fraction, loc: ctx.location} // timestamp(n): truncate to declared precision
+		}
+		if ctx.location != nil {
+			return _longdateType{loc: ctx.location}
+		}
 		return longdateType
 	case tcSeconddate:
+		if ctx.location != nil {
+			return _seconddateType{loc: ctx.location}
+		}
 		return seconddateType
 	case tcDaydate:
 		if ctx.emptyDateAsNull {
@@ -112,18 +154,30 @@ func (ctx *FieldTypeCtx) fieldType(tc typeCode, length, fraction int) fieldType
 	case tcSecondtime:
 		return secondtimeType
 	case tcDecimal:
+		if ctx.decimalAsText {
+			return _decimalType{asText: true}
+		}
 		return decimalType
 	case tcChar, tcVarchar, tcString:
+		if ctx.emptyStringAsNull {
+			return varTypeEmptyStringAsNull
+		}
 		return varType
 	case tcAlphanum:
+		if ctx.emptyStringAsNull {
+			return _alphaType{isDfv1: ctx.dfv == DfvLevel1, emptyStringAsNull: true}
+		}
 		if ctx.dfv == DfvLevel1 {
 			return alphaTypeDFV1
 		}
 		return alphaType
 	case tcNchar, tcNvarchar, tcNstring, tcShorttext:
+		if ctx.emptyStringAsNull {
+			return cesu8TypeEmptyStringAsNull
+		}
 		return cesu8Type
 	case tcBinary, tcVarbinary:
-		return varType
+		return binaryType
 	case tcStPoint, tcStGeometry:
 		return hexType
 	case tcBlob, tcClob, tcLocator:
@@ -133,16 +187,44 @@ func (ctx *FieldTypeCtx) fieldType(tc typeCode, length, fraction int) fieldType
 	case tcBintext: // ?? lobCESU8Type
 		return lobVarType
 	case tcFixed8:
-		return _fixed8Type{prec: length, scale: fraction} // used for decimals(x,y) 2^63 - 1 (int64)
+		return _fixed8Type{prec: length, scale: fraction, asText: ctx.decimalAsText} // used for decimals(x,y) 2^63 - 1 (int64)
 	case tcFixed12:
-		return _fixed12Type{prec: length, scale: fraction} // used for decimals(x,y) 2^96 - 1 (int96)
+		return _fixed12Type{prec: length, scale: fraction, asText: ctx.decimalAsText} // used for decimals(x,y) 2^96 - 1 (int96)
 	case tcFixed16:
-		return _fixed16Type{prec: length, scale: fraction} // used for decimals(x,y) 2^63 - 1 (int128)
-	default:
-		panic(fmt.Sprintf("missing fieldType for typeCode %s", tc))
+		return _fixed16Type{prec: length, scale: fraction, asText: ctx.decimalAsText} // used for decimals(x,y) 2^63 - 1 (int128)
+	default: // e.g. nested table types
+		return _unsupportedType{tc: tc}
 	}
 }
 
+// ErrUnsupportedType is the error raised if a value of a type code not supported by the driver
+// (e.g. nested table types) is sent or received.
+var ErrUnsupportedType = errors.New("unsupported type")
+
+// _unsupportedType is the field type of type codes not supported by the driver: the field metadata is
+// available, but values can neither be converted, encoded nor decoded.
+type _unsupportedType struct{ tc typeCode }
+
+var _ fieldType = (*_unsupportedType)(nil)
+
+func (ft _unsupportedType) String() string { return fmt.Sprintf("unsupportedType(%s)", ft.tc) }
+
+func (ft _unsupportedType) err() error {
+	return fmt.Errorf("%w: type code %s", ErrUnsupportedType, ft.tc)
+}
+
+func (ft _unsupportedType) convert(v any) (any, error)                 { return nil, ft.err() }
+func (_unsupportedType) prmSize(v any) int                             { return 0 }
+func (ft _unsupportedType) encodePrm(e *encoding.Encoder, v any) error { return ft.err() }
+
+// decodeRes sets a fatal decoder error as the size of the value is unknown and the value cannot be skipped.
+func (ft _unsupportedType) decodeRes(d *encoding.Decoder) (any, error) {
+	err := ft.err()
+	d.SetError(err)
+	return nil, err
+}
+func (ft _unsupportedType) decodePrm(d *encoding.Decoder) (any, error) { return ft.decodeRes(d) }
+
 type fieldType interface {
 	/*
 		statements:
@@ -158,12 +240,6 @@ type fieldType interface {
 
 var (
 	booleanType                = _booleanType{}
-	tinyintType                = _tinyintType{}
-	smallintType               = _smallintType{}
-	integerType                = _integerType{}
-	bigintType                 = _bigintType{}
-	realType                   = _realType{}
-	doubleType                 = _doubleType{}
 	dateType                   = _dateType{}
 	timeType                   = _timeType{}
 	timestampType              = _timestampType{}
@@ -174,39 +250,63 @@ var (
 	secondtimeType             = _secondtimeType{}
 	decimalType                = _decimalType{}
 	varType                    = _varType{}
+	varTypeEmptyStringAsNull   = _varType{emptyStringAsNull: true}
+	binaryType                 = _varType{isBinary: true}
 	alphaTypeDFV1              = _alphaType{isDfv1: true}
 	alphaType                  = _alphaType{isDfv1: false}
 	hexType                    = _hexType{}
 	cesu8Type                  = _cesu8Type{}
+	cesu8TypeEmptyStringAsNull = _cesu8Type{emptyStringAsNull: true}
 	lobVarType                 = _lobVarType{}
 	lobCESU8Type               = _lobCESU8Type{}
 )
 
 type (
-	_booleanType    struct{}
-	_tinyintType    struct{}
-	_smallintType   struct{}
-	_integerType    struct{}
-	_bigintType     struct{}
-	_realType       struct{}
-	_doubleType     struct{}
-	_dateType       struct{}
-	_timeType       struct{}
-	_timestampType  struct{}
-	_longdateType   struct{}
-	_seconddateType struct{}
+	_booleanType  struct{}
+	_tinyintType  struct{ durationUnit time.Duration }
+	_smallintType struct{ durationUnit time.Duration }
+	_integerType  struct{ durationUnit time.Duration }
+	_bigintType   struct{ durationUnit time.Duration }
+	_realType     struct {
+		durationUnit time.Duration
+		nullAsNaN    bool
+	}
+	_doubleType struct {
+		durationUnit time.Duration
+		nullAsNaN    bool
+	}
+	_dateType      struct{}
+	_timeType      struct{}
+	_timestampType struct {
+		fraction int
+		loc      *time.Location
+	}
+	_longdateType struct {
+		fraction int
+		loc      *time.Location
+	}
+	_seconddateType struct{ loc *time.Location }
 	_daydateType    struct{ emptyDateAsNull bool }
 	_secondtimeType struct{}
-	_decimalType    struct{}
-	_fixed8Type     struct{ prec, scale int }
-	_fixed12Type    struct{ prec, scale int }
-	_fixed16Type    struct{ prec, scale int }
-	_varType        struct{}
-	_alphaType      struct{ isDfv1 bool }
-	_hexType        struct{}
-	_cesu8Type      struct{}
-	_lobVarType     struct{}
-	_lobCESU8Type   struct{}
+	_decimalType    struct{ asText bool }
+	_fixed8Type     struct {
+		prec, scale int
+		asText      bool
+	}
+	_fixed12Type struct {
+		prec, scale int
+		asText      bool
+	}
+	_fixed16Type struct {
+		prec, scale int
+		asText      bool
+	}
+	_varType      struct{ emptyStringAsNull, isBinary bool }
+	_alphaType    struct{ isDfv1, emptyStringAsNull bool }
+	_hexType      struct{}
+	_cesu8Type    struct{ emptyStringAsNull bool }
+	_lobVarType   struct{}
+	_lobCESU8Type struct{}
 )
 
 var (
@@ -267,23 +367,23 @@ func (ft _booleanType) convert(v any) (any, error) {
 	return convertBool(ft, v)
 }
 func (ft _tinyintType) convert(v any) (any, error) {
-	return convertInteger(ft, v, minTinyint, maxTinyint)
+	return convertInteger(ft, v, minTinyint, maxTinyint, ft.durationUnit)
 }
 func (ft _smallintType) convert(v any) (any, error) {
-	return convertInteger(ft, v, minSmallint, maxSmallint)
+	return convertInteger(ft, v, minSmallint, maxSmallint, ft.durationUnit)
 }
 func (ft _integerType) convert(v any) (any, error) {
-	return convertInteger(ft, v, minInteger, maxInteger)
+	return convertInteger(ft, v, minInteger, maxInteger, ft.durationUnit)
 }
 func (ft _bigintType) convert(v any) (any, error) {
-	return convertInteger(ft, v, minBigint, maxBigint)
+	return convertInteger(ft, v, minBigint, maxBigint, ft.durationUnit)
 }
 
 func (ft _realType) convert(v any) (any, error) {
-	return convertFloat(ft, v, maxReal)
+	return convertFloat(ft, v, maxReal, ft.durationUnit)
 }
 func (ft _doubleType) convert(v any) (any, error) {
-	return convertFloat(ft, v, maxDouble)
+	return convertFloat(ft, v, maxDouble, ft.durationUnit)
 }
 
 func (ft _dateType) convert(v any) (any, error) {
@@ -293,10 +393,10 @@ func (ft _timeType) convert(v any) (any, error) {
 	return convertTime(ft, v)
 }
 func (ft _timestampType) convert(v any) (any, error) {
-	return convertTime(ft, v)
+	return convertTimeFraction(ft, v, ft.fraction)
 }
 func (ft _longdateType) convert(v any) (any, error) {
-	return convertTime(ft, v)
+	return convertTimeFraction(ft, v, ft.fraction)
 }
 func (ft _seconddateType) convert(v any) (any, error) {
 	return convertTime(ft, v)
@@ -322,16 +422,28 @@ func (ft _fixed16Type) convert(v any) (any, error) {
 }
 
 func (ft _varType) convert(v any) (any, error) {
-	return convertBytes(ft, v)
+	switch {
+	case ft.isBinary:
+		return convertBytes(ft, v)
+	case ft.emptyStringAsNull:
+		return convertTextEmptyAsNull(ft, v)
+	}
+	return convertText(ft, v)
 }
 func (ft _alphaType) convert(v any) (any, error) {
-	return convertBytes(ft, v)
+	if ft.emptyStringAsNull {
+		return convertTextEmptyAsNull(ft, v)
+	}
+	return convertText(ft, v)
 }
 func (ft _hexType) convert(v any) (any, error) {
-	return convertBytes(ft, v)
+	return convertGeometry(ft, v)
 }
 func (ft _cesu8Type) convert(v any) (any, error) {
-	return convertBytes(ft, v)
+	if ft.emptyStringAsNull {
+		return convertTextEmptyAsNull(ft, v)
+	}
+	return convertText(ft, v)
 }
 
 func (ft _lobVarType) convert(v any) (any, error) {
@@ -367,7 +479,11 @@ func convertLob(t transform.Transformer, ft fieldType, v any) (any, error) {
 		return convertToLobInDescr(t, v.Reader()), nil
 	default:
 		// check if string or []byte
-		if v, err := convertBytes(ft, v); err == nil {
+		convert := convertBytes
+		if t != nil { // character lob
+			convert = convertText
+		}
+		if v, err := convert(ft, v); err == nil {
 			switch v := v.(type) {
 			case string:
 				return convertToLobInDescr(t, strings.NewReader(v)), nil
@@ -524,7 +640,7 @@ func (ft _timeType) encodePrm(e *encoding.Encoder, v any) error {
 	return nil
 }
 func (ft _timestampType) encodePrm(e *encoding.Encoder, v any) error {
-	t := asTime(v)
+	t := asTimeIn(v, ft.loc)
 	encodeDate(e, t)
 	encodeTime(e, t)
 	return nil
@@ -547,11 +663,11 @@ func encodeTime(e *encoding.Encoder, t time.Time) {
 }
 
 func (ft _longdateType) encodePrm(e *encoding.Encoder, v any) error {
-	e.Int64(convertTimeToLongdate(asTime(v)))
+	e.Int64(convertTimeToLongdate(asTimeIn(v, ft.loc)))
 	return nil
 }
 func (ft _seconddateType) encodePrm(e *encoding.Encoder, v any) error {
-	e.Int64(convertTimeToSeconddate(asTime(v)))
+	e.Int64(convertTimeToSeconddate(asTimeIn(v, ft.loc)))
 	return nil
 }
 func (ft _daydateType) encodePrm(e *encoding.Encoder, v any) error {
@@ -576,6 +692,23 @@ func asTime(v any) time.Time {
 	return t.UTC()
 }
 
+// asTimeIn returns the wall clock of time value v in location loc as UTC time (loc nil: v in UTC).
+func asTimeIn(v any, loc *time.Location) time.Time {
+	if loc == nil {
+		return asTime(v)
+	}
+	t := asTime(v).In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+// timeIn interprets the wall clock of UTC time t in location loc (loc nil: t).
+func timeIn(t time.Time, loc *time.Location) time.Time {
+	if loc == nil {
+		return t
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+}
+
 func (ft _decimalType) encodePrm(e *encoding.Encoder, v any) error {
 	r, ok := v.(*big.Rat)
 	if !ok {
@@ -619,7 +752,7 @@ func encodeFixed(e *encoding.Encoder, v any, size, prec, scale int) error {
 	df := convertRatToFixed(r, &m, prec, scale)
 
 	if df&dfOverflow != 0 {
-		return ErrDecimalOutOfRange
+		return fmt.Errorf("%w: value %s does not fit into decimal(%d,%d)", ErrDecimalOutOfRange, r.FloatString(scale), prec, scale)
 	}
 
 	e.Fixed(&m, size)
@@ -758,16 +891,22 @@ func (ft _bigintType) decodeRes(d *encoding.Decoder) (any, error) {
 	return ft.decodePrm(d)
 }
 
-func (_realType) decodeRes(d *encoding.Decoder) (any, error) {
+func (ft _realType) decodeRes(d *encoding.Decoder) (any, error) {
 	v := d.Uint32()
 	if v == realNullValue {
+		if ft.nullAsNaN {
+			return math.NaN(), nil
+		}
 		return nil, nil
 	}
 	return float64(math.Float32frombits(v)), nil
 }
-func (_doubleType) decodeRes(d *encoding.Decoder) (any, error) {
+func (ft _doubleType) decodeRes(d *encoding.Decoder) (any, error) {
 	v := d.Uint64()
 	if v == doubleNullValue {
+		if ft.nullAsNaN {
+			return math.NaN(), nil
+		}
 		return nil, nil
 	}
 	return math.Float64frombits(v), nil
@@ -788,13 +927,13 @@ func (_timeType) decodeRes(d *encoding.Decoder) (any, error) {
 	}
 	return time.Date(1, 1, 1, hour, min, sec, nsec, time.UTC), nil
 }
-func (_timestampType) decodeRes(d *encoding.Decoder) (any, error) {
+func (ft _timestampType) decodeRes(d *encoding.Decoder) (any, error) {
 	year, month, day, dateNull := decodeDate(d)
 	hour, min, sec, nsec, timeNull := decodeTime(d)
 	if dateNull || timeNull {
 		return nil, nil
 	}
-	return time.Date(year, month, day, hour, min, sec, nsec, time.UTC), nil
+	return timeIn(time.Date(year, month, day, hour, min, sec, nsec, time.UTC), ft.loc), nil
 }
 
 /*
@@ -828,19 +967,19 @@ func decodeTime(d *encoding.Decoder) (int, int, int, int, bool) {
 	return int(hour), int(min), int(sec), nsec, null
 }
 
-func (_longdateType) decodeRes(d *encoding.Decoder) (any, error) {
+func (ft _longdateType) decodeRes(d *encoding.Decoder) (any, error) {
 	longdate := d.Int64()
 	if longdate == longdateNullValue {
 		return nil, nil
 	}
-	return convertLongdateToTime(longdate), nil
+	return timeIn(convertLongdateToTime(longdate), ft.loc), nil
 }
-func (_seconddateType) decodeRes(d *encoding.Decoder) (any, error) {
+func (ft _seconddateType) decodeRes(d *encoding.Decoder) (any, error) {
 	seconddate := d.Int64()
 	if seconddate == seconddateNullValue {
 		return nil, nil
 	}
-	return convertSeconddateToTime(seconddate), nil
+	return timeIn(convertSeconddateToTime(seconddate), ft.loc), nil
 }
 func (ft _daydateType) decodeRes(d *encoding.Decoder) (any, error) {
 	daydate := d.Int32()
@@ -857,7 +996,7 @@ func (_secondtimeType) decodeRes(d *encoding.Decoder) (any, error) {
 	return convertSecondtimeToTime(int(secondtime)), nil
 }
 
-func (_decimalType) decodeRes(d *encoding.Decoder) (any, error) {
+func (ft _decimalType) decodeRes(d *encoding.Decoder) (any, error) {
 	m, exp, err := d.Decimal()
 	if err != nil {
 		return nil, err
@@ -865,6 +1004,9 @@ func (_decimalType) decodeRes(d *encoding.Decoder) (any, error) {
 	if m == nil {
 		return nil, nil
 	}
+	if ft.asText {
+		return formatDecimal(m, exp), nil
+	}
 	return convertDecimalToRat(m, exp), nil
 }
 
@@ -872,30 +1014,33 @@ func (ft _fixed8Type) decodeRes(d *encoding.Decoder) (any, error) {
 	if !d.Bool() { // null value
 		return nil, nil
 	}
-	return decodeFixed(d, encoding.Fixed8FieldSize, ft.scale)
+	return decodeFixed(d, encoding.Fixed8FieldSize, ft.scale, ft.asText)
 }
 func (ft _fixed12Type) decodeRes(d *encoding.Decoder) (any, error) {
 	if !d.Bool() { // null value
 		return nil, nil
 	}
-	return decodeFixed(d, encoding.Fixed12FieldSize, ft.scale)
+	return decodeFixed(d, encoding.Fixed12FieldSize, ft.scale, ft.asText)
 }
 func (ft _fixed16Type) decodeRes(d *encoding.Decoder) (any, error) {
 	if !d.Bool() { // null value
 		return nil, nil
 	}
-	return decodeFixed(d, encoding.Fixed16FieldSize, ft.scale)
+	return decodeFixed(d, encoding.Fixed16FieldSize, ft.scale, ft.asText)
 }
 
-func decodeFixed(d *encoding.Decoder, size, scale int) (any, error) {
+func decodeFixed(d *encoding.Decoder, size, scale int, asText bool) (any, error) {
 	m := d.Fixed(size)
 	if m == nil { // important: return nil and not m (as m is of type *big.Int)
 		return nil, nil
 	}
+	if asText {
+		return formatDecimal(m, -scale), nil
+	}
 	return convertFixedToRat(m, scale), nil
 }
 
-func (_varType) decodeRes(d *encoding.Decoder) (any, error) {
+func (ft _varType) decodeRes(d *encoding.Decoder) (any, error) {
 	_, b := d.LIBytes()
 	/*
 	   caution:
@@ -903,6 +1048,9 @@ func (_varType) decodeRes(d *encoding.Decoder) (any, error) {
 	   - returning b == nil does not work because b is of type []byte
 	*/
 	if b == nil {
+		if ft.emptyStringAsNull {
+			return []byte{}, nil
+		}
 		return nil, nil
 	}
 	return b, nil
@@ -916,6 +1064,9 @@ func (ft _alphaType) decodeRes(d *encoding.Decoder) (any, error) {
 	   - returning b == nil does not work because b is of type []byte
 	*/
 	if b == nil {
+		if ft.emptyStringAsNull {
+			return []byte{}, nil
+		}
 		return nil, nil
 	}
 	if ft.isDfv1 { // like _varType
@@ -945,7 +1096,7 @@ func (_hexType) decodeRes(d *encoding.Decoder) (any, error) {
 	return hex.EncodeToString(b), nil
 }
 
-func (_cesu8Type) decodeRes(d *encoding.Decoder) (any, error) {
+func (ft _cesu8Type) decodeRes(d *encoding.Decoder) (any, error) {
 	_, b, err := d.CESU8LIBytes()
 	if err != nil {
 		return nil, err
@@ -956,6 +1107,9 @@ func (_cesu8Type) decodeRes(d *encoding.Decoder) (any, error) {
 	   - returning b == nil does not work because b is of type []byte
 	*/
 	if b == nil {
+		if ft.emptyStringAsNull {
+			return []byte{}, nil
+		}
 		return nil, nil
 	}
 	return b, nil
@@ -0,0 +1,154 @@
+package protocol
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/SAP/go-hdb/driver/internal/protocol/encoding"
+	"github.com/SAP/go-hdb/driver/unicode/cesu8"
+)
+
+func TestTimeZone(t *testing.T) {
+	// hdb datetime types do not store any time zone information:
+	// the point in time is preserved, the location is always UTC.
+	ftc := NewFieldTypeCtx(DfvLevel8, false, false)
+
+	locations := []*time.Location{
+		time.UTC,
+		time.FixedZone("UTC+05:30", 5*60*60+30*60),
+		time.FixedZone("UTC-08:00", -8*60*60),
+		time.FixedZone("UTC+14:00", 14*60*60),
+	}
+
+	for _, tc := range []typeCode{tcTimestamp, tcLongdate, tcSeconddate} {
+		ft := ftc.fieldType(tc, 0, 0)
+		for _, loc := range locations {
+			v := time.Date(2024, time.February, 29, 23, 30, 15, 0, loc)
+
+			buf := &bytes.Buffer{}
+			enc := encoding.NewEncoder(buf, cesu8.DefaultEncoder)
+			if err := ft.encodePrm(enc, v); err != nil {
+				t.Fatal(err)
+			}
+			dec := encoding.NewDecoder(buf, cesu8.DefaultDecoder)
+			r, err := ft.decodeRes(dec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rt := r.(time.Time)
+			if !rt.Equal(v) {
+				t.Fatalf("%s %s: time %v - expected %v", tc, loc, rt, v)
+			}
+			if rt.Location() != time.UTC {
+				t.Fatalf("%s %s: location %s - expected %s", tc, loc, rt.Location(), time.UTC)
+			}
+		}
+	}
+}
+
+func TestTimeZoneLocation(t *testing.T) {
+	// with a field type context location the timestamp wall clock is interpreted in the location
+	// (e.g. the session time zone of the database server).
+	loc := time.FixedZone("UTC+02:00", 2*60*60)
+	ftc := NewFieldTypeCtx(DfvLevel8, false, false)
+	ftc.SetLocation(loc)
+
+	v := time.Date(2024, time.February, 29, 23, 30, 15, 0, time.UTC)
+	for _, tc := range []typeCode{tcTimestamp, tcLongdate, tcSeconddate} {
+		ft := ftc.fieldType(tc, 0, 0)
+
+		buf := &bytes.Buffer{}
+		enc := encoding.NewEncoder(buf, cesu8.DefaultEncoder)
+		if err := ft.encodePrm(enc, v); err != nil {
+			t.Fatal(err)
+		}
+		// database wall clock is the wall clock in location
+		r, err := NewFieldTypeCtx(DfvLevel8, false, false).fieldType(tc, 0, 0).decodeRes(encoding.NewDecoder(bytes.NewReader(buf.Bytes()), cesu8.DefaultDecoder))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if wallClock, expected := r.(time.Time), time.Date(2024, time.March, 1, 1, 30, 15, 0, time.UTC); !wallClock.Equal(expected) {
+			t.Fatalf("%s: wall clock %v - expected %v", tc, wallClock, expected)
+		}
+
+		r, err = ft.decodeRes(encoding.NewDecoder(buf, cesu8.DefaultDecoder))
+		if err != nil {
+			t.Fatal(err)
+		}
+		rt := r.(time.Time)
+		if !rt.Equal(v) {
+			t.Fatalf("%s: time %v - expected %v", tc, rt, v)
+		}
+		if rt.Location() != loc {
+			t.Fatalf("%s: location %s - expected %s", tc, rt.Location(), loc)
+		}
+	}
+}
+
+func TestDecodeLobRes(t *testing.T) {
+	encodeLob := func(opt LobOptions, b []byte) []byte {
+		buf := &bytes.Buffer{}
+		enc := encoding.NewEncoder(buf, cesu8.DefaultEncoder)
+		enc.Int8(int8(ltcUndefined))
+		enc.Int8(int8(opt))
+		if opt.isNull() {
+			return buf.Bytes()
+		}
+		enc.Zeroes(2)
+		enc.Int64(int64(len(b))) // numChar
+		enc.Int64(int64(len(b))) // numByte
+		enc.Uint64(1)            // locator id
+		enc.Int32(int32(len(b)))
+		enc.Bytes(b)
+		return buf.Bytes()
+	}
+
+	testData := []struct {
+		name string
+		opt  LobOptions
+		b    []byte
+	}{
+		{"null", loNullindicator, nil},
+		{"empty", loDataincluded | loLastdata, []byte{}},
+		{"populated", loDataincluded | loLastdata, []byte("lob data")},
+	}
+
+	for _, d := range testData {
+		t.Run(d.name, func(t *testing.T) {
+			dec := encoding.NewDecoder(bytes.NewReader(encodeLob(d.opt, d.b)), cesu8.DefaultDecoder)
+			v, err := lobVarType.decodeRes(dec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d.opt.isNull() {
+				if v != nil {
+					t.Fatalf("value %v - expected nil", v)
+				}
+				return
+			}
+			descr, ok := v.(*LobOutDescr)
+			if !ok {
+				t.Fatalf("value type %T - expected %T", v, descr)
+			}
+			if !bytes.Equal(descr.B, d.b) || !descr.Opt.IsLastData() {
+				t.Fatalf("lob data %v options %s - expected %v", descr.B, descr.Opt, d.b)
+			}
+		})
+	}
+}
+
+func TestSupportedTypes(t *testing.T) {
+	ftc := NewFieldTypeCtx(defaultDfv, false, false)
+	for _, ti := range SupportedTypes() {
+		tc := typeCode(ti.TypeCode)
+		switch ftc.fieldType(tc, 0, 0).(type) {
+		case fieldConverter, cesu8FieldConverter:
+		default:
+			t.Fatalf("%s: field type does not support conversion", tc)
+		}
+		if len(ti.DataType.BindTypes()) == 0 {
+			t.Fatalf("%s: missing bind types", tc)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package protocol
+
+import (
+	"math"
+	"time"
+
+	"github.com/SAP/go-hdb/driver/internal/protocol/encoding"
+)
+
+// fixedKind is the kind of a fixed width result column.
+type fixedKind byte
+
+const (
+	fkTinyint fixedKind = iota
+	fkSmallint
+	fkInteger
+	fkBigint
+	fkReal
+	fkDouble
+	fkDaydate
+	fkLongdate
+	fkSeconddate
+	fkSecondtime
+)
+
+// fixedColumn describes a fixed width result column for the fast row decoding path.
+type fixedColumn struct {
+	kind            fixedKind
+	nullAsNaN       bool           // real, double
+	emptyDateAsNull bool           // daydate
+	loc             *time.Location // longdate, seconddate
+}
+
+// fixedColumns returns the fixed width column descriptions of fields
+// or nil if at least one field is not of a fixed width type supported by the fast path.
+func fixedColumns(fields []*ResultField) []fixedColumn {
+	if len(fields) == 0 {
+		return nil
+	}
+	columns := make([]fixedColumn, len(fields))
+	for i, f := range fields {
+		switch ft := f.ft.(type) {
+		case _tinyintType:
+			columns[i] = fixedColumn{kind: fkTinyint}
+		case _smallintType:
+			columns[i] = fixedColumn{kind: fkSmallint}
+		case _integerType:
+			columns[i] = fixedColumn{kind: fkInteger}
+		case _bigintType:
+			columns[i] = fixedColumn{kind: fkBigint}
+		case _realType:
+			columns[i] = fixedColumn{kind: fkReal, nullAsNaN: ft.nullAsNaN}
+		case _doubleType:
+			columns[i] = fixedColumn{kind: fkDouble, nullAsNaN: ft.nullAsNaN}
+		case _daydateType:
+			columns[i] = fixedColumn{kind: fkDaydate, emptyDateAsNull: ft.emptyDateAsNull}
+		case _longdateType:
+			columns[i] = fixedColumn{kind: fkLongdate, loc: ft.loc}
+		case _seconddateType:
+			columns[i] = fixedColumn{kind: fkSeconddate, loc: ft.loc}
+		case _secondtimeType:
+			columns[i] = fixedColumn{kind: fkSecondtime}
+		default:
+			return nil
+		}
+	}
+	return columns
+}
+
+/*
+decodeFixedRows decodes numArg rows of fixed width columns (fast path).
+
+In contrast to the general path the values are decoded without field type dispatch per value: the
+decoding is selected by the fixed width kind of the column, which is determined once per resultset part.
+*/
+func (r *Resultset) decodeFixedRows(dec *encoding.Decoder, numArg int, columns []fixedColumn) error {
+	cols := len(columns)
+	r.FieldValues = resizeSlice(r.FieldValues, numArg*cols)
+
+	values := r.FieldValues
+	for i := range values {
+		c := &columns[i%cols]
+		var v any
+		switch c.kind {
+		case fkTinyint:
+			if dec.Bool() {
+				v = int64(dec.Byte())
+			}
+		case fkSmallint:
+			if dec.Bool() {
+				v = int64(dec.Int16())
+			}
+		case fkInteger:
+			if dec.Bool() {
+				v = int64(dec.Int32())
+			}
+		case fkBigint:
+			if dec.Bool() {
+				v = dec.Int64()
+			}
+		case fkReal:
+			if bits := dec.Uint32(); bits != realNullValue {
+				v = float64(math.Float32frombits(bits))
+			} else if c.nullAsNaN {
+				v = math.NaN()
+			}
+		case fkDouble:
+			if bits := dec.Uint64(); bits != doubleNullValue {
+				v = math.Float64frombits(bits)
+			} else if c.nullAsNaN {
+				v = math.NaN()
+			}
+		case fkDaydate:
+			if daydate := dec.Int32(); daydate != daydateNullValue && !(c.emptyDateAsNull && daydate == 0) {
+				v = convertDaydateToTime(int64(daydate))
+			}
+		case fkLongdate:
+			if longdate := dec.Int64(); longdate != longdateNullValue {
+				v = timeIn(convertLongdateToTime(longdate), c.loc)
+			}
+		case fkSeconddate:
+			if seconddate := dec.Int64(); seconddate != seconddateNullValue {
+				v = timeIn(convertSeconddateToTime(seconddate), c.loc)
+			}
+		case fkSecondtime:
+			if secondtime := dec.Int32(); secondtime != secondtimeNullValue {
+				v = convertSecondtimeToTime(int(secondtime))
+			}
+		}
+		values[i] = v
+	}
+	return dec.Error()
+}
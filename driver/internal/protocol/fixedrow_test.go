@@ -0,0 +1,193 @@
+package protocol
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/SAP/go-hdb/driver/internal/protocol/encoding"
+	"github.com/SAP/go-hdb/driver/unicode/cesu8"
+)
+
+// fixedResultset returns a resultset with columns of all fixed width types and the encoded row data
+// where every nullEvery'th field is NULL.
+func fixedResultset(numRow, nullEvery int, nullAsNaN bool) (*Resultset, []byte) {
+	ftc := NewFieldTypeCtx(DfvLevel8, false, false)
+	ftc.SetNullFloatAsNaN(nullAsNaN)
+	names := &fieldNames{}
+
+	tcs := []typeCode{tcTinyint, tcSmallint, tcInteger, tcBigint, tcReal, tcDouble, tcDaydate, tcLongdate, tcSeconddate, tcSecondtime}
+	fields := make([]*ResultField, len(tcs))
+	for i, tc := range tcs {
+		fields[i] = &ResultField{names: names, tc: tc, ft: ftc.fieldType(tc, 0, 0), columnOptions: coOptional}
+	}
+
+	t := time.Date(2024, time.February, 29, 23, 30, 15, 100, time.UTC)
+
+	buf := &bytes.Buffer{}
+	enc := encoding.NewEncoder(buf, cesu8.DefaultEncoder)
+	for i := 0; i < numRow; i++ {
+		for j, f := range fields {
+			null := (i*len(fields)+j)%nullEvery == 0
+			switch f.tc {
+			case tcTinyint:
+				enc.Bool(!null)
+				if !null {
+					enc.Byte(byte(i))
+				}
+			case tcSmallint:
+				enc.Bool(!null)
+				if !null {
+					enc.Int16(int16(-i * 100))
+				}
+			case tcInteger:
+				enc.Bool(!null)
+				if !null {
+					enc.Int32(int32(i * 1000))
+				}
+			case tcBigint:
+				enc.Bool(!null)
+				if !null {
+					enc.Int64(int64(i) << 40)
+				}
+			case tcReal:
+				if null {
+					enc.Uint32(realNullValue)
+				} else {
+					enc.Float32(float32(i) / 4)
+				}
+			case tcDouble:
+				if null {
+					enc.Uint64(doubleNullValue)
+				} else {
+					enc.Float64(float64(i) / 3)
+				}
+			case tcDaydate:
+				if null {
+					enc.Int32(daydateNullValue)
+				} else {
+					enc.Int32(int32(convertTimeToDayDate(t.AddDate(0, 0, i))))
+				}
+			case tcLongdate:
+				if null {
+					enc.Int64(longdateNullValue)
+				} else {
+					enc.Int64(convertTimeToLongdate(t.Add(time.Duration(i) * time.Hour)))
+				}
+			case tcSeconddate:
+				if null {
+					enc.Int64(seconddateNullValue)
+				} else {
+					enc.Int64(convertTimeToSeconddate(t.Add(time.Duration(i) * time.Minute)))
+				}
+			case tcSecondtime:
+				if null {
+					enc.Int32(secondtimeNullValue)
+				} else {
+					enc.Int32(int32(convertTimeToSecondtime(t.Add(time.Duration(i) * time.Second))))
+				}
+			}
+		}
+	}
+	return &Resultset{ResultFields: fields}, buf.Bytes()
+}
+
+func TestFixedRows(t *testing.T) {
+	const numRow, nullEvery = 32, 7
+
+	for _, nullAsNaN := range []bool{false, true} {
+		rs, data := fixedResultset(numRow, nullEvery, nullAsNaN)
+		if fixedColumns(rs.ResultFields) == nil {
+			t.Fatal("fast path not applicable")
+		}
+
+		if err := rs.decodeNumArg(encoding.NewDecoder(bytes.NewReader(data), cesu8.DefaultDecoder), numRow); err != nil {
+			t.Fatal(err)
+		}
+		fixed := rs.FieldValues
+		rs.FieldValues = nil
+		if err := rs.decodeRows(encoding.NewDecoder(bytes.NewReader(data), cesu8.DefaultDecoder), numRow); err != nil {
+			t.Fatal(err)
+		}
+		for i, v := range fixed {
+			expected := rs.FieldValues[i]
+			null := i%nullEvery == 0
+			if f, ok := v.(float64); ok && math.IsNaN(f) { // NaN does not equal NaN
+				if e, ok := expected.(float64); !ok || !math.IsNaN(e) || !null || !nullAsNaN {
+					t.Fatalf("null as NaN %t field %d: value %v - expected %v", nullAsNaN, i, v, expected)
+				}
+				continue
+			}
+			if !reflect.DeepEqual(v, expected) {
+				t.Fatalf("null as NaN %t field %d: value %v - expected %v", nullAsNaN, i, v, expected)
+			}
+			if null != (v == nil) {
+				t.Fatalf("null as NaN %t field %d: value %v - expected null %t", nullAsNaN, i, v, null)
+			}
+		}
+	}
+}
+
+func TestFixedColumns(t *testing.T) {
+	ftc := NewFieldTypeCtx(DfvLevel8, false, false)
+	ftc.SetNullFloatAsNaN(true)
+	names := &fieldNames{}
+
+	fields := func(tcs ...typeCode) []*ResultField {
+		fields := make([]*ResultField, len(tcs))
+		for i, tc := range tcs {
+			fields[i] = &ResultField{names: names, tc: tc, ft: ftc.fieldType(tc, 0, 0)}
+		}
+		return fields
+	}
+
+	if columns := fixedColumns(fields(tcInteger, tcVarchar)); columns != nil { // variable width column: general path
+		t.Fatalf("fixed columns %v - expected nil", columns)
+	}
+	columns := fixedColumns(fields(tcDouble))
+	if len(columns) != 1 || !columns[0].nullAsNaN {
+		t.Fatalf("fixed columns %v - expected null as NaN", columns)
+	}
+
+	// NULL double decoded as NaN
+	rs := &Resultset{ResultFields: fields(tcDouble)}
+	data := []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+	if err := rs.decodeNumArg(encoding.NewDecoder(bytes.NewReader(data), cesu8.DefaultDecoder), 1); err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := rs.FieldValues[0].(float64); !ok || !math.IsNaN(v) {
+		t.Fatalf("value %v - expected NaN", rs.FieldValues[0])
+	}
+}
+
+func BenchmarkFixedRows(b *testing.B) {
+	const numRow, nullEvery = 1000, 10
+
+	rs, data := fixedResultset(numRow, nullEvery, false)
+	columns := fixedColumns(rs.ResultFields)
+	rd := bytes.NewReader(data)
+	dec := encoding.NewDecoder(rd, cesu8.DefaultDecoder)
+
+	b.Run("general", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(data)))
+		for i := 0; i < b.N; i++ {
+			rd.Reset(data)
+			if err := rs.decodeRows(dec, numRow); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("fixed", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(data)))
+		for i := 0; i < b.N; i++ {
+			rd.Reset(data)
+			if err := rs.decodeFixedRows(dec, numRow, columns); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
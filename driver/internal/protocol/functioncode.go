@@ -31,6 +31,11 @@ const (
 	fcXAJoin                    FunctionCode = 23
 )
 
+// IsDDL returns true if the function code is a DDL statement, false otherwise.
+func (fc FunctionCode) IsDDL() bool {
+	return fc == FcDDL
+}
+
 // IsProcedureCall returns true if the function code is a procedure call, false otherwise.
 func (fc FunctionCode) IsProcedureCall() bool {
 	return fc == fcDBProcedureCall
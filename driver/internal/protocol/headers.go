@@ -1,12 +1,23 @@
 package protocol
 
 import (
+	"errors"
 	"fmt"
 	"math"
 
 	"github.com/SAP/go-hdb/driver/internal/protocol/encoding"
 )
 
+const (
+	messageHeaderSize = 32
+)
+
+type packetOptions int8
+
+const (
+	poCompressed packetOptions = 0x02 // variable part is lz4 compressed
+)
+
 // Message header (size: 32 bytes).
 type messageHeader struct {
 	sessionID     int64
@@ -14,15 +25,30 @@ type messageHeader struct {
 	varPartLength uint32
 	varPartSize   uint32
 	noOfSegm      int16
+	packetOptions packetOptions
+	// uncompressed variable part length (set only in case of compressed messages)
+	compressionVarPartLength uint32
 }
 
 func (h *messageHeader) String() string {
-	return fmt.Sprintf("session id %d packetCount %d varPartLength %d, varPartSize %d noOfSegm %d",
+	return fmt.Sprintf("session id %d packetCount %d varPartLength %d, varPartSize %d noOfSegm %d packetOptions %d compressionVarPartLength %d",
 		h.sessionID,
 		h.packetCount,
 		h.varPartLength,
 		h.varPartSize,
-		h.noOfSegm)
+		h.noOfSegm,
+		h.packetOptions,
+		h.compressionVarPartLength)
+}
+
+func (h *messageHeader) compressed() bool { return h.packetOptions&poCompressed != 0 }
+
+// uncompressedVarPartLength returns the variable part length of the (uncompressed) message.
+func (h *messageHeader) uncompressedVarPartLength() uint32 {
+	if h.compressed() {
+		return h.compressionVarPartLength
+	}
+	return h.varPartLength
 }
 
 func (h *messageHeader) encode(enc *encoding.Encoder) error {
@@ -31,7 +57,10 @@ func (h *messageHeader) encode(enc *encoding.Encoder) error {
 	enc.Uint32(h.varPartLength)
 	enc.Uint32(h.varPartSize)
 	enc.Int16(h.noOfSegm)
-	enc.Zeroes(10) // size: 32 bytes
+	enc.Int8(int8(h.packetOptions))
+	enc.Zeroes(1)
+	enc.Uint32(h.compressionVarPartLength)
+	enc.Zeroes(4) // size: 32 bytes
 	return nil
 }
 
@@ -41,7 +70,10 @@ func (h *messageHeader) decode(dec *encoding.Decoder) error {
 	h.varPartLength = dec.Uint32()
 	h.varPartSize = dec.Uint32()
 	h.noOfSegm = dec.Int16()
-	dec.Skip(10) // size: 32 bytes
+	h.packetOptions = packetOptions(dec.Int8())
+	dec.Skip(1)
+	h.compressionVarPartLength = dec.Uint32()
+	dec.Skip(4) // size: 32 bytes
 	return dec.Error()
 }
 
@@ -194,6 +226,16 @@ const (
 // MaxNumArg is the maximum number of arguments allowed to send in a part.
 const MaxNumArg = math.MaxInt32
 
+// ErrMaxNumArgExceeded is the error raised if the number of arguments of a part exceeds MaxNumArg.
+var ErrMaxNumArgExceeded = errors.New("maximum number of arguments exceeded")
+
+func checkNumArg(numArg int) error {
+	if numArg < 0 || int64(numArg) > MaxNumArg {
+		return fmt.Errorf("%w: %d - maximum %d", ErrMaxNumArgExceeded, numArg, MaxNumArg)
+	}
+	return nil
+}
+
 // PartAttributes represents the part attributes.
 type PartAttributes int8
 
@@ -249,13 +291,14 @@ func (h *partHeader) String() string {
 }
 
 func (h *partHeader) setNumArg(numArg int) error {
+	if err := checkNumArg(numArg); err != nil {
+		return err
+	}
 	switch {
-	default:
-		return fmt.Errorf("maximum number of arguments %d exceeded", numArg)
 	case numArg <= math.MaxInt16:
 		h.argumentCount = int16(numArg)
 		h.bigArgumentCount = 0
-	case numArg <= math.MaxInt32:
+	default:
 		h.argumentCount = bigNumArgInd
 		h.bigArgumentCount = int32(numArg)
 	}
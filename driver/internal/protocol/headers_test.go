@@ -0,0 +1,75 @@
+package protocol
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"errors"
+	"log/slog"
+	"math"
+	"testing"
+
+	"github.com/SAP/go-hdb/driver/internal/protocol/encoding"
+	"github.com/SAP/go-hdb/driver/unicode/cesu8"
+)
+
+func TestPartHeaderNumArg(t *testing.T) {
+	tests := []struct {
+		numArg        int
+		argumentCount int16
+		err           error
+	}{
+		{0, 0, nil},
+		{math.MaxInt16, math.MaxInt16, nil},
+		{math.MaxInt16 + 1, bigNumArgInd, nil},
+		{MaxNumArg, bigNumArgInd, nil},
+		{-1, 0, ErrMaxNumArgExceeded},
+	}
+
+	for _, test := range tests {
+		ph := &partHeader{}
+		err := ph.setNumArg(test.numArg)
+		if !errors.Is(err, test.err) {
+			t.Fatalf("numArg %d: error %v - expected %v", test.numArg, err, test.err)
+		}
+		if err != nil {
+			continue
+		}
+		if ph.argumentCount != test.argumentCount {
+			t.Fatalf("numArg %d: argument count %d - expected %d", test.numArg, ph.argumentCount, test.argumentCount)
+		}
+		if ph.numArg() != test.numArg {
+			t.Fatalf("numArg %d: got %d", test.numArg, ph.numArg())
+		}
+	}
+}
+
+// testRowsPart is a writable part reporting more rows than fit into a part.
+type testRowsPart struct{ rows int }
+
+func (p *testRowsPart) String() string                     { return "testRowsPart" }
+func (p *testRowsPart) kind() PartKind                     { return PkParameters }
+func (p *testRowsPart) numArg() int                        { return p.rows }
+func (p *testRowsPart) size() int                          { return 0 }
+func (p *testRowsPart) encode(enc *encoding.Encoder) error { return nil }
+
+func TestWriterNumArgExceeded(t *testing.T) {
+	rows := MaxNumArg
+	rows++ // overflows on 32-bit platforms, which needs to be detected as well
+
+	buf := &bytes.Buffer{}
+	wr := bufio.NewWriter(buf)
+	w := NewWriter(wr, false, false, slog.Default(), nil, cesu8.DefaultEncoder, nil)
+
+	err := w.Write(context.Background(), 1, MtExecute, false, &testRowsPart{rows: rows})
+	if !errors.Is(err, ErrMaxNumArgExceeded) {
+		t.Fatalf("error %v - expected %v", err, ErrMaxNumArgExceeded)
+	}
+	if errors.Is(err, driver.ErrBadConn) {
+		t.Fatal("connection should not be marked as bad")
+	}
+	if wr.Buffered() != 0 || buf.Len() != 0 {
+		t.Fatalf("nothing should be written - buffered %d written %d", wr.Buffered(), buf.Len())
+	}
+}
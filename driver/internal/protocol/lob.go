@@ -219,6 +219,15 @@ func (r *WriteLobRequest) size() int {
 
 func (r *WriteLobRequest) numArg() int { return len(r.Descrs) }
 
+// NumByte returns the number of lob bytes of the request.
+func (r *WriteLobRequest) NumByte() int {
+	numByte := 0
+	for _, descr := range r.Descrs {
+		numByte += len(descr.b)
+	}
+	return numByte
+}
+
 // sniffer.
 func (r *WriteLobRequest) decodeNumArg(dec *encoding.Decoder, numArg int) error {
 	r.Descrs = make([]*WriteLobDescr, numArg)
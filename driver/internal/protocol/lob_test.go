@@ -0,0 +1,40 @@
+package protocol
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteLobDescrFetchNext(t *testing.T) {
+	const chunkSize = 128
+
+	testData := []int{0, 1, chunkSize - 1, chunkSize, chunkSize + 1, 10*chunkSize + 7}
+
+	for _, size := range testData {
+		src := bytes.Repeat([]byte{'x'}, size)
+		descr := &WriteLobDescr{LobInDescr: newLobInDescr(bytes.NewReader(src))}
+
+		numByte := 0
+		for {
+			if err := descr.FetchNext(chunkSize); err != nil {
+				t.Fatal(err)
+			}
+			if len(descr.b) > chunkSize {
+				t.Fatalf("size %d: chunk size %d exceeds %d", size, len(descr.b), chunkSize)
+			}
+			if descr.ofs != -1 {
+				t.Fatalf("size %d: offset %d - expected append (-1)", size, descr.ofs)
+			}
+			numByte += len(descr.b)
+			if descr.Opt.IsLastData() {
+				break
+			}
+			if len(descr.b) != chunkSize {
+				t.Fatalf("size %d: chunk size %d before last chunk - expected %d", size, len(descr.b), chunkSize)
+			}
+		}
+		if numByte != size {
+			t.Fatalf("size %d: number of written bytes %d", size, numByte)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+// Package lz4 implements the LZ4 block format used for hdb protocol message compression.
+// The format is described in https://github.com/lz4/lz4/blob/dev/doc/lz4_Block_format.md.
+package lz4
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+const (
+	minMatch     = 4
+	lastLiterals = 5  // the last 5 bytes of a block are always literals
+	mfLimit      = 12 // the last match must start at least 12 bytes before the end of a block
+	maxOffset    = 1<<16 - 1
+
+	hashLog   = 16
+	tableSize = 1 << hashLog
+)
+
+// ErrCorrupt is returned by Decompress in case of invalid compressed data.
+var ErrCorrupt = errors.New("lz4: corrupt input")
+
+// CompressBound returns the maximum size of compressed data of size n.
+func CompressBound(n int) int { return n + n/255 + 16 }
+
+func hash(v uint32) uint32 { return (v * 2654435761) >> (32 - hashLog) }
+
+func appendLength(dst []byte, n int) []byte {
+	for ; n >= 255; n -= 255 {
+		dst = append(dst, 255)
+	}
+	return append(dst, byte(n))
+}
+
+func appendSequence(dst, literals []byte, offset, matchLen int) []byte {
+	litLen := len(literals)
+	token := byte(min(litLen, 15)) << 4
+	if matchLen != 0 {
+		token |= byte(min(matchLen-minMatch, 15))
+	}
+	dst = append(dst, token)
+	if litLen >= 15 {
+		dst = appendLength(dst, litLen-15)
+	}
+	dst = append(dst, literals...)
+	if matchLen == 0 { // last sequence
+		return dst
+	}
+	dst = binary.LittleEndian.AppendUint16(dst, uint16(offset))
+	if matchLen-minMatch >= 15 {
+		dst = appendLength(dst, matchLen-minMatch-15)
+	}
+	return dst
+}
+
+// Compress appends the LZ4 block compressed src to dst and returns the extended buffer.
+func Compress(dst, src []byte) []byte {
+	n := len(src)
+	anchor := 0
+	if n > mfLimit {
+		var table [tableSize]int32 // position + 1 (zero: no entry)
+		for i := 0; i < n-mfLimit; {
+			seq := binary.LittleEndian.Uint32(src[i:])
+			h := hash(seq)
+			ref := int(table[h]) - 1
+			table[h] = int32(i + 1)
+			if ref < 0 || i-ref > maxOffset || binary.LittleEndian.Uint32(src[ref:]) != seq {
+				i++
+				continue
+			}
+			matchLen := minMatch
+			for i+matchLen < n-lastLiterals && src[ref+matchLen] == src[i+matchLen] {
+				matchLen++
+			}
+			dst = appendSequence(dst, src[anchor:i], i-ref, matchLen)
+			i += matchLen
+			anchor = i
+		}
+	}
+	return appendSequence(dst, src[anchor:], 0, 0)
+}
+
+func readLength(src []byte, si, n int) (int, int, error) {
+	for {
+		if si >= len(src) {
+			return 0, 0, ErrCorrupt
+		}
+		b := src[si]
+		si++
+		n += int(b)
+		if b != 255 {
+			return n, si, nil
+		}
+	}
+}
+
+// Decompress decompresses the LZ4 block src into dst and returns the number of bytes written to dst.
+// dst needs to be large enough to hold the decompressed data.
+func Decompress(dst, src []byte) (int, error) {
+	var si, di int
+	for si < len(src) {
+		token := src[si]
+		si++
+
+		litLen := int(token >> 4)
+		if litLen == 15 {
+			var err error
+			if litLen, si, err = readLength(src, si, litLen); err != nil {
+				return di, err
+			}
+		}
+		if si+litLen > len(src) || di+litLen > len(dst) {
+			return di, ErrCorrupt
+		}
+		di += copy(dst[di:], src[si:si+litLen])
+		si += litLen
+		if si == len(src) { // last sequence
+			return di, nil
+		}
+
+		if si+2 > len(src) {
+			return di, ErrCorrupt
+		}
+		offset := int(binary.LittleEndian.Uint16(src[si:]))
+		si += 2
+		if offset == 0 || offset > di {
+			return di, ErrCorrupt
+		}
+
+		matchLen := int(token & 0x0f)
+		if matchLen == 15 {
+			var err error
+			if matchLen, si, err = readLength(src, si, matchLen); err != nil {
+				return di, err
+			}
+		}
+		matchLen += minMatch
+		if di+matchLen > len(dst) {
+			return di, ErrCorrupt
+		}
+		// byte by byte copy as source and destination might overlap
+		for ref := di - offset; matchLen > 0; matchLen-- {
+			dst[di] = dst[ref]
+			di++
+			ref++
+		}
+	}
+	return di, ErrCorrupt // empty input or missing last sequence
+}
@@ -0,0 +1,75 @@
+package lz4
+
+import (
+	"bytes"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func testRoundTrip(t *testing.T, src []byte) {
+	compressed := Compress(nil, src)
+	if len(compressed) > CompressBound(len(src)) {
+		t.Fatalf("compressed size %d exceeds bound %d", len(compressed), CompressBound(len(src)))
+	}
+	dst := make([]byte, len(src))
+	n, err := Decompress(dst, compressed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != len(src) || !bytes.Equal(dst, src) {
+		t.Fatalf("round trip mismatch: size %d expected %d", n, len(src))
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	random := make([]byte, 100000)
+	rand.New(rand.NewSource(1)).Read(random)
+
+	testData := map[string][]byte{
+		"empty":      {},
+		"short":      []byte("abc"),
+		"mfLimit":    []byte("aaaaaaaaaaaaa"),
+		"repetitive": []byte(strings.Repeat("go-hdb ", 10000)),
+		"zeroes":     make([]byte, 70000), // offsets beyond the maximum window
+		"random":     random,
+	}
+
+	for name, src := range testData {
+		t.Run(name, func(t *testing.T) { testRoundTrip(t, src) })
+	}
+
+	if compressed := Compress(nil, testData["repetitive"]); len(compressed) >= len(testData["repetitive"])/10 {
+		t.Fatalf("repetitive data compressed to %d bytes - expected less than 10%%", len(compressed))
+	}
+}
+
+func TestDecompress(t *testing.T) {
+	// literal 'a', match offset 1 length 5, last literals 'aaaaa'
+	src := []byte{0x11, 'a', 0x01, 0x00, 0x50, 'a', 'a', 'a', 'a', 'a'}
+	dst := make([]byte, 11)
+	n, err := Decompress(dst, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := string(dst[:n]); s != strings.Repeat("a", 11) {
+		t.Fatalf("decompressed %q", s)
+	}
+}
+
+func TestDecompressCorrupt(t *testing.T) {
+	testData := [][]byte{
+		{},
+		{0xf0},                  // missing literal length
+		{0x20, 'a'},             // literals exceed input
+		{0x10, 'a', 0x02, 0x00}, // offset exceeds output
+		{0x10, 'a', 0x00, 0x00}, // zero offset
+		{0x1f, 'a', 0x01, 0x00}, // missing match length
+		{0x10, 'a', 0x01},       // incomplete offset
+	}
+	for i, src := range testData {
+		if _, err := Decompress(make([]byte, 64), src); err != ErrCorrupt {
+			t.Fatalf("test %d: error %v - expected %v", i, err, ErrCorrupt)
+		}
+	}
+}
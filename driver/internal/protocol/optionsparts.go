@@ -2,6 +2,7 @@ package protocol
 
 import (
 	"fmt"
+	"math"
 	"slices"
 
 	"github.com/SAP/go-hdb/driver/internal/protocol/encoding"
@@ -130,6 +131,31 @@ type ConnectOptions struct {
 	options[connectOption]
 }
 
+// ConnectionIDOrZero returns the connection id option if available, the zero value otherwise.
+func (co *ConnectOptions) ConnectionIDOrZero() int {
+	var v int32
+	co.options.get(coConnectionID, &v)
+	return int(v)
+}
+
+// SupportsLargeBulkOperationsOrZero returns the supports large bulk operations option if available, the zero value otherwise.
+func (co *ConnectOptions) SupportsLargeBulkOperationsOrZero() bool {
+	var v bool
+	co.options.get(coSupportsLargeBulkOperations, &v)
+	return v
+}
+
+/*
+MaxFetchSize returns the maximum number of rows the server returns in one resultset reply: without
+large bulk operation support the number of rows is limited to the 16-bit argument count of a part.
+*/
+func (co *ConnectOptions) MaxFetchSize() int {
+	if co != nil && co.SupportsLargeBulkOperationsOrZero() {
+		return math.MaxInt32
+	}
+	return math.MaxInt16
+}
+
 // DataFormatVersion2OrZero returns the data format version2 option if available, the zero value otherwise.
 func (co *ConnectOptions) DataFormatVersion2OrZero() int {
 	var v int32
@@ -166,6 +192,18 @@ func (co *ConnectOptions) FullVersionOrZero() string {
 	return v
 }
 
+// CompressionLevelAndFlagsOrZero returns the compression level and flags option if available, the zero value otherwise.
+func (co *ConnectOptions) CompressionLevelAndFlagsOrZero() int {
+	var v int32
+	co.options.get(coCompressionLevelAndFlags, &v)
+	return int(v)
+}
+
+// SetCompressionLevelAndFlags sets the compression level and flags option.
+func (co *ConnectOptions) SetCompressionLevelAndFlags(v int) {
+	co.options.set(coCompressionLevelAndFlags, int32(v))
+}
+
 // SetClientLocale sets the client locale option.
 func (co *ConnectOptions) SetClientLocale(v string) { co.options.set(coClientLocale, v) }
 
@@ -205,6 +243,46 @@ func (ci *DBConnectInfo) IsConnectedOrZero() bool {
 	return v
 }
 
+// commandInfoOption represents a command info option.
+type commandInfoOption int8
+
+func (k commandInfoOption) valueString(v any) string {
+	return fmt.Sprintf("%s: %v", k, v)
+}
+
+// CommandInfoOption constants.
+const (
+	cmiLineNumber   commandInfoOption = 1 // int4
+	cmiSourceModule commandInfoOption = 2 // string
+)
+
+// CommandInfo represents a command info part.
+// The command info tags a statement with the source module and line number
+// of the issuing application code which is shown in database server monitoring views.
+type CommandInfo struct {
+	options[commandInfoOption]
+}
+
+// SetLineNumber sets the line number option.
+func (ci *CommandInfo) SetLineNumber(v int) { ci.options.set(cmiLineNumber, int32(v)) }
+
+// SetSourceModule sets the source module option.
+func (ci *CommandInfo) SetSourceModule(v string) { ci.options.set(cmiSourceModule, v) }
+
+// LineNumberOrZero returns the line number option, the zero value otherwise.
+func (ci *CommandInfo) LineNumberOrZero() int {
+	var v int32
+	ci.options.get(cmiLineNumber, &v)
+	return int(v)
+}
+
+// SourceModuleOrZero returns the source module option, the zero value otherwise.
+func (ci *CommandInfo) SourceModuleOrZero() string {
+	var v string
+	ci.options.get(cmiSourceModule, &v)
+	return v
+}
+
 type statementContextType int8
 
 func (k statementContextType) valueString(v any) string {
@@ -316,6 +394,46 @@ func (ti *TopologyInformation) decodeNumArg(dec *encoding.Decoder, numArg int) e
 	return dec.Error()
 }
 
+// TopologyHost represents the topology information of a database host.
+type TopologyHost struct {
+	HostName         string
+	Port             int
+	VolumeID         int
+	ServiceType      ServiceType
+	IsPrimary        bool
+	IsCurrentSession bool
+	IsStandby        bool
+}
+
+// Hosts returns the topology information of all hosts.
+func (ti *TopologyInformation) Hosts() []TopologyHost {
+	hosts := make([]TopologyHost, len(ti.hosts))
+	for i, host := range ti.hosts {
+		var port, volumeID, serviceType int32
+		host.get(toHostName, &hosts[i].HostName)
+		host.get(toHostPortnumber, &port)
+		host.get(toVolumeID, &volumeID)
+		host.get(toServiceType, &serviceType)
+		host.get(toIsPrimary, &hosts[i].IsPrimary)
+		host.get(toIsCurrentSession, &hosts[i].IsCurrentSession)
+		host.get(toIsStandby, &hosts[i].IsStandby)
+		hosts[i].Port, hosts[i].VolumeID, hosts[i].ServiceType = int(port), int(volumeID), ServiceType(serviceType)
+	}
+	return hosts
+}
+
+// IsStandby returns true if the host of the current session is a standby host, false otherwise.
+func (ti *TopologyInformation) IsStandby() bool {
+	for _, host := range ti.hosts {
+		var isCurrentSession, isStandby bool
+		if host.get(toIsCurrentSession, &isCurrentSession); isCurrentSession {
+			host.get(toIsStandby, &isStandby)
+			return isStandby
+		}
+	}
+	return false
+}
+
 type optionsType interface {
 	~int8
 	valueString(v any) string
@@ -0,0 +1,95 @@
+package protocol
+
+import (
+	"bytes"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/SAP/go-hdb/driver/internal/protocol/encoding"
+	"github.com/SAP/go-hdb/driver/unicode/cesu8"
+)
+
+func TestTopologyInformationStandby(t *testing.T) {
+	// topology information of a connect reply: hosts of a system replication setup.
+	topology := func(currentIsStandby bool) []options[topologyOption] {
+		return []options[topologyOption]{
+			{toHostName: "primary", toHostPortnumber: int32(30015), toIsPrimary: true, toIsStandby: false, toIsCurrentSession: !currentIsStandby},
+			{toHostName: "standby", toHostPortnumber: int32(30015), toIsPrimary: false, toIsStandby: true, toIsCurrentSession: currentIsStandby},
+		}
+	}
+
+	for _, isStandby := range []bool{false, true} {
+		buf := &bytes.Buffer{}
+		enc := encoding.NewEncoder(buf, cesu8.DefaultEncoder)
+		hosts := topology(isStandby)
+		for _, host := range hosts {
+			enc.Int16(int16(host.numArg()))
+			if err := host.encode(enc); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		ti := &TopologyInformation{}
+		dec := encoding.NewDecoder(buf, cesu8.DefaultDecoder)
+		if err := ti.decodeNumArg(dec, len(hosts)); err != nil {
+			t.Fatal(err)
+		}
+		if ti.IsStandby() != isStandby {
+			t.Fatalf("standby %t - expected %t", ti.IsStandby(), isStandby)
+		}
+
+		expected := []TopologyHost{
+			{HostName: "primary", Port: 30015, IsPrimary: true, IsCurrentSession: !isStandby},
+			{HostName: "standby", Port: 30015, IsStandby: true, IsCurrentSession: isStandby},
+		}
+		if !slices.Equal(ti.Hosts(), expected) {
+			t.Fatalf("hosts %v - expected %v", ti.Hosts(), expected)
+		}
+	}
+}
+
+func TestCommandInfo(t *testing.T) {
+	const sourceModule, lineNumber = "github.com/SAP/go-hdb/driver/example.go", 42
+
+	ci := &CommandInfo{}
+	ci.SetSourceModule(sourceModule)
+	ci.SetLineNumber(lineNumber)
+
+	buf := &bytes.Buffer{}
+	enc := encoding.NewEncoder(buf, cesu8.DefaultEncoder)
+	if err := ci.encode(enc); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != ci.size() {
+		t.Fatalf("encoded size %d - expected %d", buf.Len(), ci.size())
+	}
+
+	decoded := &CommandInfo{}
+	dec := encoding.NewDecoder(buf, cesu8.DefaultDecoder)
+	if err := decoded.decodeNumArg(dec, ci.numArg()); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.SourceModuleOrZero() != sourceModule {
+		t.Fatalf("source module %s - expected %s", decoded.SourceModuleOrZero(), sourceModule)
+	}
+	if decoded.LineNumberOrZero() != lineNumber {
+		t.Fatalf("line number %d - expected %d", decoded.LineNumberOrZero(), lineNumber)
+	}
+}
+
+func TestMaxFetchSize(t *testing.T) {
+	var nilOptions *ConnectOptions
+	if size := nilOptions.MaxFetchSize(); size != math.MaxInt16 {
+		t.Fatalf("max fetch size %d - expected %d", size, math.MaxInt16)
+	}
+
+	co := &ConnectOptions{}
+	if size := co.MaxFetchSize(); size != math.MaxInt16 {
+		t.Fatalf("max fetch size %d - expected %d", size, math.MaxInt16)
+	}
+	co.options.set(coSupportsLargeBulkOperations, true)
+	if size := co.MaxFetchSize(); size != math.MaxInt32 {
+		t.Fatalf("max fetch size %d - expected %d", size, math.MaxInt32)
+	}
+}
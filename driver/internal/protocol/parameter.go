@@ -3,6 +3,7 @@ package protocol
 import (
 	"database/sql/driver"
 	"fmt"
+	"math"
 	"reflect"
 
 	"github.com/SAP/go-hdb/driver/internal/protocol/encoding"
@@ -123,6 +124,9 @@ func (f *ParameterField) Convert(t transform.Transformer, v any) (any, error) {
 // see https://golang.org/pkg/database/sql/driver/#RowsColumnTypeDatabaseTypeName
 func (f *ParameterField) TypeName() string { return f.tc.typeName() }
 
+// TypeCode returns the database type code of the field.
+func (f *ParameterField) TypeCode() byte { return byte(f.tc) }
+
 // ScanType returns the scan type of the field.
 // see https://golang.org/pkg/database/sql/driver/#RowsColumnTypeScanType
 func (f *ParameterField) ScanType() reflect.Type { return f.tc.dataType().ScanType(f.Nullable()) }
@@ -240,8 +244,23 @@ type InputParameters struct {
 	nvargs      []driver.NamedValue
 }
 
-// NewInputParameters returns a InputParameters instance.
+/*
+NewInputParameters returns a InputParameters instance.
+
+The number of arguments needs to be a multiple of the number of input fields, so that the part header
+number of arguments (rows) is consistent with the encoded buffer. Rows with NULL values only are valid,
+whereas an empty batch (input fields without any arguments) cannot be encoded and results in an error.
+*/
 func NewInputParameters(inputFields []*ParameterField, nvargs []driver.NamedValue) (*InputParameters, error) {
+	numColumns, numArg := len(inputFields), len(nvargs)
+	switch {
+	case numColumns == 0 && numArg != 0:
+		return nil, fmt.Errorf("invalid number of arguments %d - no input parameters expected", numArg)
+	case numColumns != 0 && numArg == 0:
+		return nil, fmt.Errorf("invalid number of arguments %d - at least one row of %d input parameters expected", numArg, numColumns)
+	case numColumns != 0 && numArg%numColumns != 0:
+		return nil, fmt.Errorf("invalid number of arguments %d - multiple of %d expected", numArg, numColumns)
+	}
 	return &InputParameters{InputFields: inputFields, nvargs: nvargs}, nil
 }
 
@@ -249,6 +268,22 @@ func (p *InputParameters) String() string {
 	return fmt.Sprintf("fields %s len(args) %d args %v", p.InputFields, len(p.nvargs), p.nvargs)
 }
 
+func (p *InputParameters) fieldValues() []driver.Value {
+	values := make([]driver.Value, len(p.nvargs))
+	for i, nv := range p.nvargs {
+		values[i] = nv.Value
+	}
+	return values
+}
+
+func (p *InputParameters) redactedString() string {
+	args := make([]string, len(p.nvargs))
+	for i, nv := range p.nvargs {
+		args[i] = redactValue(nv.Value)
+	}
+	return fmt.Sprintf("fields %s len(args) %d args %v", p.InputFields, len(p.nvargs), args)
+}
+
 func (p *InputParameters) size() int {
 	size := 0
 	numColumns := len(p.InputFields)
@@ -282,6 +317,22 @@ func (p *InputParameters) size() int {
 	return size
 }
 
+// MaxInputParametersSize is the maximum size of the input parameters of a request
+// (maximum segment size reduced by a reserve for headers and further parts).
+const MaxInputParametersSize = math.MaxInt32 - 1<<10
+
+// RowSize returns the size of the input parameters of a row including the first chunks of lob parameters.
+func RowSize(inputFields []*ParameterField, row []driver.NamedValue) int {
+	size := len(inputFields)
+	for i, f := range inputFields {
+		size += f.prmSize(row[i].Value)
+		if lobInDescr, ok := row[i].Value.(*LobInDescr); ok {
+			size += lobInDescr.size()
+		}
+	}
+	return size
+}
+
 func (p *InputParameters) numArg() int {
 	numColumns := len(p.InputFields)
 	if numColumns == 0 { // avoid divide-by-zero (e.g. prepare without parameters)
@@ -338,6 +389,16 @@ func (p *OutputParameters) String() string {
 	return fmt.Sprintf("fields %v values %v", p.OutputFields, p.FieldValues)
 }
 
+func (p *OutputParameters) fieldValues() []driver.Value { return p.FieldValues }
+
+func (p *OutputParameters) redactedString() string {
+	values := make([]string, len(p.FieldValues))
+	for i, v := range p.FieldValues {
+		values[i] = redactValue(v)
+	}
+	return fmt.Sprintf("fields %v values %v", p.OutputFields, values)
+}
+
 func (p *OutputParameters) decodeNumArg(dec *encoding.Decoder, numArg int) error {
 	cols := len(p.OutputFields)
 	p.FieldValues = resizeSlice(p.FieldValues, numArg*cols)
@@ -0,0 +1,103 @@
+package protocol
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/SAP/go-hdb/driver/internal/protocol/encoding"
+	"github.com/SAP/go-hdb/driver/unicode/cesu8"
+)
+
+func TestInputParametersAllNull(t *testing.T) {
+	ftc := NewFieldTypeCtx(DfvLevel8, false, false)
+
+	tcs := []typeCode{tcBoolean, tcInteger, tcDouble, tcDecimal, tcVarchar, tcLongdate, tcBlob, tcNclob}
+	inputFields := make([]*ParameterField, len(tcs))
+	nvargs := make([]driver.NamedValue, len(tcs))
+	for i, tc := range tcs {
+		inputFields[i] = &ParameterField{names: &fieldNames{}, tc: tc, ft: ftc.fieldType(tc, 0, 0), mode: pmIn}
+		nvargs[i] = driver.NamedValue{Ordinal: i + 1}
+	}
+
+	prms, err := NewInputParameters(inputFields, nvargs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if numArg := prms.numArg(); numArg != 1 {
+		t.Fatalf("number of arguments %d - expected %d", numArg, 1)
+	}
+
+	buf := &bytes.Buffer{}
+	enc := encoding.NewEncoder(buf, cesu8.DefaultEncoder)
+	if err := prms.encode(enc); err != nil {
+		t.Fatal(err)
+	}
+	if size := prms.size(); size != buf.Len() {
+		t.Fatalf("buffer length %d - expected %d", buf.Len(), size)
+	}
+	// one null type code per field (boolean: type code and null value)
+	if buf.Len() != len(tcs)+encoding.BooleanFieldSize {
+		t.Fatalf("buffer length %d - expected %d", buf.Len(), len(tcs)+encoding.BooleanFieldSize)
+	}
+}
+
+func TestInputParametersNumArg(t *testing.T) {
+	ftc := NewFieldTypeCtx(DfvLevel8, false, false)
+	inputFields := []*ParameterField{
+		{names: &fieldNames{}, tc: tcInteger, ft: ftc.fieldType(tcInteger, 0, 0), mode: pmIn},
+		{names: &fieldNames{}, tc: tcVarchar, ft: ftc.fieldType(tcVarchar, 0, 0), mode: pmIn},
+	}
+
+	testData := []struct {
+		inputFields []*ParameterField
+		numArg      int
+		valid       bool
+	}{
+		{nil, 0, true},          // statement without parameters
+		{nil, 1, false},         // arguments without parameters
+		{inputFields, 0, false}, // empty batch
+		{inputFields, 3, false}, // incomplete row
+		{inputFields, 4, true},  // two rows
+	}
+
+	for i, d := range testData {
+		prms, err := NewInputParameters(d.inputFields, make([]driver.NamedValue, d.numArg))
+		if (err == nil) != d.valid {
+			t.Fatalf("%d: error %v - expected valid %t", i, err, d.valid)
+		}
+		if err != nil {
+			continue
+		}
+		buf := &bytes.Buffer{}
+		if err := prms.encode(encoding.NewEncoder(buf, cesu8.DefaultEncoder)); err != nil {
+			t.Fatal(err)
+		}
+		if size := prms.size(); size != buf.Len() {
+			t.Fatalf("%d: buffer length %d - expected %d", i, buf.Len(), size)
+		}
+		if len(d.inputFields) != 0 && prms.numArg()*len(d.inputFields) != d.numArg {
+			t.Fatalf("%d: number of rows %d - expected %d", i, prms.numArg(), d.numArg/len(d.inputFields))
+		}
+	}
+}
+
+func TestRowSize(t *testing.T) {
+	ftc := NewFieldTypeCtx(DfvLevel8, false, false)
+	inputFields := []*ParameterField{
+		{names: &fieldNames{}, tc: tcInteger, ft: ftc.fieldType(tcInteger, 0, 0), mode: pmIn},
+		{names: &fieldNames{}, tc: tcVarchar, ft: ftc.fieldType(tcVarchar, 0, 0), mode: pmIn},
+	}
+	nvargs := []driver.NamedValue{
+		{Ordinal: 1, Value: int64(1)}, {Ordinal: 2, Value: "a"},
+		{Ordinal: 1, Value: nil}, {Ordinal: 2, Value: "some longer text"},
+	}
+	prms, err := NewInputParameters(inputFields, nvargs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	size := RowSize(inputFields, nvargs[:2]) + RowSize(inputFields, nvargs[2:])
+	if size != prms.size() {
+		t.Fatalf("sum of row sizes %d - expected %d", size, prms.size())
+	}
+}
@@ -19,7 +19,7 @@ const (
 	PkReadLobReply              PartKind = 18
 	pkAbapIStream               PartKind = 25
 	pkAbapOStream               PartKind = 26
-	pkCommandInfo               PartKind = 27
+	PkCommandInfo               PartKind = 27
 	PkWriteLobRequest           PartKind = 28
 	PkClientContext             PartKind = 29
 	PkWriteLobReply             PartKind = 30
@@ -61,3 +61,9 @@ const (
 	pkWorkLoadReplayContext     PartKind = 72
 	pkSQLReplyOptions           PartKind = 73
 )
+
+// known returns true if the part kind is known to the driver, false otherwise.
+func (k PartKind) known() bool {
+	_, ok := _PartKind_map[k] // stringer map contains all defined part kinds
+	return ok
+}
@@ -26,6 +26,10 @@ type bufLenPart interface {
 	Part
 	decodeBufLen(dec *encoding.Decoder, bufLen int) error
 }
+type numArgBufLenPart interface {
+	Part
+	decodeNumArgBufLen(dec *encoding.Decoder, numArg, bufLen int) error
+}
 
 // writablePart represents a protocol part the driver is able to write.
 type writablePart interface {
@@ -52,6 +56,7 @@ func (*OutputParameters) kind() PartKind    { return PkOutputParameters }
 func (*ResultMetadata) kind() PartKind      { return PkResultMetadata }
 func (ResultsetID) kind() PartKind          { return PkResultsetID }
 func (*Resultset) kind() PartKind           { return PkResultset }
+func (*ResultsetStream) kind() PartKind     { return PkResultset }
 func (Fetchsize) kind() PartKind            { return PkFetchSize }
 func (*ReadLobRequest) kind() PartKind      { return PkReadLobRequest }
 func (*ReadLobReply) kind() PartKind        { return PkReadLobReply }
@@ -60,6 +65,7 @@ func (*WriteLobReply) kind() PartKind       { return PkWriteLobReply }
 func (*ClientContext) kind() PartKind       { return PkClientContext }
 func (*ConnectOptions) kind() PartKind      { return PkConnectOptions }
 func (*DBConnectInfo) kind() PartKind       { return PkDBConnectInfo }
+func (*CommandInfo) kind() PartKind         { return PkCommandInfo }
 func (*statementContext) kind() PartKind    { return PkStatementContext }
 func (*transactionFlags) kind() PartKind    { return PkTransactionFlags }
 
@@ -104,37 +110,40 @@ var (
 	_ writablePart = (*ClientContext)(nil)
 	_ writablePart = (*ConnectOptions)(nil)
 	_ writablePart = (*DBConnectInfo)(nil)
+	_ writablePart = (*CommandInfo)(nil)
 )
 
 // check if part types implement the right part interface.
 var (
-	_ numArgPart = (*HdbErrors)(nil)
-	_ defPart    = (*AuthInitRequest)(nil)
-	_ defPart    = (*AuthInitReply)(nil)
-	_ defPart    = (*AuthFinalRequest)(nil)
-	_ defPart    = (*AuthFinalReply)(nil)
-	_ bufLenPart = (*ClientID)(nil)
-	_ numArgPart = (*clientInfo)(nil)
-	_ numArgPart = (*TopologyInformation)(nil)
-	_ bufLenPart = (*Command)(nil)
-	_ numArgPart = (*RowsAffected)(nil)
-	_ defPart    = (*StatementID)(nil)
-	_ numArgPart = (*ParameterMetadata)(nil)
-	_ numArgPart = (*InputParameters)(nil)
-	_ numArgPart = (*OutputParameters)(nil)
-	_ numArgPart = (*ResultMetadata)(nil)
-	_ defPart    = (*ResultsetID)(nil)
-	_ numArgPart = (*Resultset)(nil)
-	_ defPart    = (*Fetchsize)(nil)
-	_ defPart    = (*ReadLobRequest)(nil)
-	_ numArgPart = (*WriteLobRequest)(nil)
-	_ numArgPart = (*ReadLobReply)(nil)
-	_ numArgPart = (*WriteLobReply)(nil)
-	_ numArgPart = (*ClientContext)(nil)
-	_ numArgPart = (*ConnectOptions)(nil)
-	_ numArgPart = (*DBConnectInfo)(nil)
-	_ numArgPart = (*statementContext)(nil)
-	_ numArgPart = (*transactionFlags)(nil)
+	_ numArgPart       = (*HdbErrors)(nil)
+	_ defPart          = (*AuthInitRequest)(nil)
+	_ bufLenPart       = (*AuthInitReply)(nil)
+	_ defPart          = (*AuthFinalRequest)(nil)
+	_ bufLenPart       = (*AuthFinalReply)(nil)
+	_ bufLenPart       = (*ClientID)(nil)
+	_ numArgPart       = (*clientInfo)(nil)
+	_ numArgPart       = (*TopologyInformation)(nil)
+	_ bufLenPart       = (*Command)(nil)
+	_ numArgPart       = (*RowsAffected)(nil)
+	_ defPart          = (*StatementID)(nil)
+	_ numArgPart       = (*ParameterMetadata)(nil)
+	_ numArgPart       = (*InputParameters)(nil)
+	_ numArgPart       = (*OutputParameters)(nil)
+	_ numArgPart       = (*ResultMetadata)(nil)
+	_ defPart          = (*ResultsetID)(nil)
+	_ numArgPart       = (*Resultset)(nil)
+	_ numArgBufLenPart = (*ResultsetStream)(nil)
+	_ defPart          = (*Fetchsize)(nil)
+	_ defPart          = (*ReadLobRequest)(nil)
+	_ numArgPart       = (*WriteLobRequest)(nil)
+	_ numArgPart       = (*ReadLobReply)(nil)
+	_ numArgPart       = (*WriteLobReply)(nil)
+	_ numArgPart       = (*ClientContext)(nil)
+	_ numArgPart       = (*ConnectOptions)(nil)
+	_ numArgPart       = (*DBConnectInfo)(nil)
+	_ numArgPart       = (*CommandInfo)(nil)
+	_ numArgPart       = (*statementContext)(nil)
+	_ numArgPart       = (*transactionFlags)(nil)
 )
 
 var genPartTypeMap = map[PartKind]reflect.Type{
@@ -156,6 +165,7 @@ var genPartTypeMap = map[PartKind]reflect.Type{
 	PkTransactionFlags:    hdbreflect.TypeFor[transactionFlags](),
 	PkStatementContext:    hdbreflect.TypeFor[statementContext](),
 	PkDBConnectInfo:       hdbreflect.TypeFor[DBConnectInfo](),
+	PkCommandInfo:         hdbreflect.TypeFor[CommandInfo](),
 	/*
 	   parts that cannot be used generically as additional parameters are needed
 
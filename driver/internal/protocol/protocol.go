@@ -2,15 +2,19 @@ package protocol
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"database/sql/driver"
 	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"maps"
 	"math"
+	"sync/atomic"
 
 	"github.com/SAP/go-hdb/driver/internal/protocol/encoding"
+	"github.com/SAP/go-hdb/driver/internal/protocol/lz4"
 	"golang.org/x/text/transform"
 )
 
@@ -38,6 +42,115 @@ func padBytes(size int) int {
 	return 0
 }
 
+// redactablePart is implemented by parts which might contain sensitive data like credentials or parameter values.
+type redactablePart interface {
+	redactedString() string // string representation with masked values
+}
+
+// redactValue returns a masked representation of a value keeping the type and the length for debugging.
+func redactValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "<nil>"
+	case []byte:
+		return fmt.Sprintf("***(%T %d)", v, len(v))
+	case string:
+		return fmt.Sprintf("***(%T %d)", v, len(v))
+	default:
+		return fmt.Sprintf("***(%T)", v)
+	}
+}
+
+// valuesPart is implemented by parts carrying field values.
+type valuesPart interface {
+	fieldValues() []driver.Value
+}
+
+// TraceMode represents the format of protocol trace records.
+type TraceMode int
+
+// TraceMode constants.
+const (
+	TraceText             TraceMode = iota // parts are traced as single string value
+	TraceStructured                        // parts are traced as structured attributes excluding part content like field values
+	TraceStructuredValues                  // parts are traced as structured attributes including part content like field values
+)
+
+/*
+tracer writes protocol trace records either line by line to a writer or, if no writer is set, to a logger.
+In structured trace modes records are written in JSON format to the writer or as structured attributes to the logger.
+*/
+type tracer struct {
+	logger  *slog.Logger
+	wr      io.Writer
+	redact  bool
+	mode    TraceMode
+	slogger *slog.Logger // logger used in structured trace modes
+}
+
+func (t *tracer) setMode(mode TraceMode) {
+	t.mode = mode
+	t.slogger = t.logger
+	if t.wr != nil {
+		t.slogger = slog.New(slog.NewJSONHandler(t.wr, nil))
+	}
+}
+
+// logPart traces a part. The values of redactable parts are masked in redact mode.
+func (t *tracer) logPart(ctx context.Context, key string, ph *partHeader, part Part) {
+	if t.mode != TraceText {
+		t.logPartAttrs(ctx, key, ph, part)
+		return
+	}
+	if part, ok := part.(redactablePart); ok && t.redact {
+		t.log(ctx, key, part.redactedString())
+		return
+	}
+	t.log(ctx, key, part.String())
+}
+
+// logPartAttrs traces a part as structured attributes.
+func (t *tracer) logPartAttrs(ctx context.Context, key string, ph *partHeader, part Part) {
+	attrs := []slog.Attr{
+		slog.String("trace", key),
+		slog.String("kind", part.kind().String()),
+		slog.Int("numArg", ph.numArg()),
+		slog.Int("bufferLength", ph.bufLen()),
+	}
+	if t.mode == TraceStructuredValues {
+		switch vp, ok := part.(valuesPart); {
+		case ok && t.redact:
+			values := vp.fieldValues()
+			redacted := make([]string, len(values))
+			for i, v := range values {
+				redacted[i] = redactValue(v)
+			}
+			attrs = append(attrs, slog.Any("fields", redacted))
+		case ok:
+			attrs = append(attrs, slog.Any("fields", vp.fieldValues()))
+		default:
+			if rp, ok := part.(redactablePart); ok && t.redact {
+				attrs = append(attrs, slog.String("value", rp.redactedString()))
+			} else {
+				attrs = append(attrs, slog.String("value", part.String()))
+			}
+		}
+	}
+	t.slogger.LogAttrs(ctx, slog.LevelInfo, traceMsg, attrs...)
+}
+
+func (t *tracer) log(ctx context.Context, key, value string) {
+	if t.mode != TraceText {
+		t.slogger.LogAttrs(ctx, slog.LevelInfo, traceMsg, slog.String(key, value))
+		return
+	}
+	if t.wr != nil {
+		fmt.Fprintf(t.wr, "%s %s\n", key, value)
+		return
+	}
+	t.logger.LogAttrs(ctx, slog.LevelInfo, traceMsg, slog.String(key, value))
+}
+
 type partCache map[PartKind]Part
 
 func (c *partCache) get(kind PartKind) (Part, bool) {
@@ -56,24 +169,58 @@ func (c *partCache) get(kind PartKind) (Part, bool) {
 type Reader struct {
 	// ReadProlog reads the protocol prolog.
 	ReadProlog func(ctx context.Context) error
+	// MessageHook, if set, is called with the uncompressed size of every message read.
+	MessageHook func(size int)
+	// ReplyHook, if set, is called after a reply message got processed (read and decoded).
+	ReplyHook func()
+	// OnUnknownPart, if set, is called with the raw part buffer of parts which are neither
+	// requested by the caller nor can be decoded generically (e.g. part kinds unknown to the driver)
+	// before they are discarded. The raw buffer is owned by the callback.
+	OnUnknownPart func(kind PartKind, raw []byte)
+	// LogUnknownParts, if set, logs a warning for every part of a part kind unknown to the driver
+	// (e.g. parts introduced by newer database server versions).
+	LogUnknownParts bool
+	// ErrorPolicy defines the errors returned in case a reply contains more than one error.
+	ErrorPolicy ErrorPolicy
+	// OnWarnings, if set, is called with the warnings of every reply containing warnings.
+	OnWarnings func(warnings []*HdbError)
+	// OnRawColumn, if set, is called for every resultset row with the raw (undecoded) bytes
+	// of the field of column index RawColumn (diagnostics).
+	OnRawColumn func(raw []byte)
+	// RawColumn is the column index of the fields passed to OnRawColumn.
+	RawColumn int
 
 	protTrace bool
 	prefix    string
 	logger    *slog.Logger
+	trace     *tracer
+
+	decoder func() transform.Transformer
+	dec     *encoding.Decoder
 
-	dec *encoding.Decoder
+	// decompression
+	cbuf []byte            // compressed variable part
+	ubuf []byte            // uncompressed variable part
+	urd  *bytes.Reader     // reader of uncompressed variable part
+	udec *encoding.Decoder // decoder of uncompressed variable part
 
 	mh *messageHeader
 	sh *segmentHeader
 	ph *partHeader
 
 	partCache partCache
+
+	// server versions sent in prolog
+	product  version
+	protocol version
 }
 
-func newReader(rd io.Reader, protTrace bool, logger *slog.Logger, decoder func() transform.Transformer) *Reader {
+func newReader(rd io.Reader, protTrace, redact bool, logger *slog.Logger, traceWr io.Writer, decoder func() transform.Transformer) *Reader {
 	return &Reader{
 		protTrace: protTrace,
 		logger:    logger,
+		trace:     &tracer{logger: logger, wr: traceWr, redact: redact},
+		decoder:   decoder,
 		dec:       encoding.NewDecoder(rd, decoder),
 		partCache: partCache{},
 		mh:        &messageHeader{},
@@ -83,16 +230,20 @@ func newReader(rd io.Reader, protTrace bool, logger *slog.Logger, decoder func()
 }
 
 // NewDBReader returns an instance of a database protocol reader.
-func NewDBReader(rd io.Reader, protTrace bool, logger *slog.Logger, decoder func() transform.Transformer) *Reader {
-	reader := newReader(rd, protTrace, logger, decoder)
+// If traceWr is not nil, protocol trace records are written to traceWr instead of logger.
+// In redact mode sensitive data like credentials and parameter values are masked in protocol traces.
+func NewDBReader(rd io.Reader, protTrace, redact bool, logger *slog.Logger, traceWr io.Writer, decoder func() transform.Transformer) *Reader {
+	reader := newReader(rd, protTrace, redact, logger, traceWr, decoder)
 	reader.ReadProlog = reader.readPrologDB
 	reader.prefix = prefixDB
 	return reader
 }
 
 // NewClientReader returns an instance of a client protocol reader.
-func NewClientReader(rd io.Reader, protTrace bool, logger *slog.Logger, decoder func() transform.Transformer) *Reader {
-	reader := newReader(rd, protTrace, logger, decoder)
+// If traceWr is not nil, protocol trace records are written to traceWr instead of logger.
+// In redact mode sensitive data like credentials and parameter values are masked in protocol traces.
+func NewClientReader(rd io.Reader, protTrace, redact bool, logger *slog.Logger, traceWr io.Writer, decoder func() transform.Transformer) *Reader {
+	reader := newReader(rd, protTrace, redact, logger, traceWr, decoder)
 	reader.ReadProlog = reader.readPrologClient
 	reader.prefix = prefixClient
 	return reader
@@ -101,19 +252,36 @@ func NewClientReader(rd io.Reader, protTrace bool, logger *slog.Logger, decoder
 // SkipParts reads and discards all protocol parts.
 func (r *Reader) SkipParts(ctx context.Context) error { return r.IterateParts(ctx, nil) }
 
+// SetTraceMode sets the format of protocol trace records (default: TraceText).
+func (r *Reader) SetTraceMode(mode TraceMode) { r.trace.setMode(mode) }
+
 // SessionID returns the session ID.
 func (r *Reader) SessionID() int64 { return r.mh.sessionID }
 
 // FunctionCode returns the function code of the protocol.
 func (r *Reader) FunctionCode() FunctionCode { return r.sh.functionCode }
 
+// VarPartLength returns the (uncompressed) variable part length of the last message read.
+func (r *Reader) VarPartLength() int { return int(r.mh.uncompressedVarPartLength()) }
+
+// ServerVersion returns the product version of the database server sent in the protocol prolog.
+func (r *Reader) ServerVersion() (major, minor int) {
+	return int(r.product.major), int(r.product.minor)
+}
+
+// ProtocolVersion returns the protocol version of the database server sent in the protocol prolog.
+func (r *Reader) ProtocolVersion() (major, minor int) {
+	return int(r.protocol.major), int(r.protocol.minor)
+}
+
 func (r *Reader) readPrologDB(ctx context.Context) error {
 	rep := &initReply{}
 	if err := rep.decode(r.dec); err != nil {
 		return err
 	}
+	r.product, r.protocol = rep.product, rep.protocol
 	if r.protTrace {
-		r.logger.LogAttrs(ctx, slog.LevelInfo, traceMsg, slog.String(r.prefix+textIni, rep.String()))
+		r.trace.log(ctx, r.prefix+textIni, rep.String())
 	}
 	return nil
 }
@@ -123,7 +291,7 @@ func (r *Reader) readPrologClient(ctx context.Context) error {
 		return err
 	}
 	if r.protTrace {
-		r.logger.LogAttrs(ctx, slog.LevelInfo, traceMsg, slog.String(r.prefix+textIni, req.String()))
+		r.trace.log(ctx, r.prefix+textIni, req.String())
 	}
 	return nil
 }
@@ -137,18 +305,46 @@ func (r *Reader) skipPadding() int {
 func (r *Reader) skipPaddingLastPart(numReadByte int64) {
 	// last part:
 	// skip difference between real read bytes and message header var part length
-	padBytes := int64(r.mh.varPartLength) - numReadByte
+	varPartLength := r.mh.uncompressedVarPartLength()
+	padBytes := int64(varPartLength) - numReadByte
 	switch {
 	case padBytes < 0:
-		panic(fmt.Errorf("protocol error: bytes read %d > variable part length %d", numReadByte, r.mh.varPartLength))
+		panic(fmt.Errorf("protocol error: bytes read %d > variable part length %d", numReadByte, varPartLength))
 	case padBytes > 0:
 		r.dec.Skip(int(padBytes))
 	}
 }
 
+// skipPart skips the current part or hands the raw part buffer over to OnUnknownPart
+// in case the part cannot be decoded generically.
+func (r *Reader) skipPart(ctx context.Context, kind PartKind) {
+	bufferLength := int(r.ph.bufferLength)
+	if r.LogUnknownParts && !kind.known() {
+		r.logger.LogAttrs(ctx, slog.LevelWarn, "unknown protocol part", slog.Int("kind", int(kind)), slog.Int("size", bufferLength))
+	}
+	if r.OnUnknownPart != nil {
+		if _, ok := r.partCache.get(kind); !ok {
+			raw := make([]byte, bufferLength)
+			r.dec.Bytes(raw)
+			if r.dec.Error() == nil {
+				r.OnUnknownPart(kind, raw)
+			}
+			return
+		}
+	}
+	r.dec.Skip(bufferLength)
+}
+
 func (r *Reader) readPart(ctx context.Context, part Part) error {
 	cntBefore := r.dec.Cnt()
 
+	switch rs := part.(type) {
+	case *Resultset:
+		rs.rawColumn, rs.onRawColumn = r.RawColumn, r.OnRawColumn
+	case *ResultsetStream:
+		rs.rawColumn, rs.onRawColumn = r.RawColumn, r.OnRawColumn
+	}
+
 	var err error
 	switch part := part.(type) {
 	// do not return here in case of error -> read stream would be broken
@@ -158,6 +354,8 @@ func (r *Reader) readPart(ctx context.Context, part Part) error {
 		err = part.decodeNumArg(r.dec, r.ph.numArg())
 	case bufLenPart:
 		err = part.decodeBufLen(r.dec, r.ph.bufLen())
+	case numArgBufLenPart:
+		err = part.decodeNumArgBufLen(r.dec, r.ph.numArg(), r.ph.bufLen())
 	default:
 		panic(fmt.Errorf("decoder function part %v not found", part))
 	}
@@ -165,7 +363,7 @@ func (r *Reader) readPart(ctx context.Context, part Part) error {
 	cnt := r.dec.Cnt() - cntBefore
 
 	if r.protTrace {
-		r.logger.LogAttrs(ctx, slog.LevelInfo, traceMsg, slog.String(r.prefix+textPar, part.String()))
+		r.trace.logPart(ctx, r.prefix+textPar, r.ph, part)
 	}
 
 	bufferLen := int(r.ph.bufferLength)
@@ -178,21 +376,83 @@ func (r *Reader) readPart(ctx context.Context, part Part) error {
 	return err
 }
 
+// decompress reads the compressed variable part of a message and
+// provides the decompressed data via the uncompressed variable part decoder.
+func (r *Reader) decompress() error {
+	r.cbuf = resizeSlice(r.cbuf, int(r.mh.varPartLength))
+	r.dec.Bytes(r.cbuf)
+	if err := r.dec.Error(); err != nil {
+		return err
+	}
+	r.ubuf = resizeSlice(r.ubuf, int(r.mh.compressionVarPartLength))
+	n, err := lz4.Decompress(r.ubuf, r.cbuf)
+	if err != nil {
+		return fmt.Errorf("protocol error: decompress message: %w", err)
+	}
+	if n != len(r.ubuf) {
+		return fmt.Errorf("protocol error: decompressed bytes %d != uncompressed variable part length %d", n, len(r.ubuf))
+	}
+	if r.udec == nil {
+		r.urd = bytes.NewReader(nil)
+		r.udec = encoding.NewDecoder(r.urd, r.decoder)
+	}
+	r.urd.Reset(r.ubuf)
+	return nil
+}
+
 // IterateParts iterates through all protocol parts.
 func (r *Reader) IterateParts(ctx context.Context, fn func(kind PartKind, attrs PartAttributes, read func(part Part))) error {
+	if fn == nil {
+		return r.iterateParts(ctx, nil, nil)
+	}
+	return r.iterateParts(ctx, func(segmentNo int, kind PartKind, attrs PartAttributes, read func(part Part)) {
+		fn(kind, attrs, read)
+	}, nil)
+}
+
+/*
+IterateSegments iterates through all protocol parts of a multi-segment reply like IterateParts.
+In addition fn is called with the segment number of the reply segment, which equals the number of the
+corresponding request segment (starting with 1), and database errors are not returned but reported per
+segment via errFn.
+*/
+func (r *Reader) IterateSegments(ctx context.Context, fn func(segmentNo int, kind PartKind, attrs PartAttributes, read func(part Part)), errFn func(segmentNo int, err error)) error {
+	return r.iterateParts(ctx, fn, errFn)
+}
+
+func (r *Reader) iterateParts(ctx context.Context, fn func(segmentNo int, kind PartKind, attrs PartAttributes, read func(part Part)), errFn func(segmentNo int, err error)) error {
 	var lastErrors *HdbErrors
 	var lastRowsAffected *RowsAffected
 
+	if r.ReplyHook != nil {
+		defer r.ReplyHook()
+	}
+
 	if err := r.mh.decode(r.dec); err != nil {
 		return err
 	}
 
 	var numReadByte int64 = 0 // header bytes are not calculated in header varPartBytes: start with zero
 	if r.protTrace {
-		r.logger.LogAttrs(ctx, slog.LevelInfo, traceMsg, slog.String(r.prefix+textMsgHdr, r.mh.String()))
+		r.trace.log(ctx, r.prefix+textMsgHdr, r.mh.String())
 	}
 
-	for i := 0; i < int(r.mh.noOfSegm); i++ {
+	if r.mh.compressed() {
+		if err := r.decompress(); err != nil {
+			return err
+		}
+		// read parts from decompressed variable part
+		dec := r.dec
+		r.dec = r.udec
+		defer func() { r.dec = dec }()
+	}
+
+	if r.MessageHook != nil {
+		r.MessageHook(messageHeaderSize + int(r.mh.uncompressedVarPartLength()))
+	}
+
+	lastSegment := int(r.mh.noOfSegm) - 1
+	for i := 0; i <= lastSegment; i++ {
 		if err := r.sh.decode(r.dec); err != nil {
 			return err
 		}
@@ -200,7 +460,7 @@ func (r *Reader) IterateParts(ctx context.Context, fn func(kind PartKind, attrs
 		numReadByte += segmentHeaderSize
 
 		if r.protTrace {
-			r.logger.LogAttrs(ctx, slog.LevelInfo, traceMsg, slog.String(r.prefix+textSegHdr, r.sh.String()))
+			r.trace.log(ctx, r.prefix+textSegHdr, r.sh.String())
 		}
 
 		lastPart := int(r.sh.noOfParts) - 1
@@ -213,7 +473,7 @@ func (r *Reader) IterateParts(ctx context.Context, fn func(kind PartKind, attrs
 			numReadByte += partHeaderSize
 
 			if r.protTrace {
-				r.logger.LogAttrs(ctx, slog.LevelInfo, traceMsg, slog.String(r.prefix+textParHdr, r.ph.String()))
+				r.trace.log(ctx, r.prefix+textParHdr, r.ph.String())
 			}
 
 			cntBefore := r.dec.Cnt()
@@ -221,7 +481,7 @@ func (r *Reader) IterateParts(ctx context.Context, fn func(kind PartKind, attrs
 			partRequested := false
 			if kind != PkError && fn != nil { // caller must not handle hdb errors
 				var err error
-				fn(kind, r.ph.partAttributes, func(part Part) {
+				fn(int(r.sh.segmentNo), kind, r.ph.partAttributes, func(part Part) {
 					partRequested = true
 					err = r.readPart(ctx, part)
 					if part.kind() == PkRowsAffected {
@@ -235,7 +495,7 @@ func (r *Reader) IterateParts(ctx context.Context, fn func(kind PartKind, attrs
 			if !partRequested {
 				// if trace is on or mandatory parts need to be read we cannot skip
 				if !(r.protTrace || kind == PkError || kind == PkRowsAffected) {
-					r.dec.Skip(int(r.ph.bufferLength))
+					r.skipPart(ctx, kind)
 				} else {
 					if part, ok := r.partCache.get(kind); ok {
 						if err := r.readPart(ctx, part); err != nil {
@@ -248,9 +508,9 @@ func (r *Reader) IterateParts(ctx context.Context, fn func(kind PartKind, attrs
 							lastRowsAffected = part.(*RowsAffected)
 						}
 					} else {
-						r.dec.Skip(int(r.ph.bufferLength))
+						r.skipPart(ctx, kind)
 						if r.protTrace {
-							r.logger.LogAttrs(ctx, slog.LevelInfo, traceMsg, slog.String(r.prefix+textSkip, kind.String()))
+							r.trace.log(ctx, r.prefix+textSkip, kind.String())
 						}
 					}
 				}
@@ -258,11 +518,21 @@ func (r *Reader) IterateParts(ctx context.Context, fn func(kind PartKind, attrs
 
 			numReadByte += int64(r.dec.Cnt()) - int64(cntBefore)
 
-			if j != lastPart { // not last part
+			if j != lastPart || i != lastSegment { // not last part of message
 				numReadByte += int64(r.skipPadding())
 			}
 
 		}
+
+		if errFn != nil { // report errors per segment
+			if lastErrors != nil {
+				errs := *lastErrors // copy: error part is reused by the next segment
+				if err := r.segmentErrors(ctx, &errs, lastRowsAffected); err != nil {
+					errFn(int(r.sh.segmentNo), err)
+				}
+			}
+			lastErrors, lastRowsAffected = nil, nil
+		}
 	}
 
 	r.skipPaddingLastPart(numReadByte)
@@ -275,35 +545,69 @@ func (r *Reader) IterateParts(ctx context.Context, fn func(kind PartKind, attrs
 	if lastErrors == nil {
 		return nil
 	}
+	return r.segmentErrors(ctx, lastErrors, lastRowsAffected)
+}
 
-	if lastRowsAffected != nil { // link statement to error
+// segmentErrors links the errors to the affected statements and returns the errors according to the error policy.
+// Warnings are reported to OnWarnings in any case, warnings only are logged and nil is returned.
+func (r *Reader) segmentErrors(ctx context.Context, errs *HdbErrors, rowsAffected *RowsAffected) error {
+	if rowsAffected != nil { // link statement to error
 		j := 0
-		for i, rows := range lastRowsAffected.rows {
+		for i, rows := range rowsAffected.rows {
 			if rows == RaExecutionFailed {
-				lastErrors.setStmtNo(j, lastRowsAffected.Ofs+i)
+				errs.setStmtNo(j, rowsAffected.Ofs+i)
 				j++
 			}
 		}
 	}
-	if lastErrors.onlyWarnings {
-		for _, err := range lastErrors.errs {
+	if errs.onlyWarnings {
+		for _, err := range errs.errs {
 			r.logger.LogAttrs(ctx, slog.LevelWarn, err.Error())
 		}
+		if r.OnWarnings != nil {
+			r.OnWarnings(errs.errs)
+		}
 		return nil
 	}
-	return lastErrors
+	if r.OnWarnings != nil {
+		if warnings := errs.warnings(); len(warnings) != 0 {
+			r.OnWarnings(warnings)
+		}
+	}
+	return errs.selectErrors(r.ErrorPolicy)
 }
 
+// ErrConcurrentUse is the error raised if a protocol writer is used concurrently (see Writer.CheckConcurrentUse).
+var ErrConcurrentUse = errors.New("concurrent use of connection detected")
+
 // Writer represents a protocol writer.
 type Writer struct {
+	// MessageHook, if set, is called with the uncompressed size of every message written.
+	MessageHook func(size int)
+	// BeforeWrite, if set, is called with the context and the message type of the first segment before a message is written.
+	BeforeWrite func(ctx context.Context, messageType MessageType)
+	// CheckConcurrentUse, if set, lets a write fail with ErrConcurrentUse while the round trip of another request
+	// is in progress instead of corrupting the reused headers or reading the reply of the other request.
+	// The round trip of a written request ends when its reply got processed (see ReplyDone).
+	CheckConcurrentUse bool
+	inUse              atomic.Bool
+
 	protTrace bool
-	logger    *slog.Logger
+	trace     *tracer
+
+	wr      *bufio.Writer
+	encoder func() transform.Transformer
+	enc     *encoding.Encoder
 
-	wr  *bufio.Writer
-	enc *encoding.Encoder
+	// compression
+	compression bool
+	ubuf        *bytes.Buffer     // uncompressed variable part
+	uenc        *encoding.Encoder // encoder of uncompressed variable part
+	cbuf        []byte            // compressed variable part
 
-	sv     map[string]string
-	svSent bool
+	sv      map[string]string
+	svSent  bool
+	svUnset []string // overridden session variables to be unset with the next client info
 
 	// reuse header
 	mh *messageHeader
@@ -312,12 +616,15 @@ type Writer struct {
 }
 
 // NewWriter returns an instance of a protocol writer.
-func NewWriter(wr *bufio.Writer, protTrace bool, logger *slog.Logger, encoder func() transform.Transformer, sv map[string]string) *Writer {
+// If traceWr is not nil, protocol trace records are written to traceWr instead of logger.
+// In redact mode sensitive data like credentials and parameter values are masked in protocol traces.
+func NewWriter(wr *bufio.Writer, protTrace, redact bool, logger *slog.Logger, traceWr io.Writer, encoder func() transform.Transformer, sv map[string]string) *Writer {
 	return &Writer{
 		protTrace: protTrace,
-		logger:    logger,
+		trace:     &tracer{logger: logger, wr: traceWr, redact: redact},
 		wr:        wr,
 		sv:        sv,
+		encoder:   encoder,
 		enc:       encoding.NewEncoder(wr, encoder),
 		mh:        new(messageHeader),
 		sh:        new(segmentHeader),
@@ -325,6 +632,77 @@ func NewWriter(wr *bufio.Writer, protTrace bool, logger *slog.Logger, encoder fu
 	}
 }
 
+// SetSessionVariable sets the session variable k to value v.
+// The session variables are sent as client info part with the next request supporting client info.
+func (w *Writer) SetSessionVariable(k, v string) {
+	sv := make(map[string]string, len(w.sv)+1) // copy on write: session variables might be shared with other writers
+	maps.Copy(sv, w.sv)
+	sv[k] = v
+	w.sv = sv
+	w.svSent = false
+}
+
+// ResetSessionVariables resets the session variables to sv.
+// Session variables not contained in sv are unset with the next request supporting client info.
+func (w *Writer) ResetSessionVariables(sv map[string]string) {
+	if maps.Equal(w.sv, sv) {
+		return
+	}
+	for k := range w.sv {
+		if _, ok := sv[k]; !ok {
+			w.svUnset = append(w.svUnset, k)
+		}
+	}
+	w.sv = sv
+	w.svSent = false
+}
+
+type sessionVariablesCtxKey struct{}
+
+// WithSessionVariables returns a copy of ctx with session variables sv overriding the writer session variables
+// for requests written with the returned context.
+// With the next request not overriding session variables the writer session variables are restored.
+func WithSessionVariables(ctx context.Context, sv map[string]string) context.Context {
+	return context.WithValue(ctx, sessionVariablesCtxKey{}, maps.Clone(sv))
+}
+
+func sessionVariablesFromContext(ctx context.Context) map[string]string {
+	if ctx == nil {
+		return nil
+	}
+	sv, _ := ctx.Value(sessionVariablesCtxKey{}).(map[string]string)
+	return sv
+}
+
+// clientInfo returns the client info part for the writer session variables merged with the overriding session variables ov.
+func (w *Writer) clientInfo(ov map[string]string) *clientInfo {
+	if ov == nil && w.svUnset == nil {
+		return (*clientInfo)(&w.sv)
+	}
+	ci := make(clientInfo, len(w.sv)+len(w.svUnset)+len(ov))
+	for _, k := range w.svUnset {
+		ci[k] = "" // unset previously overridden session variable
+	}
+	maps.Copy(ci, w.sv)
+	maps.Copy(ci, ov)
+	return &ci
+}
+
+// svSentWith updates the session variable state after client info was sent with overriding session variables ov.
+func (w *Writer) svSentWith(ov map[string]string) {
+	w.svUnset = nil
+	if ov == nil {
+		w.svSent = true
+		return
+	}
+	w.svSent = false // restore writer session variables with the next request
+	for k := range ov {
+		if _, ok := w.sv[k]; !ok {
+			w.svUnset = append(w.svUnset, k)
+		}
+	}
+}
+
 const (
 	productVersionMajor  = 4
 	productVersionMinor  = 20
@@ -345,102 +723,224 @@ func (w *Writer) WriteProlog(ctx context.Context) error {
 		return err
 	}
 	if w.protTrace {
-		w.logger.LogAttrs(ctx, slog.LevelInfo, traceMsg, slog.String(prefixClient+textIni, req.String()))
+		w.trace.log(ctx, prefixClient+textIni, req.String())
 	}
 	return w.wr.Flush()
 }
 
-func (w *Writer) _write(ctx context.Context, sessionID int64, messageType MessageType, commit bool, parts ...writablePart) error {
-	// check on session variables to be send as ClientInfo
-	if w.sv != nil && !w.svSent && messageType.ClientInfoSupported() {
-		parts = append([]writablePart{(*clientInfo)(&w.sv)}, parts...)
-		w.svSent = true
-	}
+// SetTraceMode sets the format of protocol trace records (default: TraceText).
+func (w *Writer) SetTraceMode(mode TraceMode) { w.trace.setMode(mode) }
 
-	numPart := len(parts)
-	partSize := make([]int, numPart)
-	size := int64(segmentHeaderSize + numPart*partHeaderSize) // int64 to hold MaxUInt32 in 32bit OS
+// compressionThreshold is the minimal variable part size of a message to be compressed.
+const compressionThreshold = 4096
 
-	for i, part := range parts {
-		s := part.size()
-		size += int64(s + padBytes(s))
-		partSize[i] = s // buffer size (expensive calculation)
+// SetCompression sets the lz4 compression of messages with a variable part size
+// greater or equal than compressionThreshold active or inactive.
+func (w *Writer) SetCompression(on bool) {
+	w.compression = on
+	if on && w.uenc == nil {
+		w.ubuf = new(bytes.Buffer)
+		w.uenc = encoding.NewEncoder(w.ubuf, w.encoder)
 	}
+}
 
-	if size > math.MaxUint32 {
-		return fmt.Errorf("message size %d exceeds maximum message header value %d", size, int64(math.MaxUint32)) // int64: without cast overflow error in 32bit OS
-	}
-
-	bufferSize := size
-
-	w.mh.sessionID = sessionID
-	w.mh.varPartLength = uint32(size)
-	w.mh.varPartSize = uint32(bufferSize)
-	w.mh.noOfSegm = 1
-
+func (w *Writer) writeMessageHeader(ctx context.Context) error {
 	if err := w.mh.encode(w.enc); err != nil {
 		return err
 	}
 	if w.protTrace {
-		w.logger.LogAttrs(ctx, slog.LevelInfo, traceMsg, slog.String(prefixClient+textMsgHdr, w.mh.String()))
+		w.trace.log(ctx, prefixClient+textMsgHdr, w.mh.String())
 	}
+	return nil
+}
 
-	if size > math.MaxInt32 {
-		return fmt.Errorf("message size %d exceeds maximum part header value %d", size, math.MaxInt32)
+// writeCompressed writes the message header and the compressed variable part.
+// In case the compression does not reduce the size the variable part is written uncompressed.
+func (w *Writer) writeCompressed(ctx context.Context) error {
+	b := w.ubuf.Bytes()
+	w.cbuf = lz4.Compress(w.cbuf[:0], b)
+	if len(w.cbuf) < len(b) {
+		w.mh.packetOptions = poCompressed
+		w.mh.compressionVarPartLength = uint32(len(b))
+		w.mh.varPartLength = uint32(len(w.cbuf))
+		w.mh.varPartSize = uint32(len(w.cbuf))
+		b = w.cbuf
+	}
+	if err := w.writeMessageHeader(ctx); err != nil {
+		return err
 	}
+	w.enc.Bytes(b)
+	return nil
+}
 
-	w.sh.messageType = messageType
-	w.sh.commit = commit
-	w.sh.segmentKind = skRequest
-	w.sh.segmentLength = int32(size)
-	w.sh.segmentOfs = 0
-	w.sh.noOfParts = int16(numPart)
-	w.sh.segmentNo = 1
+// Segment represents a request segment of a message.
+type Segment struct {
+	messageType MessageType
+	commit      bool
+	parts       []writablePart
+}
 
-	if err := w.sh.encode(w.enc); err != nil {
-		return err
+// NewSegment returns a new request segment.
+func NewSegment(messageType MessageType, commit bool, parts ...writablePart) *Segment {
+	return &Segment{messageType: messageType, commit: commit, parts: parts}
+}
+
+func (w *Writer) _write(ctx context.Context, sessionID int64, segments ...*Segment) error {
+	// check on session variables to be send as ClientInfo
+	svSending := false
+	ov := sessionVariablesFromContext(ctx)
+	if (w.sv != nil && !w.svSent) || len(w.svUnset) != 0 || ov != nil {
+		for _, segment := range segments {
+			if segment.messageType.ClientInfoSupported() {
+				segment.parts = append([]writablePart{w.clientInfo(ov)}, segment.parts...)
+				svSending = true
+				break
+			}
+		}
 	}
-	if w.protTrace {
-		w.logger.LogAttrs(ctx, slog.LevelInfo, traceMsg, slog.String(prefixClient+textSegHdr, w.sh.String()))
+
+	partSizes := make([][]int, len(segments))
+	segmentSizes := make([]int64, len(segments))
+	var size int64 // int64 to hold MaxUInt32 in 32bit OS
+
+	for i, segment := range segments {
+		partSize := make([]int, len(segment.parts))
+		segmentSize := int64(segmentHeaderSize + len(segment.parts)*partHeaderSize)
+		for j, part := range segment.parts {
+			s := part.size()
+			segmentSize += int64(s + padBytes(s))
+			partSize[j] = s // buffer size (expensive calculation)
+		}
+		if segmentSize > math.MaxInt32 {
+			return fmt.Errorf("segment size %d exceeds maximum segment header value %d", segmentSize, math.MaxInt32)
+		}
+		partSizes[i] = partSize
+		segmentSizes[i] = segmentSize
+		size += segmentSize
 	}
 
-	bufferSize -= segmentHeaderSize
+	if size > math.MaxUint32 {
+		return fmt.Errorf("message size %d exceeds maximum message header value %d", size, int64(math.MaxUint32)) // int64: without cast overflow error in 32bit OS
+	}
 
-	for i, part := range parts {
-		size := partSize[i]
-		pad := padBytes(size)
+	bufferSize := size
 
-		w.ph.partKind = part.kind()
-		if err := w.ph.setNumArg(part.numArg()); err != nil {
+	w.mh.sessionID = sessionID
+	w.mh.varPartLength = uint32(size)
+	w.mh.varPartSize = uint32(bufferSize)
+	w.mh.noOfSegm = int16(len(segments))
+	w.mh.packetOptions = 0
+	w.mh.compressionVarPartLength = 0
+
+	// in case of compression the variable part is encoded into a buffer first
+	enc := w.enc
+	compress := w.compression && size >= compressionThreshold
+	if compress {
+		w.ubuf.Reset()
+		enc = w.uenc
+	} else {
+		if err := w.writeMessageHeader(ctx); err != nil {
 			return err
 		}
-		w.ph.bufferLength = int32(size)
-		w.ph.bufferSize = int32(bufferSize)
+	}
 
-		if err := w.ph.encode(w.enc); err != nil {
-			return err
-		}
-		if w.protTrace {
-			w.logger.LogAttrs(ctx, slog.LevelInfo, traceMsg, slog.String(prefixClient+textParHdr, w.ph.String()))
-		}
+	var segmentOfs int64
+	for i, segment := range segments {
+		w.sh.messageType = segment.messageType
+		w.sh.commit = segment.commit
+		w.sh.segmentKind = skRequest
+		w.sh.segmentLength = int32(segmentSizes[i])
+		w.sh.segmentOfs = int32(segmentOfs)
+		w.sh.noOfParts = int16(len(segment.parts))
+		w.sh.segmentNo = int16(i + 1)
 
-		if err := part.encode(w.enc); err != nil {
+		if err := w.sh.encode(enc); err != nil {
 			return err
 		}
 		if w.protTrace {
-			w.logger.LogAttrs(ctx, slog.LevelInfo, traceMsg, slog.String(prefixClient+textPar, part.String()))
+			w.trace.log(ctx, prefixClient+textSegHdr, w.sh.String())
 		}
 
-		w.enc.Zeroes(pad)
+		bufferSize -= segmentHeaderSize
+
+		for j, part := range segment.parts {
+			size := partSizes[i][j]
+			pad := padBytes(size)
+
+			w.ph.partKind = part.kind()
+			if err := w.ph.setNumArg(part.numArg()); err != nil {
+				return err
+			}
+			w.ph.bufferLength = int32(size)
+			w.ph.bufferSize = int32(bufferSize)
+
+			if err := w.ph.encode(enc); err != nil {
+				return err
+			}
+			if w.protTrace {
+				w.trace.log(ctx, prefixClient+textParHdr, w.ph.String())
+			}
+
+			if err := part.encode(enc); err != nil {
+				return err
+			}
+			if w.protTrace {
+				w.trace.logPart(ctx, prefixClient+textPar, w.ph, part)
+			}
 
-		bufferSize -= int64(partHeaderSize + size + pad)
+			enc.Zeroes(pad)
+
+			bufferSize -= int64(partHeaderSize + size + pad)
+		}
+		segmentOfs += segmentSizes[i]
 	}
-	return w.wr.Flush()
+
+	if compress {
+		if err := w.writeCompressed(ctx); err != nil {
+			return err
+		}
+	}
+	if w.MessageHook != nil {
+		w.MessageHook(messageHeaderSize + int(size))
+	}
+	if err := w.wr.Flush(); err != nil {
+		return err
+	}
+	if svSending { // session variables are sent again with the next request in case of write errors
+		w.svSentWith(ov)
+	}
+	return nil
 }
 
 func (w *Writer) Write(ctx context.Context, sessionID int64, messageType MessageType, commit bool, parts ...writablePart) error {
-	if err := w._write(ctx, sessionID, messageType, commit, parts...); err != nil {
+	return w.WriteSegments(ctx, sessionID, NewSegment(messageType, commit, parts...))
+}
+
+// WriteSegments writes a message consisting of one request segment per segment.
+func (w *Writer) WriteSegments(ctx context.Context, sessionID int64, segments ...*Segment) error {
+	// check number of arguments before anything is written, so that the connection stays usable.
+	for _, segment := range segments {
+		for _, part := range segment.parts {
+			if err := checkNumArg(part.numArg()); err != nil {
+				return err
+			}
+		}
+	}
+	if w.BeforeWrite != nil && len(segments) != 0 {
+		w.BeforeWrite(ctx, segments[0].messageType)
+	}
+	if w.CheckConcurrentUse {
+		if !w.inUse.CompareAndSwap(false, true) {
+			return ErrConcurrentUse // do not touch the writer: the connection stays usable for the round trip in progress
+		}
+	}
+	if err := w._write(ctx, sessionID, segments...); err != nil {
+		w.ReplyDone() // no reply is read
 		return errors.Join(err, driver.ErrBadConn)
 	}
 	return nil
 }
+
+// ReplyDone ends the round trip of the last written request (see CheckConcurrentUse).
+// It is to be called after the reply got processed (see Reader.ReplyHook).
+func (w *Writer) ReplyDone() { w.inUse.Store(false) }
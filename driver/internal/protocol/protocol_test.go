@@ -0,0 +1,605 @@
+package protocol
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/SAP/go-hdb/driver/internal/protocol/auth"
+	"github.com/SAP/go-hdb/driver/internal/protocol/encoding"
+	"github.com/SAP/go-hdb/driver/unicode/cesu8"
+)
+
+func TestTraceWriter(t *testing.T) {
+	ctx := context.Background()
+
+	// logger must not be used if trace writer is set
+	logBuf := &bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(logBuf, nil))
+
+	buf := &bytes.Buffer{}
+	wrTrace := &bytes.Buffer{}
+	w := NewWriter(bufio.NewWriter(buf), true, false, logger, wrTrace, cesu8.DefaultEncoder, nil)
+	if err := w.Write(ctx, 1, MtExecuteDirect, false, Command("select 1 from dummy")); err != nil {
+		t.Fatal(err)
+	}
+
+	rdTrace := &bytes.Buffer{}
+	r := NewClientReader(buf, true, false, logger, rdTrace, cesu8.DefaultDecoder)
+	if err := r.SkipParts(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if logBuf.Len() != 0 {
+		t.Fatalf("unexpected log output %s", logBuf.String())
+	}
+
+	for _, trace := range []*bytes.Buffer{wrTrace, rdTrace} {
+		lines := strings.Split(strings.TrimSuffix(trace.String(), "\n"), "\n")
+		keys := []string{textMsgHdr, textSegHdr, textParHdr, textPar}
+		if len(lines) != len(keys) {
+			t.Fatalf("number of trace lines %d - expected %d: %v", len(lines), len(keys), lines)
+		}
+		for i, key := range keys {
+			if !strings.HasPrefix(lines[i], prefixClient+key+" ") {
+				t.Fatalf("trace line %s - expected prefix %s", lines[i], prefixClient+key)
+			}
+		}
+	}
+}
+
+func TestSetSessionVariable(t *testing.T) {
+	ctx := context.Background()
+
+	sv := map[string]string{"k1": "v1"} // session variables shared by connector
+
+	trace := &bytes.Buffer{}
+	w := NewWriter(bufio.NewWriter(&bytes.Buffer{}), true, false, nil, trace, cesu8.DefaultEncoder, sv)
+
+	numParts := func() int {
+		defer trace.Reset()
+		if err := w.Write(ctx, 1, MtExecuteDirect, false, Command("select 1 from dummy")); err != nil {
+			t.Fatal(err)
+		}
+		return strings.Count(trace.String(), prefixClient+textParHdr+" ")
+	}
+
+	if n := numParts(); n != 2 { // client info and command
+		t.Fatalf("number of parts %d - expected %d", n, 2)
+	}
+	if n := numParts(); n != 1 { // client info is sent only once
+		t.Fatalf("number of parts %d - expected %d", n, 1)
+	}
+	w.SetSessionVariable("k2", "v2")
+	if n := numParts(); n != 2 { // updated client info is sent with next request
+		t.Fatalf("number of parts %d - expected %d", n, 2)
+	}
+	if _, ok := sv["k2"]; ok {
+		t.Fatal("shared session variables must not be changed")
+	}
+
+	// client info is sent again after a failed write.
+	w.SetSessionVariable("k3", "v3")
+	failWr := &testFailWriter{}
+	w.wr.Reset(failWr)
+	if err := w.Write(ctx, 1, MtExecuteDirect, false, Command("select 1 from dummy")); !errors.Is(err, errTestWrite) {
+		t.Fatalf("error %v - expected %v", err, errTestWrite)
+	}
+	trace.Reset()
+	w.wr.Reset(&bytes.Buffer{})
+	if n := numParts(); n != 2 {
+		t.Fatalf("number of parts %d - expected %d", n, 2)
+	}
+}
+
+func TestSessionVariablesOverride(t *testing.T) {
+	sv := map[string]string{"k1": "v1"}
+
+	trace := &bytes.Buffer{}
+	w := NewWriter(bufio.NewWriter(&bytes.Buffer{}), true, false, nil, trace, cesu8.DefaultEncoder, sv)
+
+	clientInfo := func(ctx context.Context) string {
+		defer trace.Reset()
+		if err := w.Write(ctx, 1, MtExecuteDirect, false, Command("select 1 from dummy")); err != nil {
+			t.Fatal(err)
+		}
+		s := trace.String()
+		i := strings.Index(s, "map[")
+		if i == -1 {
+			return ""
+		}
+		return s[i : i+strings.IndexByte(s[i:], ']')+1]
+	}
+
+	ctx := context.Background()
+	ovCtx := WithSessionVariables(ctx, map[string]string{"k1": "o1", "k2": "o2"})
+
+	testData := []struct {
+		ctx        context.Context
+		clientInfo string
+	}{
+		{ctx, "map[k1:v1]"},
+		{ovCtx, "map[k1:o1 k2:o2]"},
+		{ovCtx, "map[k1:o1 k2:o2]"}, // overrides are sent with every request
+		{ctx, "map[k1:v1 k2:]"},     // restore and unset overridden session variables
+		{ctx, ""},                   // client info is sent only once
+	}
+
+	for i, r := range testData {
+		if ci := clientInfo(r.ctx); ci != r.clientInfo {
+			t.Fatalf("request %d: client info %q - expected %q", i, ci, r.clientInfo)
+		}
+	}
+
+	// writer without session variables.
+	w = NewWriter(bufio.NewWriter(&bytes.Buffer{}), true, false, nil, trace, cesu8.DefaultEncoder, nil)
+
+	ovCtx = WithSessionVariables(ctx, map[string]string{"k": "v"})
+
+	testData = []struct {
+		ctx        context.Context
+		clientInfo string
+	}{
+		{ovCtx, "map[k:v]"},
+		{ctx, "map[k:]"}, // unset overridden session variable
+		{ctx, ""},
+	}
+
+	for i, r := range testData {
+		if ci := clientInfo(r.ctx); ci != r.clientInfo {
+			t.Fatalf("request %d (no session variables): client info %q - expected %q", i, ci, r.clientInfo)
+		}
+	}
+}
+
+func TestResetSessionVariables(t *testing.T) {
+	ctx := context.Background()
+
+	sv := map[string]string{"k1": "v1"}
+
+	trace := &bytes.Buffer{}
+	w := NewWriter(bufio.NewWriter(&bytes.Buffer{}), true, false, nil, trace, cesu8.DefaultEncoder, sv)
+
+	clientInfo := func() string {
+		defer trace.Reset()
+		if err := w.Write(ctx, 1, MtExecuteDirect, false, Command("select 1 from dummy")); err != nil {
+			t.Fatal(err)
+		}
+		s := trace.String()
+		i := strings.Index(s, "map[")
+		if i == -1 {
+			return ""
+		}
+		return s[i : i+strings.IndexByte(s[i:], ']')+1]
+	}
+
+	if ci := clientInfo(); ci != "map[k1:v1]" {
+		t.Fatalf("client info %q - expected %q", ci, "map[k1:v1]")
+	}
+	w.ResetSessionVariables(sv) // unchanged session variables are not sent again
+	if ci := clientInfo(); ci != "" {
+		t.Fatalf("client info %q - expected %q", ci, "")
+	}
+	w.SetSessionVariable("k1", "o1")
+	w.SetSessionVariable("k2", "v2")
+	if ci := clientInfo(); ci != "map[k1:o1 k2:v2]" {
+		t.Fatalf("client info %q - expected %q", ci, "map[k1:o1 k2:v2]")
+	}
+	w.ResetSessionVariables(sv)
+	if ci := clientInfo(); ci != "map[k1:v1 k2:]" {
+		t.Fatalf("client info %q - expected %q", ci, "map[k1:v1 k2:]")
+	}
+
+	// reset to no session variables.
+	w.ResetSessionVariables(nil)
+	if ci := clientInfo(); ci != "map[k1:]" {
+		t.Fatalf("client info %q - expected %q", ci, "map[k1:]")
+	}
+	w.SetSessionVariable("k", "v")
+	if ci := clientInfo(); ci != "map[k:v]" {
+		t.Fatalf("client info %q - expected %q", ci, "map[k:v]")
+	}
+	w.ResetSessionVariables(nil)
+	if ci := clientInfo(); ci != "map[k:]" {
+		t.Fatalf("client info %q - expected %q", ci, "map[k:]")
+	}
+	if ci := clientInfo(); ci != "" {
+		t.Fatalf("client info %q - expected %q", ci, "")
+	}
+}
+
+var errTestWrite = errors.New("test write error")
+
+type testFailWriter struct{}
+
+func (testFailWriter) Write(p []byte) (int, error) { return 0, errTestWrite }
+
+func TestTraceRedact(t *testing.T) {
+	ctx := context.Background()
+
+	const secret = "secret"
+
+	prms := &auth.Prms{}
+	prms.AddCESU8String(secret)
+
+	ftc := NewFieldTypeCtx(DfvLevel8, false, false)
+	inputFields := []*ParameterField{{names: &fieldNames{}, tc: tcVarchar, ft: ftc.fieldType(tcVarchar, 0, 0), mode: pmIn}}
+	inputParameters, err := NewInputParameters(inputFields, []driver.NamedValue{{Ordinal: 1, Value: secret}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, redact := range []bool{false, true} {
+		trace := &bytes.Buffer{}
+		w := NewWriter(bufio.NewWriter(&bytes.Buffer{}), true, redact, nil, trace, cesu8.DefaultEncoder, nil)
+		if err := w.Write(ctx, 1, MtAuthenticate, false, &AuthInitRequest{prms: prms}); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Write(ctx, 1, MtExecute, false, StatementID(1), inputParameters); err != nil {
+			t.Fatal(err)
+		}
+		s := trace.String()
+		if contains := strings.Contains(s, secret); contains == redact {
+			t.Fatalf("redact %t: trace contains secret %t\n%s", redact, contains, s)
+		}
+		// header information remains visible
+		if !strings.Contains(s, prefixClient+textParHdr) {
+			t.Fatalf("redact %t: missing part header in trace\n%s", redact, s)
+		}
+	}
+}
+
+func TestWriteSegments(t *testing.T) {
+	ctx := context.Background()
+
+	commands := []string{"select 1 from dummy", "select 2 from dummy"}
+
+	buf := &bytes.Buffer{}
+	w := NewWriter(bufio.NewWriter(buf), false, false, nil, nil, cesu8.DefaultEncoder, nil)
+	if err := w.WriteSegments(ctx, 1,
+		NewSegment(MtExecuteDirect, false, Command(commands[0])),
+		NewSegment(MtExecuteDirect, true, Command(commands[1])),
+	); err != nil {
+		t.Fatal(err)
+	}
+	if w.mh.noOfSegm != 2 {
+		t.Fatalf("number of segments %d - expected %d", w.mh.noOfSegm, 2)
+	}
+	if int(w.mh.varPartLength) != buf.Len()-messageHeaderSize {
+		t.Fatalf("variable part length %d - expected %d", w.mh.varPartLength, buf.Len()-messageHeaderSize)
+	}
+	if w.sh.segmentNo != 2 || w.sh.segmentOfs == 0 {
+		t.Fatalf("last segment number %d offset %d", w.sh.segmentNo, w.sh.segmentOfs)
+	}
+
+	var read []string
+	r := NewClientReader(buf, false, false, nil, nil, cesu8.DefaultDecoder)
+	if err := r.IterateParts(ctx, func(kind PartKind, attrs PartAttributes, readFn func(part Part)) {
+		if kind == PkCommand {
+			var command Command
+			readFn(&command)
+			read = append(read, command.String())
+		}
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(read, commands) {
+		t.Fatalf("commands %v - expected %v", read, commands)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("%d unread bytes", buf.Len())
+	}
+}
+
+func TestCheckConcurrentUse(t *testing.T) {
+	ctx := context.Background()
+
+	pr, pw := io.Pipe()
+	defer pr.Close()
+
+	w := NewWriter(bufio.NewWriter(pw), false, false, nil, nil, cesu8.DefaultEncoder, nil)
+	w.CheckConcurrentUse = true
+
+	// first write blocks in flush until the pipe gets read.
+	errCh := make(chan error, 1)
+	go func() { errCh <- w.Write(ctx, 1, MtExecuteDirect, false, Command("select 1 from dummy")) }()
+	if _, err := pr.Read(make([]byte, 1)); err != nil {
+		t.Fatal(err)
+	}
+
+	// concurrent write while first write is in progress.
+	if err := w.Write(ctx, 1, MtExecuteDirect, false, Command("select 2 from dummy")); !errors.Is(err, ErrConcurrentUse) {
+		t.Fatalf("error %v - expected %v", err, ErrConcurrentUse)
+	}
+
+	go io.Copy(io.Discard, pr) //nolint:errcheck
+	if err := <-errCh; err != nil {
+		t.Fatal(err)
+	}
+	// concurrent write after the first write completed but before the reply got read.
+	if err := w.Write(ctx, 1, MtExecuteDirect, false, Command("select 3 from dummy")); !errors.Is(err, ErrConcurrentUse) {
+		t.Fatalf("error %v - expected %v", err, ErrConcurrentUse)
+	}
+	// writer is released after the reply of the first request got processed.
+	w.ReplyDone()
+	if err := w.Write(ctx, 1, MtExecuteDirect, false, Command("select 4 from dummy")); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestIterateSegments(t *testing.T) {
+	ctx := context.Background()
+
+	commands := []string{"insert into t1 values (1)", "insert into t2 values (2)", "insert into t3 values (3)"}
+
+	// second and third segment fail
+	buf := &bytes.Buffer{}
+	w := NewWriter(bufio.NewWriter(buf), false, false, nil, nil, cesu8.DefaultEncoder, nil)
+	if err := w.WriteSegments(ctx, 1,
+		NewSegment(MtExecuteDirect, true, Command(commands[0])),
+		NewSegment(MtExecuteDirect, true, Command(commands[1]), errorPart(&HdbError{errorCode: 259, errorLevel: errorLevelError, errorText: []byte("invalid table name")})),
+		NewSegment(MtExecuteDirect, true, Command(commands[2]), errorPart(&HdbError{errorCode: 260, errorLevel: errorLevelError, errorText: []byte("invalid column name")})),
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	read := map[int]string{}
+	errs := map[int]error{}
+	r := NewClientReader(buf, false, false, nil, nil, cesu8.DefaultDecoder)
+	if err := r.IterateSegments(ctx, func(segmentNo int, kind PartKind, attrs PartAttributes, readFn func(part Part)) {
+		if kind == PkCommand {
+			var command Command
+			readFn(&command)
+			read[segmentNo] = command.String()
+		}
+	}, func(segmentNo int, err error) {
+		errs[segmentNo] = err
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, command := range commands {
+		if read[i+1] != command {
+			t.Fatalf("segment %d: command %s - expected %s", i+1, read[i+1], command)
+		}
+	}
+	if errs[1] != nil {
+		t.Fatalf("segment 1: unexpected error %v", errs[1])
+	}
+	for segmentNo, code := range map[int]int{2: 259, 3: 260} {
+		var hdbErrors *HdbErrors
+		if !errors.As(errs[segmentNo], &hdbErrors) || hdbErrors.Code() != code {
+			t.Fatalf("segment %d: error %v - expected code %d", segmentNo, errs[segmentNo], code)
+		}
+	}
+}
+
+func TestCompression(t *testing.T) {
+	ctx := context.Background()
+
+	command := strings.Repeat("select 1 from dummy union all ", 1000) + "select 1 from dummy"
+
+	var written, read int
+	buf := &bytes.Buffer{}
+	w := NewWriter(bufio.NewWriter(buf), false, false, nil, nil, cesu8.DefaultEncoder, nil)
+	w.MessageHook = func(size int) { written = size }
+	w.SetCompression(true)
+	if err := w.Write(ctx, 1, MtExecuteDirect, false, Command(command)); err != nil {
+		t.Fatal(err)
+	}
+	if !w.mh.compressed() {
+		t.Fatal("message not compressed")
+	}
+	if int(w.mh.varPartLength) != buf.Len()-messageHeaderSize {
+		t.Fatalf("variable part length %d - expected %d", w.mh.varPartLength, buf.Len()-messageHeaderSize)
+	}
+	if w.mh.varPartLength >= w.mh.compressionVarPartLength {
+		t.Fatalf("compressed length %d >= uncompressed length %d", w.mh.varPartLength, w.mh.compressionVarPartLength)
+	}
+
+	var readCommand Command
+	r := NewClientReader(buf, false, false, nil, nil, cesu8.DefaultDecoder)
+	r.MessageHook = func(size int) { read = size }
+	replies := 0
+	r.ReplyHook = func() { replies++ }
+	if err := r.IterateParts(ctx, func(kind PartKind, attrs PartAttributes, readFn func(part Part)) {
+		if kind == PkCommand {
+			readFn(&readCommand)
+		}
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if readCommand.String() != command {
+		t.Fatal("decompressed command does not match")
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("%d unread bytes", buf.Len())
+	}
+	if replies != 1 {
+		t.Fatalf("reply hook calls %d - expected %d", replies, 1)
+	}
+	if written != read || written != messageHeaderSize+int(w.mh.compressionVarPartLength) {
+		t.Fatalf("message hook size written %d read %d - expected %d", written, read, messageHeaderSize+int(w.mh.compressionVarPartLength))
+	}
+
+	// small messages are not compressed
+	if err := w.Write(ctx, 1, MtExecuteDirect, false, Command("select 1 from dummy")); err != nil {
+		t.Fatal(err)
+	}
+	if w.mh.compressed() {
+		t.Fatal("small message compressed")
+	}
+}
+
+func TestReadPrologDB(t *testing.T) {
+	buf := &bytes.Buffer{}
+	enc := encoding.NewEncoder(buf, cesu8.DefaultEncoder)
+	enc.Int8(2)  // product version major
+	enc.Int16(0) // product version minor
+	enc.Int8(4)  // protocol version major
+	enc.Int16(1) // protocol version minor
+	enc.Zeroes(2)
+
+	r := NewDBReader(buf, false, false, nil, nil, cesu8.DefaultDecoder)
+	if err := r.ReadProlog(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if major, minor := r.ServerVersion(); major != 2 || minor != 0 {
+		t.Fatalf("server version %d.%d - expected 2.0", major, minor)
+	}
+	if major, minor := r.ProtocolVersion(); major != 4 || minor != 1 {
+		t.Fatalf("protocol version %d.%d - expected 4.1", major, minor)
+	}
+}
+
+// rawPart is a part of arbitrary kind for testing.
+type rawPart struct {
+	pk PartKind
+	n  int // number of arguments (zero: one argument)
+	b  []byte
+}
+
+func (p rawPart) String() string                     { return fmt.Sprintf("kind %s bytes %v", p.pk, p.b) }
+func (p rawPart) kind() PartKind                     { return p.pk }
+func (p rawPart) numArg() int                        { return max(p.n, 1) }
+func (p rawPart) size() int                          { return len(p.b) }
+func (p rawPart) encode(enc *encoding.Encoder) error { enc.Bytes(p.b); return nil }
+
+func TestOnUnknownPart(t *testing.T) {
+	ctx := context.Background()
+
+	const unknownKind PartKind = 120
+	raw := []byte("experimental part")
+
+	for _, hook := range []bool{false, true} {
+		buf := &bytes.Buffer{}
+		w := NewWriter(bufio.NewWriter(buf), false, false, nil, nil, cesu8.DefaultEncoder, nil)
+		if err := w.Write(ctx, 1, MtExecuteDirect, false, Command("select 1 from dummy"), rawPart{pk: unknownKind, b: raw}, rawPart{pk: pkSessionContext, b: raw}); err != nil {
+			t.Fatal(err)
+		}
+
+		var kinds []PartKind
+		var raws [][]byte
+		r := NewClientReader(buf, false, false, nil, nil, cesu8.DefaultDecoder)
+		if hook {
+			r.OnUnknownPart = func(kind PartKind, raw []byte) {
+				kinds = append(kinds, kind)
+				raws = append(raws, raw)
+			}
+		}
+		if err := r.SkipParts(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if buf.Len() != 0 {
+			t.Fatalf("hook %t: %d unread bytes", hook, buf.Len())
+		}
+		if !hook {
+			continue
+		}
+		// the command part can be decoded generically and is not handed over to the hook
+		if !slices.Equal(kinds, []PartKind{unknownKind, pkSessionContext}) {
+			t.Fatalf("unknown part kinds %v - expected %v", kinds, []PartKind{unknownKind, pkSessionContext})
+		}
+		for _, b := range raws {
+			if !bytes.Equal(b, raw) {
+				t.Fatalf("raw part %v - expected %v", b, raw)
+			}
+		}
+	}
+}
+
+func TestLogUnknownParts(t *testing.T) {
+	ctx := context.Background()
+
+	const unknownKind PartKind = 120
+	raw := []byte("experimental part")
+
+	buf := &bytes.Buffer{}
+	w := NewWriter(bufio.NewWriter(buf), false, false, nil, nil, cesu8.DefaultEncoder, nil)
+	// the session context part kind is known, but not modeled by the driver
+	if err := w.Write(ctx, 1, MtExecuteDirect, false, Command("select 1 from dummy"), rawPart{pk: unknownKind, b: raw}, rawPart{pk: pkSessionContext, b: raw}); err != nil {
+		t.Fatal(err)
+	}
+
+	logBuf := &bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(logBuf, nil))
+	r := NewClientReader(buf, false, false, logger, nil, cesu8.DefaultDecoder)
+	r.LogUnknownParts = true
+	if err := r.SkipParts(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(logBuf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("number of log records %d - expected 1\n%s", len(lines), logBuf.String())
+	}
+	for _, s := range []string{"level=WARN", "unknown protocol part", "kind=120", fmt.Sprintf("size=%d", len(raw))} {
+		if !strings.Contains(lines[0], s) {
+			t.Fatalf("log record %q does not contain %q", lines[0], s)
+		}
+	}
+}
+
+func TestTraceStructured(t *testing.T) {
+	ctx := context.Background()
+
+	const value = "value"
+
+	ftc := NewFieldTypeCtx(DfvLevel8, false, false)
+	inputFields := []*ParameterField{{names: &fieldNames{}, tc: tcVarchar, ft: ftc.fieldType(tcVarchar, 0, 0), mode: pmIn}}
+	inputParameters, err := NewInputParameters(inputFields, []driver.NamedValue{{Ordinal: 1, Value: value}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testData := []struct {
+		mode   TraceMode
+		redact bool
+		fields any
+	}{
+		{TraceStructured, false, nil},
+		{TraceStructuredValues, false, []any{value}},
+		{TraceStructuredValues, true, []any{redactValue(value)}},
+	}
+
+	for _, d := range testData {
+		trace := &bytes.Buffer{}
+		w := NewWriter(bufio.NewWriter(&bytes.Buffer{}), true, d.redact, nil, trace, cesu8.DefaultEncoder, nil)
+		w.SetTraceMode(d.mode)
+		if err := w.Write(ctx, 1, MtExecute, false, StatementID(1), inputParameters); err != nil {
+			t.Fatal(err)
+		}
+
+		// every trace record is a JSON object
+		var parts []map[string]any
+		for _, line := range strings.Split(strings.TrimSpace(trace.String()), "\n") {
+			var record map[string]any
+			if err := json.Unmarshal([]byte(line), &record); err != nil {
+				t.Fatalf("mode %d: invalid JSON trace record %s: %s", d.mode, line, err)
+			}
+			if record["trace"] == prefixClient+textPar {
+				parts = append(parts, record)
+			}
+		}
+		if len(parts) != 2 {
+			t.Fatalf("mode %d: number of part records %d - expected 2", d.mode, len(parts))
+		}
+		record := parts[1]
+		if record["kind"] != PkParameters.String() || record["numArg"] != float64(1) || record["bufferLength"] != float64(inputParameters.size()) {
+			t.Fatalf("mode %d: invalid part attributes %v", d.mode, record)
+		}
+		if fields := record["fields"]; !reflect.DeepEqual(fields, d.fields) {
+			t.Fatalf("mode %d redact %t: fields %v - expected %v", d.mode, d.redact, fields, d.fields)
+		}
+	}
+}
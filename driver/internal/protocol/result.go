@@ -1,11 +1,14 @@
 package protocol
 
 import (
+	"bytes"
 	"database/sql/driver"
 	"fmt"
+	"io"
 	"reflect"
 
 	"github.com/SAP/go-hdb/driver/internal/protocol/encoding"
+	"golang.org/x/text/transform"
 )
 
 type columnOptions int8
@@ -158,23 +161,126 @@ type Resultset struct {
 	ResultFields []*ResultField
 	FieldValues  []driver.Value
 	DecodeErrors DecodeErrors
+
+	rawColumn   int              // see Reader.RawColumn
+	onRawColumn func(raw []byte) // see Reader.OnRawColumn
 }
 
 func (r *Resultset) String() string {
 	return fmt.Sprintf("result fields %v field values %v", r.ResultFields, r.FieldValues)
 }
 
+func (r *Resultset) fieldValues() []driver.Value { return r.FieldValues }
+
+// decodeNumArg decodes numArg rows.
+// The protocol does not provide a NULL bitmap per row - instead each field carries its own
+// null indicator (see fieldType decodeRes), so NULL fields are skipped per field by reading
+// the indicator only.
 func (r *Resultset) decodeNumArg(dec *encoding.Decoder, numArg int) error {
+	if r.onRawColumn == nil {
+		if columns := fixedColumns(r.ResultFields); columns != nil { // all columns of fixed width: fast path
+			return r.decodeFixedRows(dec, numArg, columns)
+		}
+	}
+	return r.decodeRows(dec, numArg)
+}
+
+// decodeRows decodes numArg rows via the field types (general path).
+func (r *Resultset) decodeRows(dec *encoding.Decoder, numArg int) error {
 	cols := len(r.ResultFields)
 	r.FieldValues = resizeSlice(r.FieldValues, numArg*cols)
 
+	rawColumn := -1 // no raw column capturing
+	if r.onRawColumn != nil {
+		rawColumn = r.rawColumn
+	}
+
 	for i := 0; i < numArg; i++ {
 		for j, f := range r.ResultFields {
+			if j == rawColumn {
+				dec.StartCapture()
+			}
 			var err error
 			if r.FieldValues[i*cols+j], err = f.decodeRes(dec); err != nil {
 				r.DecodeErrors = append(r.DecodeErrors, &DecodeError{row: i, fieldName: f.Name(), s: err.Error()}) // collect decode / conversion errors
 			}
+			if j == rawColumn {
+				r.onRawColumn(dec.StopCapture())
+			}
 		}
 	}
 	return dec.Error()
 }
+
+/*
+ResultsetStream represents a database result set which is decoded row by row (streaming mode).
+
+Instead of decoding all rows of a resultset part at once, the raw row data of the part is retained and the rows are
+decoded on demand by Next, so that only the compact wire representation of a fetched block is held in memory and the
+decoded values are released as soon as the rows are consumed. The raw data buffer is reused for subsequent parts.
+*/
+type ResultsetStream struct {
+	ResultFields []*ResultField
+
+	numRow int
+	row    int    // number of rows decoded
+	buf    []byte // raw row data
+	rd     *bytes.Reader
+	dec    *encoding.Decoder
+
+	rawColumn   int              // see Reader.RawColumn
+	onRawColumn func(raw []byte) // see Reader.OnRawColumn
+}
+
+// NewResultsetStream returns a new ResultsetStream instance.
+func NewResultsetStream(decoder func() transform.Transformer) *ResultsetStream {
+	rd := bytes.NewReader(nil)
+	return &ResultsetStream{rd: rd, dec: encoding.NewDecoder(rd, decoder)}
+}
+
+func (r *ResultsetStream) String() string {
+	return fmt.Sprintf("result fields %v rows %d", r.ResultFields, r.numRow)
+}
+
+// NumRow returns the number of rows of the current resultset part.
+func (r *ResultsetStream) NumRow() int { return r.numRow }
+
+func (r *ResultsetStream) decodeNumArgBufLen(dec *encoding.Decoder, numArg, bufLen int) error {
+	r.buf = resizeSlice(r.buf, bufLen)
+	dec.Bytes(r.buf)
+	r.rd.Reset(r.buf)
+	r.dec.ResetError()
+	r.numRow, r.row = numArg, 0
+	return dec.Error()
+}
+
+// Next decodes the next row of the current resultset part into dest. It returns io.EOF if all rows are decoded.
+// Decode (conversion) errors of fields are returned as row error after the complete row got decoded.
+func (r *ResultsetStream) Next(dest []driver.Value) error {
+	if r.row >= r.numRow {
+		return io.EOF
+	}
+	rawColumn := -1 // no raw column capturing
+	if r.onRawColumn != nil {
+		rawColumn = r.rawColumn
+	}
+
+	var rowErr error
+	for j, f := range r.ResultFields {
+		if j == rawColumn {
+			r.dec.StartCapture()
+		}
+		var err error
+		if dest[j], err = f.decodeRes(r.dec); err != nil && rowErr == nil {
+			rowErr = &DecodeError{row: r.row, fieldName: f.Name(), s: err.Error()}
+		}
+		if j == rawColumn {
+			r.onRawColumn(r.dec.StopCapture())
+		}
+	}
+	r.row++
+	if err := r.dec.Error(); err != nil {
+		return err
+	}
+	return rowErr
+}
@@ -0,0 +1,201 @@
+package protocol
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/SAP/go-hdb/driver/internal/protocol/encoding"
+	"github.com/SAP/go-hdb/driver/unicode/cesu8"
+)
+
+// sparseResultset returns a wide resultset with integer and varchar columns
+// and the encoded row data where only every nonNull'th field is not NULL.
+func sparseResultset(numCol, numRow, nonNull int) (*Resultset, []byte) {
+	ftc := NewFieldTypeCtx(DfvLevel8, false, false)
+	names := &fieldNames{}
+
+	fields := make([]*ResultField, numCol)
+	for i := range fields {
+		tc := tcInteger
+		if i%2 != 0 {
+			tc = tcVarchar
+		}
+		fields[i] = &ResultField{names: names, tc: tc, ft: ftc.fieldType(tc, 0, 0), columnOptions: coOptional}
+	}
+
+	buf := &bytes.Buffer{}
+	enc := encoding.NewEncoder(buf, cesu8.DefaultEncoder)
+	for i := 0; i < numRow; i++ {
+		for j, f := range fields {
+			null := (i*numCol+j)%nonNull != 0
+			switch f.tc {
+			case tcInteger:
+				enc.Bool(!null)
+				if !null {
+					enc.Int32(int32(j))
+				}
+			case tcVarchar:
+				if null {
+					enc.Byte(0xff) // null length indicator
+				} else {
+					enc.LIString("sparse") //nolint:errcheck
+				}
+			}
+		}
+	}
+	return &Resultset{ResultFields: fields}, buf.Bytes()
+}
+
+func TestSparseResultset(t *testing.T) {
+	const numCol, numRow, nonNull = 64, 8, 10
+
+	rs, data := sparseResultset(numCol, numRow, nonNull)
+	dec := encoding.NewDecoder(bytes.NewReader(data), cesu8.DefaultDecoder)
+	if err := rs.decodeNumArg(dec, numRow); err != nil {
+		t.Fatal(err)
+	}
+	if len(rs.DecodeErrors) != 0 {
+		t.Fatalf("decode errors: %v", rs.DecodeErrors)
+	}
+	for i, v := range rs.FieldValues {
+		if null := i%nonNull != 0; null != (v == nil) {
+			t.Fatalf("field %d: value %v - expected null %t", i, v, null)
+		}
+	}
+}
+
+func TestResultsetStream(t *testing.T) {
+	const numCol, numRow, nonNull = 16, 8, 3
+
+	rs, data := sparseResultset(numCol, numRow, nonNull)
+	dec := encoding.NewDecoder(bytes.NewReader(data), cesu8.DefaultDecoder)
+	if err := rs.decodeNumArg(dec, numRow); err != nil {
+		t.Fatal(err)
+	}
+
+	stream := NewResultsetStream(cesu8.DefaultDecoder)
+	stream.ResultFields = rs.ResultFields
+	for i := 0; i < 2; i++ { // second part reuses the raw data buffer
+		dec := encoding.NewDecoder(bytes.NewReader(data), cesu8.DefaultDecoder)
+		if err := stream.decodeNumArgBufLen(dec, numRow, len(data)); err != nil {
+			t.Fatal(err)
+		}
+		if stream.NumRow() != numRow {
+			t.Fatalf("number of rows %d - expected %d", stream.NumRow(), numRow)
+		}
+		dest := make([]driver.Value, numCol)
+		for row := 0; row < numRow; row++ {
+			if err := stream.Next(dest); err != nil {
+				t.Fatal(err)
+			}
+			for j, v := range dest {
+				if !reflect.DeepEqual(v, rs.FieldValues[row*numCol+j]) {
+					t.Fatalf("row %d field %d: value %v - expected %v", row, j, v, rs.FieldValues[row*numCol+j])
+				}
+			}
+		}
+		if err := stream.Next(dest); err != io.EOF {
+			t.Fatalf("error %v - expected %v", err, io.EOF)
+		}
+	}
+}
+
+func TestRawColumn(t *testing.T) {
+	const numCol, numRow, rawColumn = 4, 3, 1
+
+	rs, data := sparseResultset(numCol, numRow, 1) // no NULL values
+	var raws [][]byte
+	rs.rawColumn, rs.onRawColumn = rawColumn, func(raw []byte) { raws = append(raws, raw) }
+
+	dec := encoding.NewDecoder(bytes.NewReader(data), cesu8.DefaultDecoder)
+	if err := rs.decodeNumArg(dec, numRow); err != nil {
+		t.Fatal(err)
+	}
+	if len(raws) != numRow {
+		t.Fatalf("number of raw values %d - expected %d", len(raws), numRow)
+	}
+	expected := append([]byte{byte(len("sparse"))}, "sparse"...) // length indicator + varchar data
+	for i, raw := range raws {
+		if !bytes.Equal(raw, expected) {
+			t.Fatalf("row %d: raw value %v - expected %v", i, raw, expected)
+		}
+		if v, _ := rs.FieldValues[i*numCol+rawColumn].([]byte); string(v) != "sparse" { // decoding not affected
+			t.Fatalf("row %d: value %v - expected %s", i, v, "sparse")
+		}
+	}
+
+	// streaming mode
+	var streamRaws [][]byte
+	stream := NewResultsetStream(cesu8.DefaultDecoder)
+	stream.ResultFields = rs.ResultFields
+	stream.rawColumn, stream.onRawColumn = rawColumn, func(raw []byte) { streamRaws = append(streamRaws, raw) }
+
+	dec = encoding.NewDecoder(bytes.NewReader(data), cesu8.DefaultDecoder)
+	if err := stream.decodeNumArgBufLen(dec, numRow, len(data)); err != nil {
+		t.Fatal(err)
+	}
+	dest := make([]driver.Value, numCol)
+	for stream.Next(dest) == nil {
+	}
+	if !reflect.DeepEqual(streamRaws, raws) {
+		t.Fatalf("stream raw values %v - expected %v", streamRaws, raws)
+	}
+}
+
+func BenchmarkSparseResultset(b *testing.B) {
+	const numCol, numRow = 500, 32
+
+	for _, nonNull := range []int{1, 10, 100} {
+		rs, data := sparseResultset(numCol, numRow, nonNull)
+		rd := bytes.NewReader(data)
+		dec := encoding.NewDecoder(rd, cesu8.DefaultDecoder)
+
+		b.Run(fmt.Sprintf("nonNullEvery%d", nonNull), func(b *testing.B) {
+			b.SetBytes(int64(len(data)))
+			for i := 0; i < b.N; i++ {
+				rd.Reset(data)
+				if err := rs.decodeNumArg(dec, numRow); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestUnsupportedType(t *testing.T) {
+	ftc := NewFieldTypeCtx(DfvLevel8, false, false)
+	names := &fieldNames{}
+
+	// nested table column
+	fields := []*ResultField{
+		{names: names, tc: tcInteger, ft: ftc.fieldType(tcInteger, 0, 0)},
+		{names: names, tc: tcTable, ft: ftc.fieldType(tcTable, 0, 0)},
+	}
+	if typeName := fields[1].TypeName(); typeName != "TABLE" {
+		t.Fatalf("type name %s - expected %s", typeName, "TABLE")
+	}
+	if scanType := fields[1].ScanType(); scanType != DtUnknown.ScanType(false) {
+		t.Fatalf("scan type %s - expected %s", scanType, DtUnknown.ScanType(false))
+	}
+
+	buf := &bytes.Buffer{}
+	enc := encoding.NewEncoder(buf, cesu8.DefaultEncoder)
+	enc.Int32(42)
+	enc.Bytes([]byte{1, 2, 3, 4})
+
+	rs := &Resultset{ResultFields: fields}
+	dec := encoding.NewDecoder(bytes.NewReader(buf.Bytes()), cesu8.DefaultDecoder)
+	if err := rs.decodeNumArg(dec, 1); !errors.Is(err, ErrUnsupportedType) {
+		t.Fatalf("error %v - expected %v", err, ErrUnsupportedType)
+	}
+
+	prm := &ParameterField{tc: tcTable, ft: ftc.fieldType(tcTable, 0, 0)}
+	if _, err := prm.Convert(nil, 1); !errors.Is(err, ErrUnsupportedType) {
+		t.Fatalf("error %v - expected %v", err, ErrUnsupportedType)
+	}
+}
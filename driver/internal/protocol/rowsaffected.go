@@ -8,7 +8,7 @@ import (
 
 // rows affected.
 const (
-	raSuccessNoInfo   = -2
+	RaSuccessNoInfo   = -2
 	RaExecutionFailed = -3
 )
 
@@ -31,6 +31,15 @@ func (r *RowsAffected) decodeNumArg(dec *encoding.Decoder, numArg int) error {
 	return dec.Error()
 }
 
+// Rows returns a copy of the per statement rows affected values.
+func (r RowsAffected) Rows() []int64 {
+	rows := make([]int64, len(r.rows))
+	for i, v := range r.rows {
+		rows[i] = int64(v)
+	}
+	return rows
+}
+
 // Total return the total number of all affected rows.
 func (r RowsAffected) Total() int64 {
 	total := int64(0)
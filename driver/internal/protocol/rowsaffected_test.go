@@ -0,0 +1,34 @@
+package protocol
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/SAP/go-hdb/driver/internal/protocol/encoding"
+	"github.com/SAP/go-hdb/driver/unicode/cesu8"
+)
+
+func TestRowsAffectedTotal(t *testing.T) {
+	rows := []int32{math.MaxInt32, math.MaxInt32, RaSuccessNoInfo, RaExecutionFailed, 2}
+
+	buf := &bytes.Buffer{}
+	enc := encoding.NewEncoder(buf, cesu8.DefaultEncoder)
+	for _, v := range rows {
+		enc.Int32(v)
+	}
+
+	ra := &RowsAffected{}
+	if err := ra.decodeNumArg(encoding.NewDecoder(buf, cesu8.DefaultDecoder), len(rows)); err != nil {
+		t.Fatal(err)
+	}
+	const expected = 2*math.MaxInt32 + 2 // exceeds int32 range
+	if total := ra.Total(); total != expected {
+		t.Fatalf("total %d - expected %d", total, int64(expected))
+	}
+	for i, v := range ra.Rows() {
+		if v != int64(rows[i]) {
+			t.Fatalf("row %d: rows affected %d - expected %d", i, v, rows[i])
+		}
+	}
+}
@@ -1,7 +1,6 @@
 package protocol
 
 import (
-	"fmt"
 	"strings"
 )
 
@@ -87,7 +86,7 @@ func (tc typeCode) isLob() bool {
 }
 
 func (tc typeCode) isVariableLength() bool {
-	return tc == tcChar || tc == tcNchar || tc == tcVarchar || tc == tcNvarchar || tc == tcBinary || tc == tcVarbinary || tc == tcShorttext || tc == tcAlphanum
+	return tc == tcChar || tc == tcNchar || tc == tcVarchar || tc == tcNvarchar || tc == tcString || tc == tcNstring || tc == tcBinary || tc == tcVarbinary || tc == tcShorttext || tc == tcAlphanum
 }
 
 func (tc typeCode) isDecimalType() bool {
@@ -165,13 +164,20 @@ func (tc typeCode) dataType() DataType {
 		return DtLob
 	case TcTableRows:
 		return DtRows
-	default:
-		panic(fmt.Sprintf("missing DataType for typeCode %s", tc))
+	default: // unknown or unsupported type code: scan into any
+		return DtUnknown
 	}
 }
 
+// unknownTypeName is the database type name of unknown type codes.
+const unknownTypeName = "UNKNOWN"
+
 // typeName returns the database type name.
 // see https://golang.org/pkg/database/sql/driver/#RowsColumnTypeDatabaseTypeName
 func (tc typeCode) typeName() string {
-	return strings.ToUpper(tc.String()[2:])
+	s := tc.String()
+	if !strings.HasPrefix(s, "tc") { // unknown type code
+		return unknownTypeName
+	}
+	return strings.ToUpper(s[2:])
 }
@@ -0,0 +1,47 @@
+package protocol
+
+import (
+	"testing"
+)
+
+func TestResultFieldColumnType(t *testing.T) {
+	names := &fieldNames{}
+
+	testData := []struct {
+		field     *ResultField
+		typeName  string
+		dataType  DataType
+		length    int64
+		lengthOk  bool
+		precision int64
+		scale     int64
+		decimalOk bool
+		nullable  bool
+	}{
+		{&ResultField{names: names, tc: tcDecimal, length: 10, fraction: 2, columnOptions: coOptional}, "DECIMAL", DtDecimal, 0, false, 10, 2, true, true},
+		{&ResultField{names: names, tc: tcFixed8, length: 18, fraction: 4}, "FIXED8", DtDecimal, 0, false, 18, 4, true, false},
+		{&ResultField{names: names, tc: tcNvarchar, length: 20, columnOptions: coOptional}, "NVARCHAR", DtString, 20, true, 0, 0, false, true},
+		{&ResultField{names: names, tc: tcNstring, length: 30}, "NSTRING", DtString, 30, true, 0, 0, false, false},
+		{&ResultField{names: names, tc: tcInteger}, "INTEGER", DtInteger, 0, false, 0, 0, false, false},
+		{&ResultField{names: names, tc: typeCode(0x7e), columnOptions: coOptional}, unknownTypeName, DtUnknown, 0, false, 0, 0, false, true},
+	}
+
+	for _, d := range testData {
+		f := d.field
+		if typeName := f.TypeName(); typeName != d.typeName {
+			t.Fatalf("type code %s: type name %s - expected %s", f.tc, typeName, d.typeName)
+		}
+		if scanType := f.ScanType(); scanType != d.dataType.ScanType(d.nullable) {
+			t.Fatalf("type code %s: scan type %s - expected %s", f.tc, scanType, d.dataType.ScanType(d.nullable))
+		}
+		if length, ok := f.TypeLength(); length != d.length || ok != d.lengthOk {
+			t.Fatalf("type code %s: length %d %t - expected %d %t", f.tc, length, ok, d.length, d.lengthOk)
+		}
+		if precision, scale, ok := f.TypePrecisionScale(); precision != d.precision || scale != d.scale || ok != d.decimalOk {
+			t.Fatalf("type code %s: precision %d scale %d %t - expected %d %d %t", f.tc, precision, scale, ok, d.precision, d.scale, d.decimalOk)
+		}
+		if nullable := f.Nullable(); nullable != d.nullable {
+			t.Fatalf("type code %s: nullable %t - expected %t", f.tc, nullable, d.nullable)
+		}
+	}
+}
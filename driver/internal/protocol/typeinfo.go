@@ -0,0 +1,28 @@
+package protocol
+
+// supportedTypeCodes are the type codes the driver can bind and scan.
+var supportedTypeCodes = []typeCode{
+	tcTinyint, tcSmallint, tcInteger, tcBigint, tcDecimal, tcReal, tcDouble,
+	tcChar, tcVarchar, tcNchar, tcNvarchar, tcBinary, tcVarbinary,
+	tcDate, tcTime, tcTimestamp,
+	tcClob, tcNclob, tcBlob, tcBoolean, tcString, tcNstring,
+	tcText, tcShorttext, tcBintext, tcAlphanum,
+	tcLongdate, tcSeconddate, tcDaydate, tcSecondtime,
+	tcStGeometry, tcStPoint, tcFixed16, tcFixed8, tcFixed12,
+}
+
+// TypeInfo describes a database type supported by the driver.
+type TypeInfo struct {
+	TypeCode byte
+	TypeName string
+	DataType DataType
+}
+
+// SupportedTypes returns the database types supported by the driver ordered by type code.
+func SupportedTypes() []TypeInfo {
+	typeInfos := make([]TypeInfo, len(supportedTypeCodes))
+	for i, tc := range supportedTypeCodes {
+		typeInfos[i] = TypeInfo{TypeCode: byte(tc), TypeName: tc.typeName(), DataType: tc.dataType()}
+	}
+	return typeInfos
+}
@@ -1,4 +1,4 @@
-// Code generated by "stringer -type=typeCode,MessageType,clientContextOption,connectOption,dbConnectInfoType,DataType,FunctionCode,PartKind,Cdm,endianess,segmentKind,statementContextType,topologyOption,ServiceType,transactionFlagType,dpv,lobTypecode -output=x_stringer.go"; DO NOT EDIT.
+// Code generated by "stringer -type=typeCode,MessageType,clientContextOption,connectOption,dbConnectInfoType,commandInfoOption,DataType,FunctionCode,PartKind,Cdm,endianess,segmentKind,statementContextType,topologyOption,ServiceType,transactionFlagType,dpv,lobTypecode -output=x_stringer.go"; DO NOT EDIT.
 
 package protocol
 
@@ -317,6 +317,25 @@ func (i dbConnectInfoType) String() string {
 	}
 	return _dbConnectInfoType_name[_dbConnectInfoType_index[i]:_dbConnectInfoType_index[i+1]]
 }
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[cmiLineNumber-1]
+	_ = x[cmiSourceModule-2]
+}
+
+const _commandInfoOption_name = "cmiLineNumbercmiSourceModule"
+
+var _commandInfoOption_index = [...]uint8{0, 13, 28}
+
+func (i commandInfoOption) String() string {
+	i -= 1
+	if i < 0 || i >= commandInfoOption(len(_commandInfoOption_index)-1) {
+		return "commandInfoOption(" + strconv.FormatInt(int64(i+1), 10) + ")"
+	}
+	return _commandInfoOption_name[_commandInfoOption_index[i]:_commandInfoOption_index[i+1]]
+}
 func _() {
 	// An "invalid array index" compiler error signifies that the constant values have changed.
 	// Re-run the stringer command to generate them again.
@@ -405,7 +424,7 @@ func _() {
 	_ = x[PkReadLobReply-18]
 	_ = x[pkAbapIStream-25]
 	_ = x[pkAbapOStream-26]
-	_ = x[pkCommandInfo-27]
+	_ = x[PkCommandInfo-27]
 	_ = x[PkWriteLobRequest-28]
 	_ = x[PkClientContext-29]
 	_ = x[PkWriteLobReply-30]
@@ -448,7 +467,7 @@ func _() {
 	_ = x[pkSQLReplyOptions-73]
 }
 
-const _PartKind_name = "pkNilPkCommandPkResultsetPkErrorPkStatementIDpkTransactionIDPkRowsAffectedPkResultsetIDPkTopologyInformationpkTableLocationPkReadLobRequestPkReadLobReplypkAbapIStreampkAbapOStreampkCommandInfoPkWriteLobRequestPkClientContextPkWriteLobReplyPkParametersPkAuthenticationpkSessionContextPkClientIDpkProfilePkStatementContextpkPartitionInformationPkOutputParametersPkConnectOptionspkCommitOptionspkFetchOptionsPkFetchSizePkParameterMetadataPkResultMetadatapkFindLobRequestpkFindLobReplypkItabSHMpkItabChunkMetadatapkItabMetadatapkItabResultChunkPkClientInfopkStreamDatapkOStreamResultpkFDARequestMetadatapkFDAReplyMetadatapkBatchPreparepkBatchExecutePkTransactionFlagspkRowSlotImageParamMetadatapkRowSlotImageResultsetPkDBConnectInfopkLobFlagspkResultsetOptionspkXATransactionInfopkSessionVariablepkWorkLoadReplayContextpkSQLReplyOptions"
+const _PartKind_name = "pkNilPkCommandPkResultsetPkErrorPkStatementIDpkTransactionIDPkRowsAffectedPkResultsetIDPkTopologyInformationpkTableLocationPkReadLobRequestPkReadLobReplypkAbapIStreampkAbapOStreamPkCommandInfoPkWriteLobRequestPkClientContextPkWriteLobReplyPkParametersPkAuthenticationpkSessionContextPkClientIDpkProfilePkStatementContextpkPartitionInformationPkOutputParametersPkConnectOptionspkCommitOptionspkFetchOptionsPkFetchSizePkParameterMetadataPkResultMetadatapkFindLobRequestpkFindLobReplypkItabSHMpkItabChunkMetadatapkItabMetadatapkItabResultChunkPkClientInfopkStreamDatapkOStreamResultpkFDARequestMetadatapkFDAReplyMetadatapkBatchPreparepkBatchExecutePkTransactionFlagspkRowSlotImageParamMetadatapkRowSlotImageResultsetPkDBConnectInfopkLobFlagspkResultsetOptionspkXATransactionInfopkSessionVariablepkWorkLoadReplayContextpkSQLReplyOptions"
 
 var _PartKind_map = map[PartKind]string{
 	0:  _PartKind_name[0:5],
@@ -0,0 +1,53 @@
+//go:build !unit
+
+package driver
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"net/netip"
+	"testing"
+)
+
+func TestIPAddrArgs(t *testing.T) {
+	t.Parallel()
+
+	db := MT.DB()
+
+	tableName := RandomIdentifier("ipaddr_")
+	if _, err := db.Exec(fmt.Sprintf("create table %s (no integer, s varchar(45), b varbinary(16))", tableName)); err != nil {
+		t.Fatal(err)
+	}
+
+	ip := net.ParseIP("192.168.0.1")
+
+	// character field: text representation, binary field: raw address bytes
+	if _, err := db.Exec(fmt.Sprintf("insert into %s values (?, ?, ?)", tableName), 0, ip, ip); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(fmt.Sprintf("insert into %s values (?, ?, ?)", tableName), 1, netip.MustParseAddr("2001:db8::1"), net.IP(nil)); err != nil {
+		t.Fatal(err)
+	}
+
+	testData := []struct {
+		s string
+		b []byte
+	}{
+		{"192.168.0.1", ip},
+		{"2001:db8::1", nil},
+	}
+
+	for i, r := range testData {
+		var (
+			s string
+			b []byte
+		)
+		if err := db.QueryRow(fmt.Sprintf("select s, b from %s where no = ?", tableName), i).Scan(&s, &b); err != nil {
+			t.Fatal(err)
+		}
+		if s != r.s || !bytes.Equal(b, r.b) {
+			t.Fatalf("row %d: got %s %v - expected %s %v", i, s, b, r.s, r.b)
+		}
+	}
+}
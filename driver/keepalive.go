@@ -0,0 +1,92 @@
+package driver
+
+import (
+	"sync"
+	"time"
+)
+
+/*
+keepAlive pings an idle connection periodically to prevent intermediary network devices like NAT gateways or
+firewalls from dropping the connection.
+
+A connection is idle while it is part of the connection pool: database/sql validates a connection (see
+driver.Validator) when it is returned to the pool, which starts the pings. As database/sql does not reset the
+session (see driver.SessionResetter) on every reuse of a connection, the pings are stopped by every database
+call of the connection, statements and transactions, so that a connection in use is never pinged.
+The methods can be called on a nil keepAlive (keep-alive disabled).
+*/
+type keepAlive struct {
+	mu       sync.Mutex
+	interval time.Duration
+	ping     func() error
+	timer    *time.Timer // nil if connection is not idle
+	closed   bool
+}
+
+func newKeepAlive(interval time.Duration, ping func() error) *keepAlive {
+	return &keepAlive{interval: interval, ping: ping}
+}
+
+// idle starts the keep-alive pings.
+func (k *keepAlive) idle() {
+	if k == nil {
+		return
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.closed || k.timer != nil {
+		return
+	}
+	k.timer = time.AfterFunc(k.interval, k.run)
+}
+
+// busy stops the keep-alive pings. In case a ping is running busy waits until the ping is finished.
+func (k *keepAlive) busy() {
+	if k == nil {
+		return
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.stop()
+}
+
+// do executes f synchronized with the keep-alive pings without stopping them.
+func (k *keepAlive) do(f func() error) error {
+	if k == nil {
+		return f()
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return f()
+}
+
+// close stops the keep-alive pings permanently.
+func (k *keepAlive) close() {
+	if k == nil {
+		return
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.stop()
+	k.closed = true
+}
+
+func (k *keepAlive) stop() {
+	if k.timer != nil {
+		k.timer.Stop()
+		k.timer = nil
+	}
+}
+
+func (k *keepAlive) run() {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.timer == nil { // connection got busy or closed in the meantime
+		return
+	}
+	if err := k.ping(); err != nil {
+		k.timer = nil // connection is bad: stop pinging
+		return
+	}
+	k.timer.Reset(k.interval)
+}
@@ -0,0 +1,121 @@
+package driver
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestKeepAlive(t *testing.T) {
+	const interval = time.Millisecond
+
+	waitPings := func(t *testing.T, numPings *atomic.Int64, n int64) {
+		deadline := time.Now().Add(5 * time.Second)
+		for numPings.Load() < n {
+			if time.Now().After(deadline) {
+				t.Fatalf("number of pings %d - expected at least %d", numPings.Load(), n)
+			}
+			time.Sleep(interval)
+		}
+	}
+
+	t.Run("idle", func(t *testing.T) {
+		numPings := &atomic.Int64{}
+		k := newKeepAlive(interval, func() error { numPings.Add(1); return nil })
+		k.idle()
+		waitPings(t, numPings, 2) // pings are repeated
+		k.busy()
+		n := numPings.Load()
+		time.Sleep(10 * interval)
+		if numPings.Load() != n {
+			t.Fatal("busy connection must not be pinged")
+		}
+		k.idle()
+		waitPings(t, numPings, n+1)
+		k.close()
+		k.idle() // closed: no pings anymore
+		n = numPings.Load()
+		time.Sleep(10 * interval)
+		if numPings.Load() != n {
+			t.Fatal("closed connection must not be pinged")
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		numPings := &atomic.Int64{}
+		k := newKeepAlive(interval, func() error { numPings.Add(1); return errors.New("ping error") })
+		k.idle()
+		waitPings(t, numPings, 1)
+		time.Sleep(10 * interval)
+		if n := numPings.Load(); n != 1 {
+			t.Fatalf("number of pings %d - expected %d", n, 1)
+		}
+		k.close()
+	})
+}
+
+func TestKeepAliveDo(t *testing.T) {
+	const interval = time.Millisecond
+
+	var k *keepAlive // disabled
+	if err := k.do(func() error { return nil }); err != nil {
+		t.Fatal(err)
+	}
+	k.idle()
+	k.busy()
+	k.close()
+
+	running := &atomic.Bool{}
+	numPings := &atomic.Int64{}
+	ping := func() error {
+		if running.Load() {
+			t.Error("ping executed concurrently")
+		}
+		numPings.Add(1)
+		return nil
+	}
+	k = newKeepAlive(interval, ping)
+	k.idle()
+	for i := 0; i < 10; i++ {
+		k.do(func() error { //nolint:errcheck
+			running.Store(true)
+			time.Sleep(interval)
+			running.Store(false)
+			return nil
+		})
+	}
+	n := numPings.Load()
+	deadline := time.Now().Add(5 * time.Second)
+	for numPings.Load() == n { // pings are not stopped
+		if time.Now().After(deadline) {
+			t.Fatal("pings stopped by do")
+		}
+		time.Sleep(interval)
+	}
+	k.close()
+}
+
+func TestDBConnDeadline(t *testing.T) {
+	now := time.Now()
+
+	c := &dbConn{timeout: time.Hour}
+	if d := c.deadline(); d.Before(now.Add(time.Hour)) {
+		t.Fatalf("deadline %s - expected timeout deadline", d)
+	}
+	// call deadline before timeout (keep-alive ping).
+	c.callDeadline = now.Add(time.Second)
+	if d := c.deadline(); !d.Equal(c.callDeadline) {
+		t.Fatalf("deadline %s - expected %s", d, c.callDeadline)
+	}
+	// earliest deadline.
+	c.handshakeDeadline = now.Add(time.Millisecond)
+	if d := c.deadline(); !d.Equal(c.handshakeDeadline) {
+		t.Fatalf("deadline %s - expected %s", d, c.handshakeDeadline)
+	}
+	// no timeout.
+	c.timeout, c.handshakeDeadline = 0, time.Time{}
+	if d := c.deadline(); !d.Equal(c.callDeadline) {
+		t.Fatalf("deadline %s - expected %s", d, c.callDeadline)
+	}
+}
@@ -10,6 +10,69 @@ import (
 	p "github.com/SAP/go-hdb/driver/internal/protocol"
 )
 
+// ErrLobStreamInterrupted is the error raised if reading a lob got interrupted before the end of the lob
+// was reached (e.g. because of a connection loss), so that the lob data written so far is incomplete.
+// The underlying error is wrapped.
+var ErrLobStreamInterrupted = errors.New("lob stream interrupted")
+
+func isLobStreamInterrupted(err error) bool {
+	return errors.Is(err, driver.ErrBadConn) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+/*
+decodeLobChunks writes the lob data of descr to wr and reads the remaining lob chunks via fetch
+until the last chunk is reached.
+Errors interrupting the stream (connection loss, premature end of data) are wrapped by ErrLobStreamInterrupted.
+*/
+func decodeLobChunks(descr *p.LobOutDescr, wr io.Writer, lobChunkSize int64, countChars func(b []byte) (int, int), fetch func(lobRequest *p.ReadLobRequest, lobReply *p.ReadLobReply) error) error {
+	chunkSize := func(numChar, ofs int64) int32 {
+		chunkSize := numChar - ofs
+		if chunkSize > lobChunkSize {
+			return int32(lobChunkSize)
+		}
+		return int32(chunkSize)
+	}
+
+	size, numChar := countChars(descr.B)
+	if _, err := wr.Write(descr.B[:size]); err != nil {
+		return err
+	}
+
+	lobRequest := &p.ReadLobRequest{}
+	lobRequest.ID = descr.ID
+
+	lobReply := &p.ReadLobReply{}
+
+	eof := descr.Opt.IsLastData()
+
+	for !eof {
+		lobRequest.Ofs += int64(numChar)
+		lobRequest.ChunkSize = chunkSize(descr.NumChar, lobRequest.Ofs)
+
+		lobReply.B = nil
+		if err := fetch(lobRequest, lobReply); err != nil {
+			if isLobStreamInterrupted(err) {
+				return fmt.Errorf("%w at offset %d: %w", ErrLobStreamInterrupted, lobRequest.Ofs, err)
+			}
+			return err
+		}
+
+		if lobReply.ID != lobRequest.ID {
+			return fmt.Errorf("internal error: invalid lob locator %d - expected %d", lobReply.ID, lobRequest.ID)
+		}
+
+		size, numChar = countChars(lobReply.B)
+		if _, err := wr.Write(lobReply.B[:size]); err != nil {
+			return err
+		}
+		eof = lobReply.Opt.IsLastData()
+		if !eof && len(lobReply.B) == 0 { // no progress without reaching the end of the lob
+			return fmt.Errorf("%w at offset %d: %w", ErrLobStreamInterrupted, lobRequest.Ofs, io.ErrUnexpectedEOF)
+		}
+	}
+	return nil
+}
+
 func scanLob(src any, wr io.Writer) error {
 	scanner, ok := src.(p.LobScanner)
 	if !ok {
@@ -27,21 +90,30 @@ func scanLob(src any, wr io.Writer) error {
 
 // ScanLobBytes supports scanning Lob data into a byte slice.
 // This enables using []byte based custom types for scanning Lobs instead of using a Lob object.
+// A NULL Lob is scanned as nil byte slice, an empty Lob as non-nil byte slice of length zero.
 // For usage please refer to the example.
 func ScanLobBytes(src any, b *[]byte) error {
 	if b == nil {
 		return fmt.Errorf("lob scan error: parameter b %T is nil", b)
 	}
+	if src == nil { // NULL lob
+		*b = nil
+		return nil
+	}
 	wr := new(bytes.Buffer)
 	if err := scanLob(src, wr); err != nil {
 		return err
 	}
-	*b = wr.Bytes()
+	if *b = wr.Bytes(); *b == nil { // empty lob: buffer without any data written
+		*b = []byte{}
+	}
 	return nil
 }
 
 // ScanLobString supports scanning Lob data into a string.
 // This enables using string based custom types for scanning Lobs instead of using a Lob object.
+// As a NULL Lob is scanned as empty string, please use ScanLobBytes or NullBytes if NULL Lobs need to be
+// distinguished from empty Lobs.
 // For usage please refer to the example.
 func ScanLobString(src any, s *string) error {
 	if s == nil {
@@ -126,6 +198,9 @@ func (n *NullLob) Scan(value any) error {
 		n.Lob, n.Valid = new(Lob), false
 		return nil
 	}
+	if n.Lob == nil {
+		n.Lob = new(Lob)
+	}
 	n.Valid = true
 	return n.Lob.Scan(value)
 }
@@ -247,6 +247,70 @@ func testLobDelayedScan(t *testing.T, db *sql.DB) {
 	}
 }
 
+func testLobNullEmpty(t *testing.T, db *sql.DB) {
+	table := RandomIdentifier("lobNullEmpty_")
+
+	if _, err := db.Exec(fmt.Sprintf("create table %s (i integer, b blob)", table)); err != nil {
+		t.Fatalf("create table failed: %s", err)
+	}
+
+	testData := []struct {
+		b     []byte
+		valid bool
+	}{
+		{nil, false},               // NULL blob
+		{[]byte{}, true},           // empty blob
+		{[]byte("lob data"), true}, // populated blob
+	}
+
+	// use trancactions:
+	// SQL Error 596 - LOB streaming is not permitted in auto-commit mode
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, d := range testData {
+		var v any
+		if d.valid {
+			v = d.b
+		}
+		if _, err := tx.Exec(fmt.Sprintf("insert into %s values (?,?)", table), i, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := db.Query(fmt.Sprintf("select b from %s order by i", table))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	i := 0
+	for rows.Next() {
+		var b bytesLob
+		if err := rows.Scan(&b); err != nil {
+			t.Fatal(err)
+		}
+		d := testData[i]
+		switch {
+		case !d.valid && b != nil:
+			t.Fatalf("record %d: got %v - expected nil", i, b)
+		case d.valid && (b == nil || !bytes.Equal(b, d.b)):
+			t.Fatalf("record %d: got %v (nil %t) - expected %v", i, b, b == nil, d.b)
+		}
+		i++
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if i != len(testData) {
+		t.Fatalf("number of records %d - expected %d", i, len(testData))
+	}
+}
+
 func TestLob(t *testing.T) {
 	tests := []struct {
 		name string
@@ -255,6 +319,7 @@ func TestLob(t *testing.T) {
 		{"insert", testLobInsert},
 		{"pipe", testLobPipe},
 		{"delayedScan", testLobDelayedScan},
+		{"nullEmpty", testLobNullEmpty},
 	}
 
 	db := MT.DB()
@@ -264,3 +329,69 @@ func TestLob(t *testing.T) {
 		})
 	}
 }
+
+func TestLobPrefetchStatement(t *testing.T) {
+	const (
+		numRow  = 5
+		lobSize = 100000 // exceeds inline lob data: lob is read in additional round trips
+	)
+
+	connector := MT.NewConnector()
+	connector.SetLobPrefetch(true)
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	table := RandomIdentifier("lobPrefetch_")
+	if _, err := db.Exec(fmt.Sprintf("create column table %s (i integer, b blob)", table)); err != nil {
+		t.Fatal(err)
+	}
+
+	testData := make([][]byte, numRow)
+	for i := range testData {
+		testData[i] = make([]byte, lobSize)
+		if _, err := rand.Read(testData[i]); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := db.Exec(fmt.Sprintf("insert into %s values (?, ?)", table), i, testData[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	rows, err := tx.Query(fmt.Sprintf("select i, b from %s order by i", table))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	i := 0
+	for rows.Next() {
+		// the lobs of the next row are prefetched: statements on the same connection need to wait for the prefetch.
+		var n int
+		if err := tx.QueryRow(fmt.Sprintf("select count(*) from %s", table)).Scan(&n); err != nil {
+			t.Fatal(err)
+		}
+		if n != numRow {
+			t.Fatalf("number of rows %d - expected %d", n, numRow)
+		}
+
+		var b bytesLob
+		if err := rows.Scan(&i, &b); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(b, testData[i]) {
+			t.Fatalf("row %d: lob data differs", i)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if i != numRow-1 {
+		t.Fatalf("last row %d - expected %d", i, numRow-1)
+	}
+}
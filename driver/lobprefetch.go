@@ -0,0 +1,86 @@
+package driver
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"io"
+	"sync"
+
+	p "github.com/SAP/go-hdb/driver/internal/protocol"
+)
+
+type lobPrefetchResult struct {
+	b   []byte
+	err error
+}
+
+/*
+lobPrefetcher reads the lobs of the next resultset row in the background while the application
+processes the current row.
+
+As lob round trips on a connection are processed one after another, a single worker per resultset
+reads the lobs of a row in column order. The lob data is buffered in memory and replayed when the
+lob is scanned - errors of the prefetch are returned by the scan of the respective lob.
+*/
+type lobPrefetcher struct {
+	decode  func(descr *p.LobOutDescr, wr io.Writer) error
+	wg      *sync.WaitGroup // running prefetches of the connection
+	done    chan struct{}
+	results map[*p.LobOutDescr]*lobPrefetchResult
+}
+
+func newLobPrefetcher(decode func(descr *p.LobOutDescr, wr io.Writer) error, wg *sync.WaitGroup) *lobPrefetcher {
+	return &lobPrefetcher{decode: decode, wg: wg, results: map[*p.LobOutDescr]*lobPrefetchResult{}}
+}
+
+// start starts prefetching the lobs of the row values which need additional round trips.
+func (lp *lobPrefetcher) start(values []driver.Value) {
+	var descrs []*p.LobOutDescr
+	for _, v := range values {
+		if descr, ok := v.(*p.LobOutDescr); ok && !descr.Opt.IsLastData() {
+			descrs = append(descrs, descr)
+		}
+	}
+	if len(descrs) == 0 {
+		return
+	}
+
+	done := make(chan struct{})
+	lp.done = done
+	lp.wg.Add(1) // let other requests and the close of the connection wait for the prefetch
+	go func() {
+		defer lp.wg.Done()
+		defer close(done)
+		for _, descr := range descrs {
+			buf := new(bytes.Buffer)
+			err := lp.decode(descr, buf)
+			lp.results[descr] = &lobPrefetchResult{b: buf.Bytes(), err: err}
+		}
+	}()
+}
+
+// wait waits until a running prefetch is finished.
+func (lp *lobPrefetcher) wait() {
+	if lp.done != nil {
+		<-lp.done
+		lp.done = nil
+	}
+}
+
+// decoder returns the lob decoder replaying the prefetched data of descr and removes the prefetch result.
+// In case descr was not prefetched the standard decoder is returned.
+func (lp *lobPrefetcher) decoder(descr *p.LobOutDescr) func(descr *p.LobOutDescr, wr io.Writer) error {
+	result, ok := lp.results[descr]
+	if !ok {
+		return lp.decode
+	}
+	delete(lp.results, descr)
+	return func(descr *p.LobOutDescr, wr io.Writer) error {
+		err := result.err
+		if err == nil {
+			_, err = wr.Write(result.b)
+		}
+		closeLobWriter(wr, err)
+		return err
+	}
+}
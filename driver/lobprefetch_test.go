@@ -0,0 +1,123 @@
+package driver
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+
+	p "github.com/SAP/go-hdb/driver/internal/protocol"
+)
+
+func TestLobPrefetch(t *testing.T) {
+	errPrefetch := errors.New("prefetch error")
+
+	var mu sync.Mutex
+	var order []p.LocatorID
+	decode := func(descr *p.LobOutDescr, wr io.Writer) error {
+		mu.Lock()
+		order = append(order, descr.ID)
+		mu.Unlock()
+		if descr.ID == 3 {
+			return errPrefetch
+		}
+		_, err := fmt.Fprintf(wr, "lob %d", descr.ID)
+		return err
+	}
+
+	complete := &p.LobOutDescr{ID: 4, Opt: 0x04, B: []byte("complete")} // last data: no prefetch needed
+	descrs := []*p.LobOutDescr{{ID: 1}, {ID: 2}, {ID: 3}}
+	values := []driver.Value{descrs[0], int64(42), descrs[1], nil, descrs[2], complete}
+
+	wg := new(sync.WaitGroup)
+	lp := newLobPrefetcher(decode, wg)
+	lp.start(values)
+	lp.wait()
+	wg.Wait()
+
+	// per lob ordering
+	if len(order) != len(descrs) {
+		t.Fatalf("number of prefetched lobs %d - expected %d", len(order), len(descrs))
+	}
+	for i, descr := range descrs {
+		if order[i] != descr.ID {
+			t.Fatalf("prefetch order %v", order)
+		}
+	}
+
+	for _, descr := range descrs[:2] {
+		wr := new(bytes.Buffer)
+		if err := lp.decoder(descr)(descr, wr); err != nil {
+			t.Fatal(err)
+		}
+		if s, expected := wr.String(), fmt.Sprintf("lob %d", descr.ID); s != expected {
+			t.Fatalf("lob data %s - expected %s", s, expected)
+		}
+	}
+
+	// prefetch error is returned on scan
+	if err := lp.decoder(descrs[2])(descrs[2], new(bytes.Buffer)); !errors.Is(err, errPrefetch) {
+		t.Fatalf("error %v - expected %v", err, errPrefetch)
+	}
+
+	// not prefetched lob uses the standard decoder
+	order = nil
+	if err := lp.decoder(complete)(complete, new(bytes.Buffer)); err != nil || len(order) != 1 {
+		t.Fatalf("standard decoder not called - error %v", err)
+	}
+}
+
+func TestLobPrefetchQueryResult(t *testing.T) {
+	var mu sync.Mutex
+	numDecode := map[p.LocatorID]int{}
+	decode := func(descr *p.LobOutDescr, wr io.Writer) error {
+		mu.Lock()
+		numDecode[descr.ID]++
+		mu.Unlock()
+		_, err := fmt.Fprintf(wr, "lob %d", descr.ID)
+		return err
+	}
+
+	// resultset of three rows with one lob column each - all lobs need additional round trips.
+	descrs := []*p.LobOutDescr{{ID: 1}, {ID: 2}, {ID: 3}}
+	wg := new(sync.WaitGroup)
+	qr := &queryResult{
+		fields:        make([]*p.ResultField, 1),
+		fieldValues:   []driver.Value{descrs[0], descrs[1], descrs[2]},
+		attrs:         p.PartAttributes(0x01), // last packet
+		lobPrefetcher: newLobPrefetcher(decode, wg),
+	}
+
+	dest := make([]driver.Value, 1)
+	for i, descr := range descrs {
+		if err := qr.Next(dest); err != nil {
+			t.Fatal(err)
+		}
+		if dest[0] != descr {
+			t.Fatalf("row %d: value %v - expected %v", i, dest[0], descr)
+		}
+		// the lob of the next row is prefetched while the current row is processed
+		wr := new(bytes.Buffer)
+		if err := descr.Scan(wr); err != nil {
+			t.Fatal(err)
+		}
+		if s, expected := wr.String(), fmt.Sprintf("lob %d", descr.ID); s != expected {
+			t.Fatalf("row %d: lob data %s - expected %s", i, s, expected)
+		}
+	}
+	if err := qr.Next(dest); err != io.EOF {
+		t.Fatalf("error %v - expected %v", err, io.EOF)
+	}
+	qr.lobPrefetcher.wait()
+	wg.Wait()
+
+	// every lob is read exactly once: the first row lob by the standard decoder, the others by the prefetch.
+	for _, descr := range descrs {
+		if n := numDecode[descr.ID]; n != 1 {
+			t.Fatalf("lob %d: decoded %d times - expected 1", descr.ID, n)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package driver
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	p "github.com/SAP/go-hdb/driver/internal/protocol"
+)
+
+func TestLobStreamInterrupted(t *testing.T) {
+	const lobSize, chunkSize, truncateSize = 1000, 128, 500
+
+	countBytes := func(b []byte) (int, int) { return len(b), len(b) }
+
+	// mock reader delivering the lob data until the connection drops.
+	rd := io.LimitReader(bytes.NewReader(make([]byte, lobSize)), truncateSize)
+	descr := &p.LobOutDescr{ID: 1, NumChar: lobSize}
+
+	wr := new(bytes.Buffer)
+	err := decodeLobChunks(descr, wr, chunkSize, countBytes, func(lobRequest *p.ReadLobRequest, lobReply *p.ReadLobReply) error {
+		b := make([]byte, lobRequest.ChunkSize)
+		n, err := io.ReadFull(rd, b)
+		if err != nil {
+			return fmt.Errorf("%w: %w", driver.ErrBadConn, err) // like dbConn in case of read errors
+		}
+		lobReply.ID, lobReply.B = lobRequest.ID, b[:n]
+		return nil
+	})
+	if !errors.Is(err, ErrLobStreamInterrupted) {
+		t.Fatalf("error %v - expected %v", err, ErrLobStreamInterrupted)
+	}
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("error %v - expected wrapped %v", err, io.ErrUnexpectedEOF)
+	}
+	if wr.Len() >= lobSize {
+		t.Fatalf("written bytes %d - expected incomplete lob", wr.Len())
+	}
+
+	// reply without data and without reaching the end of the lob.
+	err = decodeLobChunks(descr, new(bytes.Buffer), chunkSize, countBytes, func(lobRequest *p.ReadLobRequest, lobReply *p.ReadLobReply) error {
+		lobReply.ID = lobRequest.ID
+		return nil
+	})
+	if !errors.Is(err, ErrLobStreamInterrupted) {
+		t.Fatalf("error %v - expected %v", err, ErrLobStreamInterrupted)
+	}
+
+	// other errors are not wrapped.
+	errFetch := errors.New("fetch error")
+	err = decodeLobChunks(descr, new(bytes.Buffer), chunkSize, countBytes, func(*p.ReadLobRequest, *p.ReadLobReply) error { return errFetch })
+	if !errors.Is(err, errFetch) || errors.Is(err, ErrLobStreamInterrupted) {
+		t.Fatalf("error %v - expected %v", err, errFetch)
+	}
+}
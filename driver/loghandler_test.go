@@ -0,0 +1,86 @@
+package driver
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/SAP/go-hdb/driver/dial"
+)
+
+// captureHandler is a slog.Handler capturing log records.
+type captureHandler struct {
+	mu      *sync.Mutex
+	attrs   []slog.Attr
+	records *[]slog.Record
+}
+
+func newCaptureHandler() *captureHandler {
+	return &captureHandler{mu: new(sync.Mutex), records: new([]slog.Record)}
+}
+
+func (h *captureHandler) Enabled(context.Context, slog.Level) bool { return true }
+func (h *captureHandler) Handle(ctx context.Context, r slog.Record) error {
+	r = r.Clone()
+	r.AddAttrs(h.attrs...)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	*h.records = append(*h.records, r)
+	return nil
+}
+func (h *captureHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &captureHandler{mu: h.mu, attrs: append(h.attrs[:len(h.attrs):len(h.attrs)], attrs...), records: h.records}
+}
+func (h *captureHandler) WithGroup(name string) slog.Handler { return h }
+
+func (h *captureHandler) messages() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	msgs := make([]string, len(*h.records))
+	for i, r := range *h.records {
+		msgs[i] = r.Message
+	}
+	return msgs
+}
+
+// closingDialer returns connections to a mock server closing the connection after reading the protocol prolog.
+type closingDialer struct{}
+
+func (d closingDialer) DialContext(ctx context.Context, address string, options dial.DialerOptions) (net.Conn, error) {
+	client, server := net.Pipe()
+	go func() {
+		defer server.Close()
+		io.ReadFull(server, make([]byte, 14)) //nolint:errcheck
+	}()
+	return client, nil
+}
+
+func TestLogHandler(t *testing.T) {
+	handler := newCaptureHandler()
+
+	connector := NewBasicAuthConnector("host:30015", "user", "password")
+	connector.SetDialer(closingDialer{})
+	connector.SetLogHandler(handler)
+
+	if connector.LogHandler() != slog.Handler(handler) {
+		t.Fatal("log handler not set")
+	}
+
+	if _, err := connector.Connect(context.Background()); err == nil {
+		t.Fatal("connect error expected")
+	}
+
+	msgs := handler.messages()
+	if len(msgs) == 0 {
+		t.Fatal("no log records captured")
+	}
+	for _, msg := range msgs {
+		if msg == "DB conn read error" {
+			return
+		}
+	}
+	t.Fatalf("log record %q not captured - got %v", "DB conn read error", msgs)
+}
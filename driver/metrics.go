@@ -4,12 +4,21 @@ import (
 	"fmt"
 	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 const (
 	counterBytesRead = iota
 	counterBytesWritten
+	counterLobBytesRead
+	counterLobBytesWritten
+	counterUncompressedBytesRead
+	counterUncompressedBytesWritten
+	counterDroppedWarnings
+	counterRetries
+	counterStmtCacheHits
+	counterStmtCacheMisses
 	numCounter
 )
 
@@ -17,6 +26,7 @@ const (
 	gaugeConn = iota
 	gaugeTx
 	gaugeStmt
+	gaugeStmtCache
 	numGauge
 )
 
@@ -24,6 +34,8 @@ const (
 	timeRead = iota
 	timeWrite
 	timeAuth
+	timeServer
+	timeClient
 	numTime
 )
 
@@ -39,6 +51,54 @@ const (
 	numSQLTime
 )
 
+// numSQLTimeSample is the number of most recent sql time samples kept per sql time kind (0: disabled).
+var numSQLTimeSample atomic.Int64
+
+// SQLTimeSamples returns the number of most recent sql time samples kept per sql time kind.
+func SQLTimeSamples() int { return int(numSQLTimeSample.Load()) }
+
+// SetSQLTimeSamples sets the number of most recent sql time samples kept per sql time kind (see Stats SQLTimeSamples).
+// Collecting samples is disabled by default (n == 0) to bound the memory used by the statistics.
+func SetSQLTimeSamples(n int) {
+	if n < 0 {
+		n = 0
+	}
+	numSQLTimeSample.Store(int64(n))
+}
+
+// ring keeps the most recent values added.
+type ring struct {
+	values []float64
+	idx    int
+	full   bool
+}
+
+// add adds v to a ring of size n. The ring gets reset in case the size did change.
+func (r *ring) add(v float64, n int) {
+	if n != len(r.values) {
+		r.values, r.idx, r.full = make([]float64, n), 0, false
+	}
+	if n == 0 {
+		return
+	}
+	r.values[r.idx] = v
+	r.idx++
+	if r.idx == n {
+		r.idx, r.full = 0, true
+	}
+}
+
+// list returns the values of the ring (oldest first).
+func (r *ring) list() []float64 {
+	if !r.full {
+		return slices.Clone(r.values[:r.idx])
+	}
+	return append(slices.Clone(r.values[r.idx:]), r.values[:r.idx]...)
+}
+
+// rowsUpperBounds are the histogram upper bounds of the number of rows per query / fetch.
+var rowsUpperBounds = []float64{1, 10, 100, 1000, 10000, 100000}
+
 type histogram struct {
 	count          uint64
 	sum            float64
@@ -97,6 +157,20 @@ type sqlTimeMsg struct {
 	idx int
 }
 
+type sqlErrorMsg struct {
+	idx int
+}
+
+type rowsMsg struct {
+	v int
+}
+
+// scopeMsg is a metric message to be handled by the metrics of a metrics scope only.
+type scopeMsg struct {
+	metrics *metrics
+	msg     any
+}
+
 type metrics struct {
 	mu sync.RWMutex
 
@@ -105,31 +179,44 @@ type metrics struct {
 	timeUnit string
 	divider  float64
 
-	counters []uint64
-	gauges   []int64
-	times    []*histogram
-	sqlTimes []*histogram
+	counters       []uint64
+	gauges         []int64
+	times          []*histogram
+	sqlTimes       []*histogram
+	sqlTimeSamples []*ring
+	sqlErrors      []uint64
+	rows           *histogram
 }
 
-func newMetrics(parentMetrics *metrics, timeUnit string, timeUpperBounds []float64) *metrics {
+// newMetrics creates a new metrics instance. The histograms of the sql times are using the upper bounds of
+// sqlTimeUpperBounds (key: sql time text) if available, timeUpperBounds otherwise.
+func newMetrics(parentMetrics *metrics, timeUnit string, timeUpperBounds []float64, sqlTimeUpperBounds map[string][]float64) *metrics {
 	d, ok := timeUnitMap[timeUnit]
 	if !ok {
 		panic("invalid unit " + timeUnit)
 	}
 	rv := &metrics{
-		parentMetrics: parentMetrics,
-		timeUnit:      timeUnit,
-		divider:       float64(d),
-		counters:      make([]uint64, numCounter),
-		gauges:        make([]int64, numGauge),
-		times:         make([]*histogram, numTime),
-		sqlTimes:      make([]*histogram, numSQLTime),
+		parentMetrics:  parentMetrics,
+		timeUnit:       timeUnit,
+		divider:        float64(d),
+		counters:       make([]uint64, numCounter),
+		gauges:         make([]int64, numGauge),
+		times:          make([]*histogram, numTime),
+		sqlTimes:       make([]*histogram, numSQLTime),
+		sqlTimeSamples: make([]*ring, numSQLTime),
+		sqlErrors:      make([]uint64, numSQLTime),
+		rows:           newHistogram(rowsUpperBounds),
 	}
 	for i := 0; i < int(numTime); i++ {
 		rv.times[i] = newHistogram(timeUpperBounds)
 	}
 	for i := 0; i < int(numSQLTime); i++ {
-		rv.sqlTimes[i] = newHistogram(timeUpperBounds)
+		if upperBounds, ok := sqlTimeUpperBounds[statsCfg.SQLTimeTexts[i]]; ok {
+			rv.sqlTimes[i] = newHistogram(upperBounds)
+		} else {
+			rv.sqlTimes[i] = newHistogram(timeUpperBounds)
+		}
+		rv.sqlTimeSamples[i] = &ring{}
 	}
 	return rv
 }
@@ -142,17 +229,41 @@ func (m *metrics) stats() *Stats {
 	for i, sqlTime := range m.sqlTimes {
 		sqlTimes[statsCfg.SQLTimeTexts[i]] = sqlTime.stats()
 	}
+	sqlTimeSamples := map[string][]float64{}
+	for i, samples := range m.sqlTimeSamples {
+		if values := samples.list(); len(values) != 0 {
+			sqlTimeSamples[statsCfg.SQLTimeTexts[i]] = values
+		}
+	}
+	sqlErrors := make(map[string]uint64, len(m.sqlErrors))
+	for i, sqlError := range m.sqlErrors {
+		sqlErrors[statsCfg.SQLTimeTexts[i]] = sqlError
+	}
 	return &Stats{
-		OpenConnections:  int(m.gauges[gaugeConn]),
-		OpenTransactions: int(m.gauges[gaugeTx]),
-		OpenStatements:   int(m.gauges[gaugeStmt]),
-		ReadBytes:        m.counters[counterBytesRead],
-		WrittenBytes:     m.counters[counterBytesWritten],
-		TimeUnit:         m.timeUnit,
-		ReadTime:         m.times[timeRead].stats(),
-		WriteTime:        m.times[timeWrite].stats(),
-		AuthTime:         m.times[timeAuth].stats(),
-		SQLTimes:         sqlTimes,
+		OpenConnections:          int(m.gauges[gaugeConn]),
+		OpenTransactions:         int(m.gauges[gaugeTx]),
+		OpenStatements:           int(m.gauges[gaugeStmt]),
+		StmtCacheSize:            int(m.gauges[gaugeStmtCache]),
+		ReadBytes:                m.counters[counterBytesRead],
+		WrittenBytes:             m.counters[counterBytesWritten],
+		LobReadBytes:             m.counters[counterLobBytesRead],
+		LobWrittenBytes:          m.counters[counterLobBytesWritten],
+		UncompressedReadBytes:    m.counters[counterUncompressedBytesRead],
+		UncompressedWrittenBytes: m.counters[counterUncompressedBytesWritten],
+		DroppedWarnings:          m.counters[counterDroppedWarnings],
+		Retries:                  m.counters[counterRetries],
+		StmtCacheHits:            m.counters[counterStmtCacheHits],
+		StmtCacheMisses:          m.counters[counterStmtCacheMisses],
+		TimeUnit:                 m.timeUnit,
+		ReadTime:                 m.times[timeRead].stats(),
+		WriteTime:                m.times[timeWrite].stats(),
+		AuthTime:                 m.times[timeAuth].stats(),
+		ServerTime:               m.times[timeServer].stats(),
+		ClientTime:               m.times[timeClient].stats(),
+		SQLTimes:                 sqlTimes,
+		SQLTimeSamples:           sqlTimeSamples,
+		SQLErrors:                sqlErrors,
+		RowsPerQuery:             m.rows.stats(),
 	}
 }
 
@@ -166,7 +277,13 @@ func (m *metrics) handleMsg(msg any) {
 	case timeMsg:
 		m.times[msg.idx].add(float64(msg.d.Nanoseconds()) / m.divider)
 	case sqlTimeMsg:
-		m.sqlTimes[msg.idx].add(float64(msg.d.Nanoseconds()) / m.divider)
+		v := float64(msg.d.Nanoseconds()) / m.divider
+		m.sqlTimes[msg.idx].add(v)
+		m.sqlTimeSamples[msg.idx].add(v, int(numSQLTimeSample.Load()))
+	case sqlErrorMsg:
+		m.sqlErrors[msg.idx]++
+	case rowsMsg:
+		m.rows.add(float64(msg.v))
 	default:
 		panic(fmt.Sprintf("invalid metric message type %T", msg))
 	}
@@ -199,6 +316,10 @@ func newMetricsCollector(metrics *metrics) *metricsCollector {
 func (mc *metricsCollector) collect(wg *sync.WaitGroup, chMsg <-chan any, metrics *metrics) {
 	defer wg.Done()
 	for msg := range chMsg {
+		if msg, ok := msg.(scopeMsg); ok {
+			msg.metrics.handleMsg(msg.msg)
+			continue
+		}
 		metrics.handleMsg(msg)
 	}
 }
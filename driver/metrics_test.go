@@ -0,0 +1,197 @@
+package driver
+
+import (
+	"io"
+	"log/slog"
+	"net"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestMetricsSQLErrors(t *testing.T) {
+	parent := newMetrics(nil, statsCfg.TimeUnit, statsCfg.TimeUpperBounds, statsCfg.SQLTimeUpperBounds)
+	child := newMetrics(parent, statsCfg.TimeUnit, statsCfg.TimeUpperBounds, statsCfg.SQLTimeUpperBounds)
+
+	collector := newMetricsCollector(child)
+	collector.msgCh <- sqlErrorMsg{idx: sqlTimeExec}
+	collector.msgCh <- sqlErrorMsg{idx: sqlTimeExec}
+	collector.msgCh <- sqlErrorMsg{idx: sqlTimeQuery}
+	collector.close()
+
+	for _, m := range []*metrics{child, parent} {
+		stats := m.stats()
+		if len(stats.SQLErrors) != numSQLTime {
+			t.Fatalf("number of sql errors %d - expected %d", len(stats.SQLErrors), numSQLTime)
+		}
+		for i, text := range statsCfg.SQLTimeTexts {
+			var expected uint64
+			switch i {
+			case sqlTimeExec:
+				expected = 2
+			case sqlTimeQuery:
+				expected = 1
+			}
+			if stats.SQLErrors[text] != expected {
+				t.Fatalf("sql errors %s: %d - expected %d", text, stats.SQLErrors[text], expected)
+			}
+		}
+	}
+}
+
+func TestMetricsSQLTimeUpperBounds(t *testing.T) {
+	timeUpperBounds := []float64{1, 10, 100}
+	sqlTimeUpperBounds := map[string][]float64{statsCfg.SQLTimeTexts[sqlTimeFetch]: {0.1, 0.5}}
+
+	m := newMetrics(nil, statsCfg.TimeUnit, timeUpperBounds, sqlTimeUpperBounds)
+	m.handleMsg(sqlTimeMsg{idx: sqlTimeFetch, d: 0})
+
+	for text, h := range m.stats().SQLTimes {
+		upperBounds, ok := sqlTimeUpperBounds[text]
+		if !ok {
+			upperBounds = timeUpperBounds
+		}
+		if len(h.Buckets) != len(upperBounds) {
+			t.Fatalf("sql time %s: number of buckets %d - expected %d", text, len(h.Buckets), len(upperBounds))
+		}
+		for _, upperBound := range upperBounds {
+			count, ok := h.Buckets[upperBound]
+			if !ok {
+				t.Fatalf("sql time %s: bucket %f missing", text, upperBound)
+			}
+			if count != h.Count {
+				t.Fatalf("sql time %s: bucket %f count %d - expected %d", text, upperBound, count, h.Count)
+			}
+		}
+	}
+}
+
+func TestMetricsRowsPerQuery(t *testing.T) {
+	m := newMetrics(nil, statsCfg.TimeUnit, statsCfg.TimeUpperBounds, nil)
+	for _, numRow := range []int{0, 5, 50, 500} {
+		m.handleMsg(rowsMsg{v: numRow})
+	}
+
+	h := m.stats().RowsPerQuery
+	if h.Count != 4 || h.Sum != 555 {
+		t.Fatalf("count %d sum %f - expected count %d sum %d", h.Count, h.Sum, 4, 555)
+	}
+	for upperBound, expected := range map[float64]uint64{1: 1, 10: 2, 100: 3, 1000: 4} {
+		if h.Buckets[upperBound] != expected {
+			t.Fatalf("bucket %f count %d - expected %d", upperBound, h.Buckets[upperBound], expected)
+		}
+	}
+}
+
+func TestMetricsLobBytes(t *testing.T) {
+	parent := newMetrics(nil, statsCfg.TimeUnit, statsCfg.TimeUpperBounds, nil)
+	child := newMetrics(parent, statsCfg.TimeUnit, statsCfg.TimeUpperBounds, nil)
+
+	collector := newMetricsCollector(child)
+	collector.msgCh <- counterMsg{idx: counterLobBytesRead, v: 100}
+	collector.msgCh <- counterMsg{idx: counterLobBytesRead, v: 20}
+	collector.msgCh <- counterMsg{idx: counterLobBytesWritten, v: 300}
+	collector.close()
+
+	for _, m := range []*metrics{child, parent} {
+		stats := m.stats()
+		if stats.LobReadBytes != 120 || stats.LobWrittenBytes != 300 {
+			t.Fatalf("lob read bytes %d lob written bytes %d - expected %d %d", stats.LobReadBytes, stats.LobWrittenBytes, 120, 300)
+		}
+	}
+}
+
+func TestMetricsSQLTimeSamples(t *testing.T) {
+	defer SetSQLTimeSamples(SQLTimeSamples())
+
+	m := newMetrics(nil, "ms", statsCfg.TimeUpperBounds, nil)
+
+	SetSQLTimeSamples(0)
+	m.handleMsg(sqlTimeMsg{idx: sqlTimeQuery, d: time.Millisecond})
+	if samples := m.stats().SQLTimeSamples; len(samples) != 0 {
+		t.Fatalf("samples %v - expected none", samples)
+	}
+
+	SetSQLTimeSamples(3)
+	for i := 1; i <= 5; i++ {
+		m.handleMsg(sqlTimeMsg{idx: sqlTimeQuery, d: time.Duration(i) * time.Millisecond})
+	}
+	m.handleMsg(sqlTimeMsg{idx: sqlTimeExec, d: 10 * time.Millisecond})
+
+	samples := m.stats().SQLTimeSamples
+	if len(samples) != 2 {
+		t.Fatalf("number of sql time samples %d - expected %d", len(samples), 2)
+	}
+	if v := samples[statsCfg.SQLTimeTexts[sqlTimeQuery]]; !slices.Equal(v, []float64{3, 4, 5}) {
+		t.Fatalf("query samples %v - expected %v", v, []float64{3, 4, 5})
+	}
+	if v := samples[statsCfg.SQLTimeTexts[sqlTimeExec]]; !slices.Equal(v, []float64{10}) {
+		t.Fatalf("exec samples %v - expected %v", v, []float64{10})
+	}
+}
+
+func TestMetricsServerClientTime(t *testing.T) {
+	const serverDelay = 50 * time.Millisecond
+
+	m := newMetrics(nil, statsCfg.TimeUnit, statsCfg.TimeUpperBounds, nil)
+	collector := newMetricsCollector(m)
+
+	client, server := net.Pipe()
+	defer client.Close()
+	go func() {
+		defer server.Close()
+		request := make([]byte, 4)
+		if _, err := io.ReadFull(server, request); err != nil {
+			return
+		}
+		time.Sleep(serverDelay)
+		server.Write([]byte("reply")) //nolint:errcheck
+	}()
+
+	c := &dbConn{collector: collector, conn: client, logger: slog.Default()}
+	if _, err := c.Write([]byte("ping")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.ReadFull(c, make([]byte, 5)); err != nil {
+		t.Fatal(err)
+	}
+	c.replyDone()
+	c.replyDone() // no reply pending: must not add a sample
+	collector.close()
+
+	stats := m.stats()
+	if stats.ServerTime.Count != 1 {
+		t.Fatalf("server time count %d - expected %d", stats.ServerTime.Count, 1)
+	}
+	if min := float64(serverDelay.Nanoseconds()) / m.divider; stats.ServerTime.Sum < min {
+		t.Fatalf("server time %f - expected >= %f", stats.ServerTime.Sum, min)
+	}
+	if stats.ClientTime.Count != 1 {
+		t.Fatalf("client time count %d - expected %d", stats.ClientTime.Count, 1)
+	}
+}
+
+func TestMetricsConnRollUp(t *testing.T) {
+	parent := newMetrics(nil, statsCfg.TimeUnit, statsCfg.TimeUpperBounds, statsCfg.SQLTimeUpperBounds)
+
+	conns := []*metrics{newConnMetrics(parent), newConnMetrics(parent)}
+	for i, m := range conns {
+		collector := newMetricsCollector(m)
+		collector.msgCh <- counterMsg{idx: counterBytesRead, v: uint64(10 * (i + 1))}
+		collector.msgCh <- counterMsg{idx: counterBytesWritten, v: uint64(i + 1)}
+		collector.close()
+	}
+
+	// per connection counters.
+	for i, m := range conns {
+		stats := m.stats()
+		if stats.ReadBytes != uint64(10*(i+1)) || stats.WrittenBytes != uint64(i+1) {
+			t.Fatalf("conn %d: read bytes %d written bytes %d - expected %d %d", i, stats.ReadBytes, stats.WrittenBytes, 10*(i+1), i+1)
+		}
+	}
+	// rolled up into parent.
+	stats := parent.stats()
+	if stats.ReadBytes != 30 || stats.WrittenBytes != 3 {
+		t.Fatalf("parent: read bytes %d written bytes %d - expected %d %d", stats.ReadBytes, stats.WrittenBytes, 30, 3)
+	}
+}
@@ -0,0 +1,94 @@
+package driver
+
+import (
+	"context"
+	"sync"
+)
+
+// metricsScopeCtxKey is the context key of a metrics scope.
+type metricsScopeCtxKey struct{}
+
+type metricsScope struct {
+	name    string
+	metrics *metrics
+	parent  *metricsScope // scope of the parent context (nil if none)
+	numRef  int
+}
+
+// metricsScopes is the registry of the active metrics scopes.
+var metricsScopes = struct {
+	mu     sync.Mutex
+	scopes map[string]*metricsScope
+}{scopes: map[string]*metricsScope{}}
+
+/*
+WithMetricsScope returns a copy of ctx associated with the metrics scope name (e.g. a tenant or a request).
+The sql statistics (sql times, sql errors and rows per query) of database calls executed with the returned
+context are collected in the metrics scope in addition to the database and driver statistics, so that the
+statistics of a scope are always part of the aggregated driver statistics.
+
+A metrics scope created with a context already associated with a metrics scope (e.g. a request scope within
+a tenant scope) is a child of this scope: the statistics of the child are part of the statistics of the parent
+scope. The parent is determined by the context creating the scope, contexts using the same name share the
+metrics scope and its parent.
+
+The scope is released as soon as all contexts associated with the scope (and all child scopes) are done.
+The scope of a context which is never done (e.g. context.Background()) is never released and lives as long as
+the process - so please use a cancelable context to avoid leaking scopes.
+*/
+func WithMetricsScope(ctx context.Context, name string) context.Context {
+	parent := metricsScopeFromContext(ctx)
+
+	metricsScopes.mu.Lock()
+	scope, ok := metricsScopes.scopes[name]
+	if !ok {
+		scope = &metricsScope{name: name, parent: parent}
+		var parentMetrics *metrics
+		if parent != nil {
+			parentMetrics = parent.metrics
+			parent.numRef++ // parent is released after the child
+		}
+		scope.metrics = newMetrics(parentMetrics, statsCfg.TimeUnit, statsCfg.TimeUpperBounds, statsCfg.SQLTimeUpperBounds)
+		metricsScopes.scopes[name] = scope
+	}
+	scope.numRef++
+	metricsScopes.mu.Unlock()
+
+	context.AfterFunc(ctx, func() {
+		metricsScopes.mu.Lock()
+		defer metricsScopes.mu.Unlock()
+		scope.release()
+	})
+	return context.WithValue(ctx, metricsScopeCtxKey{}, scope)
+}
+
+// release releases a reference of the scope and of its parents if the scope is not referenced anymore.
+// The caller needs to hold the metricsScopes lock.
+func (s *metricsScope) release() {
+	for ; s != nil; s = s.parent {
+		s.numRef--
+		if s.numRef != 0 {
+			return
+		}
+		delete(metricsScopes.scopes, s.name)
+	}
+}
+
+func metricsScopeFromContext(ctx context.Context) *metricsScope {
+	if scope, ok := ctx.Value(metricsScopeCtxKey{}).(*metricsScope); ok {
+		return scope
+	}
+	return nil
+}
+
+// ScopeStats returns the statistics of the metrics scope name and true if the scope is active, nil and false otherwise.
+// For the aggregated statistics please use the driver Stats method.
+func ScopeStats(name string) (*Stats, bool) {
+	metricsScopes.mu.Lock()
+	scope, ok := metricsScopes.scopes[name]
+	metricsScopes.mu.Unlock()
+	if !ok {
+		return nil, false
+	}
+	return scope.metrics.stats(), true
+}
@@ -0,0 +1,108 @@
+package driver
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMetricsScope(t *testing.T) {
+	const name = "tenant"
+
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	scopeCtx1 := WithMetricsScope(ctx1, name)
+	scopeCtx2 := WithMetricsScope(ctx2, name)
+
+	scope := metricsScopeFromContext(scopeCtx1)
+	if scope == nil || scope != metricsScopeFromContext(scopeCtx2) {
+		t.Fatal("contexts with same scope name do not share metrics")
+	}
+	if metricsScopeFromContext(ctx1) != nil {
+		t.Fatal("unexpected metrics scope of parent context")
+	}
+
+	parent := newMetrics(nil, statsCfg.TimeUnit, statsCfg.TimeUpperBounds, nil)
+	collector := newMetricsCollector(parent)
+	c := &conn{collector: collector}
+	c.addSQLMsg(scopeCtx1, rowsMsg{v: 10})
+	c.addSQLMsg(scopeCtx2, rowsMsg{v: 20})
+	c.addSQLMsg(context.Background(), rowsMsg{v: 30}) // not scoped
+	collector.close()
+
+	stats, ok := ScopeStats(name)
+	if !ok {
+		t.Fatalf("scope %s not found", name)
+	}
+	if stats.RowsPerQuery.Count != 2 || stats.RowsPerQuery.Sum != 30 {
+		t.Fatalf("scope rows count %d sum %f - expected count %d sum %d", stats.RowsPerQuery.Count, stats.RowsPerQuery.Sum, 2, 30)
+	}
+	if stats := parent.stats(); stats.RowsPerQuery.Count != 3 || stats.RowsPerQuery.Sum != 60 {
+		t.Fatalf("aggregated rows count %d sum %f - expected count %d sum %d", stats.RowsPerQuery.Count, stats.RowsPerQuery.Sum, 3, 60)
+	}
+
+	cancel1()
+	if _, ok := ScopeStats(name); !ok {
+		t.Fatalf("scope %s released while still in use", name)
+	}
+	cancel2()
+	// context.AfterFunc runs in its own goroutine
+	for i := 0; ; i++ {
+		if _, ok := ScopeStats(name); !ok {
+			break
+		}
+		if i == 100 {
+			t.Fatalf("scope %s not released", name)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestMetricsScopeParent(t *testing.T) {
+	const tenantName, requestName = "tenantParent", "requestChild"
+
+	tenantCtx, cancelTenant := context.WithCancel(context.Background())
+	requestCtx, cancelRequest := context.WithCancel(WithMetricsScope(tenantCtx, tenantName))
+	requestCtx = WithMetricsScope(requestCtx, requestName)
+
+	tenantScope := metricsScopeFromContext(WithMetricsScope(tenantCtx, tenantName))
+	if scope := metricsScopeFromContext(requestCtx); scope.parent != tenantScope {
+		t.Fatalf("parent scope %v - expected %v", scope.parent, tenantScope)
+	}
+
+	collector := newMetricsCollector(newMetrics(nil, statsCfg.TimeUnit, statsCfg.TimeUpperBounds, nil))
+	c := &conn{collector: collector}
+	c.addSQLMsg(requestCtx, rowsMsg{v: 10})
+	collector.close()
+
+	// statistics of the child scope roll up into the parent scope.
+	for _, name := range []string{requestName, tenantName} {
+		stats, ok := ScopeStats(name)
+		if !ok {
+			t.Fatalf("scope %s not found", name)
+		}
+		if stats.RowsPerQuery.Count != 1 || stats.RowsPerQuery.Sum != 10 {
+			t.Fatalf("scope %s rows count %d sum %f - expected count %d sum %d", name, stats.RowsPerQuery.Count, stats.RowsPerQuery.Sum, 1, 10)
+		}
+	}
+
+	waitReleased := func(name string) {
+		for i := 0; ; i++ {
+			if _, ok := ScopeStats(name); !ok {
+				return
+			}
+			if i == 100 {
+				t.Fatalf("scope %s not released", name)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	cancelRequest()
+	waitReleased(requestName)
+	if _, ok := ScopeStats(tenantName); !ok {
+		t.Fatalf("scope %s released while still in use", tenantName)
+	}
+	cancelTenant()
+	waitReleased(tenantName)
+}
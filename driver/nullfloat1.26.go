@@ -0,0 +1,7 @@
+//go:build !go1.27
+
+package driver
+
+// nullFloatAsNaNSupported reports if NULL floating point values can be scanned as NaN depending
+// on the scan destination (database/sql RowsColumnScanner support).
+const nullFloatAsNaNSupported = false
@@ -0,0 +1,62 @@
+//go:build go1.27
+
+package driver
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"math"
+)
+
+// nullFloatAsNaNSupported reports if NULL floating point values can be scanned as NaN depending
+// on the scan destination (database/sql RowsColumnScanner support).
+const nullFloatAsNaNSupported = true
+
+var (
+	_ driver.RowsColumnScanner = (*queryResult)(nil)
+	_ driver.RowsColumnScanner = (*callResult)(nil)
+)
+
+/*
+nullFloatValue returns the value to be assigned to dest.
+
+NULL floating point values are decoded as NaN if NullFloatAsNaN is set (the database does not store NaN values).
+The NaN value is kept for float64 and float32 destinations only, all other destinations receive NULL.
+*/
+func nullFloatValue(v driver.Value, dest any) driver.Value {
+	if f, ok := v.(float64); !ok || !math.IsNaN(f) {
+		return v
+	}
+	switch dest.(type) {
+	case *float64, *float32:
+		return v
+	default:
+		return nil
+	}
+}
+
+// NextRow implements the driver.RowsColumnScanner interface.
+func (qr *queryResult) NextRow() error {
+	if qr.row == nil {
+		qr.row = make([]driver.Value, len(qr.fields))
+	}
+	return qr.Next(qr.row)
+}
+
+// ScanColumn implements the driver.RowsColumnScanner interface.
+func (qr *queryResult) ScanColumn(scanCtx driver.ScanContext, idx int, dest any) error {
+	return sql.ConvertAssign(scanCtx, dest, nullFloatValue(qr.row[idx], dest))
+}
+
+// NextRow implements the driver.RowsColumnScanner interface.
+func (cr *callResult) NextRow() error {
+	if cr.row == nil {
+		cr.row = make([]driver.Value, len(cr.outputFields))
+	}
+	return cr.Next(cr.row)
+}
+
+// ScanColumn implements the driver.RowsColumnScanner interface.
+func (cr *callResult) ScanColumn(scanCtx driver.ScanContext, idx int, dest any) error {
+	return sql.ConvertAssign(scanCtx, dest, nullFloatValue(cr.row[idx], dest))
+}
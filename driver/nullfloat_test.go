@@ -0,0 +1,67 @@
+//go:build go1.27
+
+package driver
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"math"
+	"testing"
+
+	p "github.com/SAP/go-hdb/driver/internal/protocol"
+)
+
+func TestNullFloatAsNaN(t *testing.T) {
+	nan := math.NaN() // NULL value decoded as NaN
+
+	type dests struct {
+		f64  float64
+		f32  float32
+		nf   sql.NullFloat64
+		pf   *float64
+		a    any
+		val  float64
+		null sql.NullFloat64
+	}
+
+	values := []driver.Value{nan, nan, nan, nan, nan, 1.5, nil}
+	columns := []string{"F64", "F32", "NF", "PF", "A", "VAL", "NULL"}
+
+	check := func(t *testing.T, d *dests) {
+		t.Helper()
+		if !math.IsNaN(d.f64) || !math.IsNaN(float64(d.f32)) {
+			t.Fatalf("float destinations %v %v - expected NaN", d.f64, d.f32)
+		}
+		if d.nf.Valid || d.pf != nil || d.a != nil || d.null.Valid {
+			t.Fatalf("nullable destinations %v %v %v %v - expected NULL", d.nf, d.pf, d.a, d.null)
+		}
+		if d.val != 1.5 {
+			t.Fatalf("value %v - expected %v", d.val, 1.5)
+		}
+	}
+
+	t.Run("outputParameters", func(t *testing.T) {
+		cr := &callResult{outputFields: make([]*p.ParameterField, len(values)), fieldValues: values, _columns: columns}
+
+		d := &dests{}
+		if err := stdConnTracker.callDB().QueryRow("", cr).Scan(&d.f64, &d.f32, &d.nf, &d.pf, &d.a, &d.val, &d.null); err != nil {
+			t.Fatal(err)
+		}
+		check(t, d)
+	})
+
+	t.Run("queryResult", func(t *testing.T) {
+		qr := &queryResult{fields: make([]*p.ResultField, len(values)), fieldValues: values, _columns: columns, attrs: p.PartAttributes(0x01)} // last packet
+
+		if err := qr.NextRow(); err != nil {
+			t.Fatal(err)
+		}
+		d := &dests{}
+		for i, dest := range []any{&d.f64, &d.f32, &d.nf, &d.pf, &d.a, &d.val, &d.null} {
+			if err := qr.ScanColumn(driver.ScanContext{}, i, dest); err != nil {
+				t.Fatal(err)
+			}
+		}
+		check(t, d)
+	})
+}
@@ -0,0 +1,96 @@
+package driver
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidLimitOffset is the error raised by Paginate if a limit or offset value is not a non-negative integer.
+var ErrInvalidLimitOffset = errors.New("invalid limit or offset value")
+
+// limitOffsetValue returns the decimal representation of a non-negative integer value v.
+func limitOffsetValue(v any) (string, error) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if i := rv.Int(); i >= 0 {
+			return strconv.FormatInt(i, 10), nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.String:
+		// strict parsing: no sign, blanks or any other characters
+		if u, err := strconv.ParseUint(rv.String(), 10, 64); err == nil {
+			return strconv.FormatUint(u, 10), nil
+		}
+	}
+	return "", fmt.Errorf("%w: %[2]T %[2]v", ErrInvalidLimitOffset, v)
+}
+
+// statementEnd returns the end position of the statement text of query excluding trailing comments and blanks.
+func statementEnd(query string) int {
+	end := 0
+	for i := 0; i < len(query); i++ {
+		switch c := query[i]; {
+		case c == '\'' || c == '"': // string literal or quoted identifier (doubled quote is an escaped quote)
+			for i++; i < len(query) && query[i] != c; i++ {
+			}
+			end = min(i+1, len(query))
+		case c == '-' && strings.HasPrefix(query[i:], "--"): // line comment
+			if j := strings.IndexByte(query[i:], '\n'); j != -1 {
+				i += j
+			} else {
+				i = len(query)
+			}
+		case c == '/' && strings.HasPrefix(query[i:], "/*"): // block comment
+			if j := strings.Index(query[i+2:], "*/"); j != -1 {
+				i += j + 3
+			} else {
+				i = len(query)
+			}
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+		default:
+			end = i + 1
+		}
+	}
+	return end
+}
+
+/*
+Paginate returns the query extended by a LIMIT and an optional OFFSET clause.
+
+As hdb does not accept bound parameters in LIMIT and OFFSET positions, the values are inlined into
+the statement text. To prevent SQL injection the values need to be non-negative integers - either of an
+integer type or a string consisting of decimal digits only (e.g. a value taken from a http request).
+Any other value results in an ErrInvalidLimitOffset error. A nil offset omits the OFFSET clause.
+The clauses are inserted after the statement text, so that trailing comments of query (e.g. "-- comment")
+do not comment them out.
+
+Example:
+
+	query, err := Paginate("select * from t order by id", 10, 20) // select * from t order by id limit 10 offset 20
+*/
+func Paginate(query string, limit, offset any) (string, error) {
+	l, err := limitOffsetValue(limit)
+	if err != nil {
+		return "", err
+	}
+	end := statementEnd(query)
+	var sb strings.Builder
+	sb.WriteString(query[:end])
+	sb.WriteString(" limit ")
+	sb.WriteString(l)
+	if offset != nil {
+		o, err := limitOffsetValue(offset)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(" offset ")
+		sb.WriteString(o)
+	}
+	sb.WriteString(query[end:])
+	return sb.String(), nil
+}
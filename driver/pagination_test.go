@@ -0,0 +1,75 @@
+package driver
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPaginate(t *testing.T) {
+	const query = "select * from t order by id"
+
+	validData := []struct {
+		limit, offset any
+		query         string
+	}{
+		{10, nil, query + " limit 10"},
+		{10, 20, query + " limit 10 offset 20"},
+		{uint8(1), int64(0), query + " limit 1 offset 0"},
+		{"25", "0050", query + " limit 25 offset 50"},
+	}
+
+	// trailing comments
+	commentData := []struct {
+		query, expected string
+	}{
+		{query + " -- comment", query + " limit 10 -- comment"},
+		{query + " /* comment */\n", query + " limit 10 /* comment */\n"},
+		{query + " -- comment\n-- another comment", query + " limit 10 -- comment\n-- another comment"},
+		{"select '--' from t /* ' */", "select '--' from t limit 10 /* ' */"},
+		{"select \"a--b\" from t", "select \"a--b\" from t limit 10"},
+		{"select * /* comment */ from t\n", "select * /* comment */ from t limit 10\n"},
+	}
+
+	for i, d := range commentData {
+		q, err := Paginate(d.query, 10, nil)
+		if err != nil {
+			t.Fatalf("%d: %s", i, err)
+		}
+		if q != d.expected {
+			t.Fatalf("%d: query %q - expected %q", i, q, d.expected)
+		}
+	}
+
+	for i, d := range validData {
+		q, err := Paginate(query, d.limit, d.offset)
+		if err != nil {
+			t.Fatalf("%d: %s", i, err)
+		}
+		if q != d.query {
+			t.Fatalf("%d: query %s - expected %s", i, q, d.query)
+		}
+	}
+
+	maliciousData := []struct {
+		limit, offset any
+	}{
+		{"10; drop table t", nil},
+		{"1 or 1=1", nil},
+		{"10", "0 union select * from users"},
+		{" 10", nil},
+		{"+10", nil},
+		{-1, nil},
+		{10, -5},
+		{1.5, nil},
+		{nil, nil},
+		{"", nil},
+		{"0x10", nil},
+		{"10--", nil},
+	}
+
+	for i, d := range maliciousData {
+		if q, err := Paginate(query, d.limit, d.offset); !errors.Is(err, ErrInvalidLimitOffset) {
+			t.Fatalf("%d: query %s error %v - expected %v", i, q, err, ErrInvalidLimitOffset)
+		}
+	}
+}
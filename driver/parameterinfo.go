@@ -0,0 +1,45 @@
+package driver
+
+import (
+	"reflect"
+
+	p "github.com/SAP/go-hdb/driver/internal/protocol"
+)
+
+// ParameterInfo describes a parameter of a prepared statement (e.g. to validate arguments before execution).
+type ParameterInfo struct {
+	Name      string       // parameter name (procedure calls), empty otherwise
+	TypeCode  byte         // database type code
+	TypeName  string       // database type name (see TypeInfo)
+	ScanType  reflect.Type // scan type of the parameter (see sql.ColumnType.ScanType)
+	Length    int64        // length of variable length types, 0 otherwise
+	Precision int64        // precision of decimal types, 0 otherwise
+	Scale     int64        // scale of decimal types, 0 otherwise
+	Nullable  bool
+	In        bool // input parameter
+	Out       bool // output parameter
+}
+
+func newParameterInfos(fields []*p.ParameterField) []ParameterInfo {
+	infos := make([]ParameterInfo, len(fields))
+	for i, f := range fields {
+		length, _ := f.TypeLength()
+		precision, scale, _ := f.TypePrecisionScale()
+		infos[i] = ParameterInfo{
+			Name:      f.Name(),
+			TypeCode:  f.TypeCode(),
+			TypeName:  f.TypeName(),
+			ScanType:  f.ScanType(),
+			Length:    length,
+			Precision: precision,
+			Scale:     scale,
+			Nullable:  f.Nullable(),
+			In:        f.In(),
+			Out:       f.Out(),
+		}
+	}
+	return infos
+}
+
+// ParameterInfos implements the Stmt interface.
+func (s *stmt) ParameterInfos() []ParameterInfo { return newParameterInfos(s.pr.parameterFields) }
@@ -0,0 +1,114 @@
+//go:build !unit
+
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"testing"
+)
+
+func testPrepareParameterInfos(t *testing.T, db *sql.DB, query string) []ParameterInfo {
+	ctx := context.Background()
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	var infos []ParameterInfo
+	if err := conn.Raw(func(driverConn any) error {
+		stmt, err := driverConn.(driver.ConnPrepareContext).PrepareContext(ctx, query)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+		infos = stmt.(Stmt).ParameterInfos()
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return infos
+}
+
+func TestParameterInfos(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := MT.DB()
+
+	table := RandomIdentifier("parameterInfos")
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("create table %s (i integer not null, d decimal(10,2), s nvarchar(20))", table)); err != nil {
+		t.Fatalf("create table failed: %s", err)
+	}
+
+	infos := testPrepareParameterInfos(t, db, fmt.Sprintf("insert into %s values (?,?,?)", table))
+
+	expected := []struct {
+		typeName         string
+		length           int64
+		precision, scale int64
+		nullable         bool
+	}{
+		{"INTEGER", 0, 0, 0, false},
+		{"DECIMAL", 0, 10, 2, true},
+		{"NVARCHAR", 20, 0, 0, true},
+	}
+	if len(infos) != len(expected) {
+		t.Fatalf("number of parameters %d - expected %d", len(infos), len(expected))
+	}
+	for i, e := range expected {
+		info := infos[i]
+		if info.TypeName != e.typeName || info.Length != e.length || info.Precision != e.precision || info.Scale != e.scale || info.Nullable != e.nullable {
+			t.Fatalf("parameter %d: %+v - expected %+v", i, info, e)
+		}
+		if !info.In || info.Out {
+			t.Fatalf("parameter %d: in %t out %t - expected input parameter", i, info.In, info.Out)
+		}
+	}
+}
+
+func TestParameterInfosProcedure(t *testing.T) {
+	t.Parallel()
+
+	const procInOut = `create procedure %[1]s (in i integer, inout s nvarchar(20), out d decimal(10,2))
+language SQLSCRIPT as
+begin
+    s := concat(:s, 'x');
+    d := :i;
+end
+`
+	ctx := context.Background()
+	db := MT.DB()
+
+	proc := RandomIdentifier("procInOut_")
+	if _, err := db.ExecContext(ctx, fmt.Sprintf(procInOut, proc)); err != nil {
+		t.Fatalf("create procedure failed: %s", err)
+	}
+
+	infos := testPrepareParameterInfos(t, db, fmt.Sprintf("call %s(?,?,?)", proc))
+
+	expected := []struct {
+		name             string
+		typeName         string
+		length           int64
+		precision, scale int64
+		in, out          bool
+	}{
+		{"I", "INTEGER", 0, 0, 0, true, false},
+		{"S", "NVARCHAR", 20, 0, 0, true, true},
+		{"D", "DECIMAL", 0, 10, 2, false, true},
+	}
+	if len(infos) != len(expected) {
+		t.Fatalf("number of parameters %d - expected %d", len(infos), len(expected))
+	}
+	for i, e := range expected {
+		info := infos[i]
+		if info.Name != e.name || info.TypeName != e.typeName || info.Length != e.length || info.Precision != e.precision || info.Scale != e.scale || info.In != e.in || info.Out != e.out {
+			t.Fatalf("parameter %d: %+v - expected %+v", i, info, e)
+		}
+	}
+}
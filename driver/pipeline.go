@@ -0,0 +1,109 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	p "github.com/SAP/go-hdb/driver/internal/protocol"
+)
+
+// ErrPipelineNotExecuted is the error of pipelined statements the database server did not reply to.
+var ErrPipelineNotExecuted = errors.New("pipelined statement not executed")
+
+// PipelineResult is the result of a pipelined statement.
+type PipelineResult struct {
+	RowsAffected int64 // Number of rows affected by the statement.
+	Err          error // Database error of the statement (nil in case of success).
+}
+
+/*
+ExecPipeline implements the Conn interface.
+
+ExecPipeline sends the statements as one multi-segment request message to the database server and reads the
+replies of all statements in one round-trip. The results are returned in statement order. In case the message
+could not be written or read an error is returned, database errors are returned per statement.
+
+Constraints given by the database server:
+  - statements are executed in the order given, each statement as own request (no atomicity: in auto-commit
+    mode every statement is committed on its own, in a transaction statements are part of the transaction)
+  - only statements without parameters and without result set (e.g. insert, update, delete, ddl) can be
+    pipelined - result sets of queries are not fetched
+  - the size of all statements is limited by the maximum message size
+  - depending on the database server version execution might stop after the first failing statement;
+    statements without reply are reported with ErrPipelineNotExecuted
+*/
+func (c *conn) ExecPipeline(ctx context.Context, queries ...string) ([]PipelineResult, error) {
+	c.keepAlive.busy()
+	if c.sqlTrace {
+		for _, query := range queries {
+			defer c.logSQLTrace(ctx, time.Now(), query, nil)
+		}
+	}
+
+	ctx, done := withDBCall(ctx)
+	var results []PipelineResult
+	var err error
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		results, err = c.execPipeline(ctx, queries, !c.inTx)
+		close(done)
+	}()
+
+	select {
+	case <-ctx.Done():
+		c.cancelled(done, &err)
+		return nil, ctx.Err()
+	case <-done:
+		c.setLastError(err)
+		return results, err
+	}
+}
+
+func (c *conn) execPipeline(ctx context.Context, queries []string, commit bool) (_ []PipelineResult, err error) {
+	defer c.addSQLTimeValue(ctx, time.Now(), sqlTimeExec)
+	defer c.addSQLErrorValue(ctx, sqlTimeExec, &err)
+
+	if len(queries) == 0 {
+		return nil, nil
+	}
+
+	segments := make([]*p.Segment, len(queries))
+	for i, query := range queries {
+		if ci := c.commandInfo(ctx); ci != nil {
+			segments[i] = p.NewSegment(p.MtExecuteDirect, commit, p.Command(query), ci)
+		} else {
+			segments[i] = p.NewSegment(p.MtExecuteDirect, commit, p.Command(query))
+		}
+	}
+	if err := c.pw.WriteSegments(ctx, c.sessionID, segments...); err != nil {
+		return nil, err
+	}
+
+	results := make([]PipelineResult, len(queries))
+	replied := make([]bool, len(queries))
+	rows := &p.RowsAffected{}
+	if err := c.pr.IterateSegments(ctx, func(segmentNo int, kind p.PartKind, attrs p.PartAttributes, read func(part p.Part)) {
+		if i := segmentNo - 1; i >= 0 && i < len(results) {
+			replied[i] = true
+			if kind == p.PkRowsAffected {
+				read(rows)
+				results[i].RowsAffected = rows.Total()
+			}
+		}
+	}, func(segmentNo int, err error) {
+		if i := segmentNo - 1; i >= 0 && i < len(results) {
+			replied[i] = true
+			results[i].Err = err
+		}
+	}); err != nil {
+		return nil, err
+	}
+	for i, ok := range replied {
+		if !ok {
+			results[i].Err = ErrPipelineNotExecuted
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,112 @@
+//go:build !unit
+
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func testPipelineInsert(t *testing.T, db *sql.DB) {
+	table1, table2 := RandomIdentifier("pipeline1_"), RandomIdentifier("pipeline2_")
+	for _, table := range []Identifier{table1, table2} {
+		if _, err := db.Exec(fmt.Sprintf("create table %s (i integer)", table)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	var results []PipelineResult
+	if err := conn.Raw(func(driverConn any) error {
+		var err error
+		results, err = driverConn.(Conn).ExecPipeline(ctx,
+			fmt.Sprintf("insert into %s values (1)", table1),
+			fmt.Sprintf("insert into %s select 2 from dummy union all select 3 from dummy", table2),
+		)
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("number of results %d - expected %d", len(results), 2)
+	}
+	for i, expected := range []int64{1, 2} {
+		if results[i].Err != nil {
+			t.Fatalf("statement %d: %s", i, results[i].Err)
+		}
+		if results[i].RowsAffected != expected {
+			t.Fatalf("statement %d: rows affected %d - expected %d", i, results[i].RowsAffected, expected)
+		}
+	}
+
+	for i, table := range []Identifier{table1, table2} {
+		var numRow int64
+		if err := db.QueryRow(fmt.Sprintf("select count(*) from %s", table)).Scan(&numRow); err != nil {
+			t.Fatal(err)
+		}
+		if numRow != results[i].RowsAffected {
+			t.Fatalf("table %s: number of rows %d - expected %d", table, numRow, results[i].RowsAffected)
+		}
+	}
+}
+
+func testPipelineError(t *testing.T, db *sql.DB) {
+	table := RandomIdentifier("pipeline_")
+	if _, err := db.Exec(fmt.Sprintf("create table %s (i integer)", table)); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	var results []PipelineResult
+	if err := conn.Raw(func(driverConn any) error {
+		var err error
+		results, err = driverConn.(Conn).ExecPipeline(ctx,
+			fmt.Sprintf("insert into %s values (1)", RandomIdentifier("notExisting_")),
+			fmt.Sprintf("insert into %s values (1)", table),
+		)
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	var dbError Error
+	if !errors.As(results[0].Err, &dbError) {
+		t.Fatalf("statement 0: error %v - expected database error", results[0].Err)
+	}
+	if err := results[1].Err; err != nil && !errors.Is(err, ErrPipelineNotExecuted) {
+		t.Fatalf("statement 1: %s", err)
+	}
+}
+
+func TestPipeline(t *testing.T) {
+	tests := []struct {
+		name string
+		fct  func(t *testing.T, db *sql.DB)
+	}{
+		{"insert", testPipelineInsert},
+		{"error", testPipelineError},
+	}
+
+	db := MT.DB()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.fct(t, db)
+		})
+	}
+}
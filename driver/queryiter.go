@@ -0,0 +1,91 @@
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// errInvalidRow is returned by Row methods called on the row of an iteration error.
+var errInvalidRow = errors.New("invalid row")
+
+// Queryer is the interface of the query function of sql.DB, sql.Conn and sql.Tx.
+type Queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// Row represents the current row of a resultset iterated by QueryIter.
+// A row is only valid within the loop iteration the row is provided by.
+type Row struct {
+	rows *sql.Rows
+}
+
+// Columns returns the column names of the resultset.
+func (r Row) Columns() ([]string, error) {
+	if r.rows == nil {
+		return nil, errInvalidRow
+	}
+	return r.rows.Columns()
+}
+
+// Scan copies the column values of the row into the values pointed at by dest (see sql.Rows.Scan).
+func (r Row) Scan(dest ...any) error {
+	if r.rows == nil {
+		return errInvalidRow
+	}
+	return r.rows.Scan(dest...)
+}
+
+// Values returns the column values of the row.
+func (r Row) Values() ([]any, error) {
+	columns, err := r.Columns()
+	if err != nil {
+		return nil, err
+	}
+	values := make([]any, len(columns))
+	dest := make([]any, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := r.rows.Scan(dest...); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+/*
+QueryIter executes query with args via q (e.g. a sql.DB, sql.Conn or sql.Tx) and returns an iterator over the rows
+of the resultset. The iterator is compatible with iter.Seq2[Row, error], so that the rows can be iterated via range:
+
+	for row, err := range driver.QueryIter(ctx, conn, "select * from t where id > ?", 42) {
+		if err != nil {
+			return err
+		}
+		...
+	}
+
+The query is executed when the iteration starts. Errors (query, fetch or close errors) are provided as second
+value and terminate the iteration. If the loop is terminated early the resultset is closed on the database server.
+*/
+func QueryIter(ctx context.Context, q Queryer, query string, args ...any) func(yield func(Row, error) bool) {
+	return func(yield func(Row, error) bool) {
+		rows, err := q.QueryContext(ctx, query, args...)
+		if err != nil {
+			yield(Row{}, err)
+			return
+		}
+		defer rows.Close() // release resultset (and statement) in any case (e.g. early termination, panic)
+		for rows.Next() {
+			if !yield(Row{rows: rows}, nil) {
+				return
+			}
+		}
+		if err := rows.Err(); err != nil {
+			yield(Row{}, err)
+			return
+		}
+		if err := rows.Close(); err != nil {
+			yield(Row{}, err)
+		}
+	}
+}
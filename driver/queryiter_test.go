@@ -0,0 +1,103 @@
+//go:build !unit
+
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+)
+
+func TestQueryIter(t *testing.T) {
+	t.Parallel()
+
+	const numRow = 100
+	query := "select generated_period_start from series_generate_integer(1, 0, ?) order by 1"
+
+	ctx := context.Background()
+	conn, err := MT.DB().Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	t.Run("all", func(t *testing.T) {
+		i := int64(0)
+		QueryIter(ctx, conn, query, numRow)(func(row Row, err error) bool {
+			if err != nil {
+				t.Fatal(err)
+			}
+			var n int64
+			if err := row.Scan(&n); err != nil {
+				t.Fatal(err)
+			}
+			if n != i {
+				t.Fatalf("value %d - expected %d", n, i)
+			}
+			i++
+			return true
+		})
+		if i != numRow {
+			t.Fatalf("number of rows %d - expected %d", i, numRow)
+		}
+	})
+
+	t.Run("break", func(t *testing.T) {
+		i := 0
+		QueryIter(ctx, conn, query, numRow)(func(row Row, err error) bool {
+			if err != nil {
+				t.Fatal(err)
+			}
+			values, err := row.Values()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(values) != 1 {
+				t.Fatalf("number of values %d - expected %d", len(values), 1)
+			}
+			i++
+			return i < 10
+		})
+		if i != 10 {
+			t.Fatalf("number of rows %d - expected %d", i, 10)
+		}
+		// resultset got released: connection is usable
+		if err := conn.PingContext(ctx); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		var rows *sql.Rows
+		func() {
+			defer func() { recover() }()
+			QueryIter(ctx, conn, query, numRow)(func(row Row, err error) bool {
+				if err != nil {
+					t.Fatal(err)
+				}
+				rows = row.rows
+				panic("loop body")
+			})
+		}()
+		if rows == nil || rows.Next() {
+			t.Fatal("resultset is not released after panic")
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		numErr := 0
+		QueryIter(ctx, conn, "select * from invalid_table_name")(func(row Row, err error) bool {
+			if err == nil {
+				t.Fatal("error expected")
+			}
+			if err := row.Scan(); err != errInvalidRow {
+				t.Fatalf("error %v - expected %v", err, errInvalidRow)
+			}
+			numErr++
+			return true
+		})
+		if numErr != 1 {
+			t.Fatalf("number of errors %d - expected %d", numErr, 1)
+		}
+	})
+}
@@ -0,0 +1,122 @@
+package driver
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"strconv"
+)
+
+// ErrReadOnlyRouting is returned if statements other than queries or transactions are executed with read-only routing.
+var ErrReadOnlyRouting = errors.New("read-only routing is only supported for queries outside of transactions")
+
+// readOnlyRoutingCtxKey is the context key of the read-only routing hint.
+type readOnlyRoutingCtxKey struct{}
+
+/*
+WithReadOnlyRouting returns a copy of ctx requesting queries to be routed to a secondary replica of a system replication
+setup with read access enabled (active/active read enabled).
+
+The replica connection is opened on first use per connection to the first standby host of the topology provided by the
+database on connect (see Conn.Topology) and is set to read-only access mode. In case the topology does not contain a
+standby host the queries are executed on the connection itself.
+
+Executing statements via Exec, queries within a transaction and starting a transaction with the returned context
+returns ErrReadOnlyRouting, so that writes are never routed to a read-only replica.
+*/
+func WithReadOnlyRouting(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readOnlyRoutingCtxKey{}, true)
+}
+
+func isReadOnlyRouting(ctx context.Context) bool {
+	routing, _ := ctx.Value(readOnlyRoutingCtxKey{}).(bool)
+	return routing
+}
+
+// replicaHost returns the address of the first standby host of the topology.
+func replicaHost(topology []TopologyHost) (string, bool) {
+	for _, host := range topology {
+		if host.IsStandby && !host.IsCurrentSession && host.Host != "" {
+			return net.JoinHostPort(host.Host, strconv.Itoa(host.Port)), true
+		}
+	}
+	return "", false
+}
+
+// checkReadOnlyRouting returns ErrReadOnlyRouting in case read-only routing is requested by ctx.
+func (c *conn) checkReadOnlyRouting(ctx context.Context) error {
+	if !c.isReplica && isReadOnlyRouting(ctx) {
+		return ErrReadOnlyRouting
+	}
+	return nil
+}
+
+// replicaConn returns the read-only replica connection opening it on first use or nil if no replica is available.
+func (c *conn) replicaConn(ctx context.Context) (*conn, error) {
+	if c.replica != nil {
+		if !c.replica.isBad() {
+			return c.replica, nil
+		}
+		c.replica.Close()
+		c.replica = nil
+	}
+	host, ok := replicaHost(c.topology)
+	if !ok || c.hostConnect == nil {
+		return nil, nil
+	}
+	attrs := c.attrs.clone()
+	attrs._failOnStandby = false // connecting to a standby host on purpose
+	dc, err := c.hostConnect(ctx, host, attrs)
+	if err != nil {
+		return nil, err
+	}
+	replica := dc.(*conn)
+	replica.isReplica = true
+	if _, err := replica.execDirect(ctx, setAccessModeReadOnly, true); err != nil {
+		replica.Close()
+		return nil, err
+	}
+	c.replica = replica
+	return replica, nil
+}
+
+/*
+routeQuery executes the query on the read-only replica connection in case read-only routing is requested by ctx.
+routed is false if the query was not routed and needs to be executed on the connection itself.
+*/
+func (c *conn) routeQuery(ctx context.Context, query string, nvargs []driver.NamedValue) (rows driver.Rows, routed bool, err error) {
+	if c.isReplica || !isReadOnlyRouting(ctx) {
+		return nil, false, nil
+	}
+	if c.inTx {
+		return nil, true, ErrReadOnlyRouting
+	}
+	replica, err := c.replicaConn(ctx)
+	if err != nil {
+		return nil, true, err
+	}
+	if replica == nil {
+		return nil, false, nil
+	}
+	rows, err = replica.replicaQuery(ctx, query, nvargs)
+	return rows, true, err
+}
+
+// replicaQuery executes a query on the replica connection.
+func (c *conn) replicaQuery(ctx context.Context, query string, nvargs []driver.NamedValue) (driver.Rows, error) {
+	if len(nvargs) == 0 {
+		return c.QueryContext(ctx, query, nil)
+	}
+	return c.preparedQuery(ctx, query, nvargs)
+}
+
+// stmtQueryResult is a query result closing its statement on close.
+type stmtQueryResult struct {
+	*queryResult
+	stmt *stmt
+}
+
+func (r *stmtQueryResult) Close() error {
+	return errors.Join(r.queryResult.Close(), r.stmt.Close())
+}
@@ -0,0 +1,45 @@
+package driver
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+)
+
+func TestReplicaHost(t *testing.T) {
+	topology := []TopologyHost{
+		{Host: "primary", Port: 30015, IsPrimary: true, IsCurrentSession: true},
+		{Host: "secondary", Port: 30015, IsStandby: true},
+	}
+	host, ok := replicaHost(topology)
+	if !ok || host != "secondary:30015" {
+		t.Fatalf("replica host %s %t - expected %s", host, ok, "secondary:30015")
+	}
+	if _, ok := replicaHost(topology[:1]); ok {
+		t.Fatal("no replica host expected")
+	}
+}
+
+func TestReadOnlyRouting(t *testing.T) {
+	ctx := WithReadOnlyRouting(context.Background())
+
+	c := &conn{attrs: newConnAttrs()}
+
+	// no replica available: query is executed on the connection itself
+	if _, routed, err := c.routeQuery(ctx, "select * from dummy", nil); routed || err != nil {
+		t.Fatalf("routed %t error %v - expected query not to be routed", routed, err)
+	}
+
+	// writes and transactions are never routed
+	if _, err := c.ExecContext(ctx, "insert into t values (1)", nil); !errors.Is(err, ErrReadOnlyRouting) {
+		t.Fatalf("exec error %v - expected %v", err, ErrReadOnlyRouting)
+	}
+	if _, err := c.BeginTx(ctx, driver.TxOptions{}); !errors.Is(err, ErrReadOnlyRouting) {
+		t.Fatalf("begin transaction error %v - expected %v", err, ErrReadOnlyRouting)
+	}
+	c.inTx = true
+	if _, err := c.QueryContext(ctx, "select * from dummy", nil); !errors.Is(err, ErrReadOnlyRouting) {
+		t.Fatalf("query in transaction error %v - expected %v", err, ErrReadOnlyRouting)
+	}
+}
@@ -0,0 +1,82 @@
+//go:build !unit
+
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+)
+
+func TestResetSession(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	sessionVariable := func(t *testing.T, db *sql.DB, key string) string {
+		var v sql.NullString
+		if err := db.QueryRow(sessionContextQuery(key)).Scan(&v); err != nil {
+			t.Fatal(err)
+		}
+		return v.String
+	}
+
+	setSessionVariable := func(t *testing.T, db *sql.DB, key, value string) {
+		conn, err := db.Conn(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close() // return connection to pool
+		if err := conn.Raw(func(driverConn any) error {
+			return driverConn.(Conn).SetSessionVariable(ctx, key, value)
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("callback", func(t *testing.T) {
+		t.Parallel()
+
+		numCalls := 0
+		connector := MT.NewConnector()
+		connector.SetOnResetSession(func(ctx context.Context, conn Conn) error {
+			numCalls++
+			_, err := conn.ExecPipeline(ctx, "unset 'RESET_SESSION_TEST'")
+			return err
+		})
+		db := sql.OpenDB(connector)
+		defer db.Close()
+		db.SetMaxOpenConns(1)
+
+		if _, err := db.Exec("set 'RESET_SESSION_TEST' = 'test'"); err != nil {
+			t.Fatal(err)
+		}
+		if v := sessionVariable(t, db, "RESET_SESSION_TEST"); v != "" {
+			t.Fatalf("session variable %q - expected %q", v, "")
+		}
+		if numCalls == 0 {
+			t.Fatal("reset session callback was not called")
+		}
+	})
+
+	t.Run("sessionVariables", func(t *testing.T) {
+		t.Parallel()
+
+		connector := MT.NewConnector()
+		connector.SetSessionVariables(SessionVariables{"k1": "v1"})
+		connector.SetResetSessionVariables(true)
+		db := sql.OpenDB(connector)
+		defer db.Close()
+		db.SetMaxOpenConns(1)
+
+		setSessionVariable(t, db, "k1", "o1")
+		setSessionVariable(t, db, "k2", "v2")
+
+		if v := sessionVariable(t, db, "k1"); v != "v1" {
+			t.Fatalf("session variable %q - expected %q", v, "v1")
+		}
+		if v := sessionVariable(t, db, "k2"); v != "" {
+			t.Fatalf("session variable %q - expected %q", v, "")
+		}
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql/driver"
 	"io"
 	"reflect"
+	"slices"
 
 	p "github.com/SAP/go-hdb/driver/internal/protocol"
 )
@@ -19,6 +20,7 @@ var (
 	_ driver.RowsColumnTypeNullable         = (*queryResult)(nil)
 	_ driver.RowsColumnTypePrecisionScale   = (*queryResult)(nil)
 	_ driver.RowsColumnTypeScanType         = (*queryResult)(nil)
+	_ Rows                                  = (*queryResult)(nil)
 	/*
 		currently not used
 		could be implemented as pointer to next queryResult (advancing by copying data from next)
@@ -38,9 +40,17 @@ type prepareResult struct {
 // isProcedureCall returns true if the statement is a call statement.
 func (pr *prepareResult) isProcedureCall() bool { return pr.fc.IsProcedureCall() }
 
+// isDDL returns true if the statement is a DDL statement.
+func (pr *prepareResult) isDDL() bool { return pr.fc.IsDDL() }
+
 // numField returns the number of parameter fields in a database statement.
 func (pr *prepareResult) numField() int { return len(pr.parameterFields) }
 
+// hasLobParameter returns true if the statement has lob parameters.
+func (pr *prepareResult) hasLobParameter() bool {
+	return slices.ContainsFunc(pr.parameterFields, func(f *p.ParameterField) bool { return f.IsLob() })
+}
+
 // NoResult is the driver.Rows drop-in replacement if driver Query or QueryRow is used for statements that do not return rows.
 var noResult = new(noResultType)
 
@@ -52,20 +62,52 @@ func (r *noResultType) Columns() []string              { return noColumns }
 func (r *noResultType) Close() error                   { return nil }
 func (r *noResultType) Next(dest []driver.Value) error { return io.EOF }
 
+// Rows enhances driver.Rows with go-hdb specific resultset functions.
+// It is available via sql.Conn.Raw querying the driver connection directly.
+type Rows interface {
+	driver.Rows
+	// EstimatedRowCount returns the estimated total number of rows of the resultset
+	// and false if no estimate is available.
+	EstimatedRowCount() (int64, bool)
+}
+
 // queryResult represents the resultset of a query.
 type queryResult struct {
 	// field alignment
-	fields       []*p.ResultField
-	fieldValues  []driver.Value
-	decodeErrors p.DecodeErrors
-	_columns     []string
-	lastErr      error
-	conn         *conn
-	rsID         uint64
-	pos          int
-	attrs        p.PartAttributes
+	fields            []*p.ResultField
+	fieldValues       []driver.Value
+	decodeErrors      p.DecodeErrors
+	_columns          []string
+	lastErr           error
+	conn              *conn
+	rsID              uint64
+	pos               int
+	estimatedRowCount int64
+	hasEstimate       bool
+	lobPrefetcher     *lobPrefetcher     // nil if lob prefetch is not enabled
+	stream            *p.ResultsetStream // nil if resultset streaming is not enabled
+	attrs             p.PartAttributes
+	ctx               context.Context // context of the query (cancels fetches), nil if not cancellable
+	row               []driver.Value  // current row (driver.RowsColumnScanner)
+}
+
+/*
+estimateRowCount returns the estimated total number of rows of a resultset.
+
+If the first resultset packet is the last one, the number of rows is known exactly. Otherwise no
+estimate is available, as query replies do not carry the total number of rows of a resultset.
+*/
+func estimateRowCount(lastPacket bool, numRow int) (int64, bool) {
+	if lastPacket {
+		return int64(numRow), true
+	}
+	return 0, false
 }
 
+// EstimatedRowCount implements the Rows interface.
+// As the value is only an estimate, the number of rows returned by Next might differ.
+func (qr *queryResult) EstimatedRowCount() (int64, bool) { return qr.estimatedRowCount, qr.hasEstimate }
+
 // Columns implements the driver.Rows interface.
 func (qr *queryResult) Columns() []string {
 	if qr._columns == nil {
@@ -80,7 +122,10 @@ func (qr *queryResult) Columns() []string {
 
 // Close implements the driver.Rows interface.
 func (qr *queryResult) Close() error {
-	if qr.attrs.ResultsetClosed() {
+	if qr.lobPrefetcher != nil {
+		qr.lobPrefetcher.wait()
+	}
+	if qr.attrs.ResultsetClosed() || qr.noResultset() {
 		return nil
 	}
 	// if lastError is set, attrs are nil
@@ -90,7 +135,15 @@ func (qr *queryResult) Close() error {
 	return qr.conn.closeResultsetID(context.Background(), qr.rsID)
 }
 
+// noResultset returns true if the database did not provide a resultset (e.g. NULL table output parameter of a procedure call).
+func (qr *queryResult) noResultset() bool {
+	return qr.rsID == 0 && qr.fieldValues == nil && qr.lastErr == nil
+}
+
 func (qr *queryResult) numRow() int {
+	if qr.stream != nil {
+		return qr.stream.NumRow()
+	}
 	if len(qr.fieldValues) == 0 {
 		return 0
 	}
@@ -104,11 +157,15 @@ func (qr *queryResult) copyRow(idx int, dest []driver.Value) {
 
 // Next implements the driver.Rows interface.
 func (qr *queryResult) Next(dest []driver.Value) error {
+	if qr.lobPrefetcher != nil {
+		qr.lobPrefetcher.wait() // the connection is needed and the prefetched row is going to be returned
+	}
+
 	if qr.pos >= qr.numRow() {
-		if qr.attrs.LastPacket() {
+		if qr.attrs.LastPacket() || qr.noResultset() {
 			return io.EOF
 		}
-		if err := qr.conn.fetchNext(context.Background(), qr); err != nil {
+		if err := qr.conn.fetchNextContext(qr.ctx, qr); err != nil {
 			qr.lastErr = err // fieldValues and attrs are nil
 			return err
 		}
@@ -118,13 +175,31 @@ func (qr *queryResult) Next(dest []driver.Value) error {
 		qr.pos = 0
 	}
 
-	qr.copyRow(qr.pos, dest)
-	err := qr.decodeErrors.RowError(qr.pos)
+	var err error
+	if qr.stream != nil {
+		err = qr.stream.Next(dest)
+	} else {
+		qr.copyRow(qr.pos, dest)
+		err = qr.decodeErrors.RowError(qr.pos)
+	}
 	qr.pos++
 
+	if qr.lobPrefetcher != nil {
+		for _, v := range dest {
+			if descr, ok := v.(*p.LobOutDescr); ok {
+				descr.SetDecoder(qr.lobPrefetcher.decoder(descr))
+			}
+		}
+		if qr.pos < qr.numRow() {
+			cols := len(qr.fields)
+			qr.lobPrefetcher.start(qr.fieldValues[qr.pos*cols : (qr.pos+1)*cols])
+		}
+		return err
+	}
+
 	for _, v := range dest {
 		if v, ok := v.(p.LobDecoderSetter); ok {
-			v.SetDecoder(qr.conn.decodeLob)
+			v.SetDecoder(qr.conn.lobDecoder(qr.ctx))
 		}
 	}
 	return err
@@ -158,6 +233,8 @@ type callResult struct { // call output parameters
 	decodeErrors p.DecodeErrors
 	_columns     []string
 	eof          bool
+	ctx          context.Context // context of the call
+	row          []driver.Value  // current row (driver.RowsColumnScanner)
 }
 
 // Columns implements the driver.Rows interface.
@@ -183,7 +260,7 @@ func (cr *callResult) Next(dest []driver.Value) error {
 	cr.eof = true
 	for _, v := range dest {
 		if v, ok := v.(p.LobDecoderSetter); ok {
-			v.SetDecoder(cr.conn.decodeLob)
+			v.SetDecoder(cr.conn.lobDecoder(cr.ctx))
 		}
 	}
 	return err
@@ -0,0 +1,81 @@
+package driver
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// DefaultRetryCodes returns the database error codes classified as transient by default (see RetryPolicy).
+func DefaultRetryCodes() []int {
+	return []int{HdbErrTxRolledBack, HdbErrTxRolledBackLockTimeout, HdbErrTxRolledBackDeadlock}
+}
+
+/*
+RetryPolicy defines the automatic retry of queries failing with transient database errors (see SetRetryPolicy).
+
+The classification of errors is table driven: a database error is transient if its code is contained in Codes.
+*/
+type RetryPolicy struct {
+	MaxRetries int           // Maximum number of retries per query (0: retries disabled, default).
+	Backoff    time.Duration // Wait time before the first retry, doubled for every further retry.
+	Codes      []int         // Database error codes classified as transient (nil: DefaultRetryCodes).
+}
+
+func (p RetryPolicy) String() string {
+	return fmt.Sprintf("maxRetries %d backoff %s codes %v", p.MaxRetries, p.Backoff, p.codes())
+}
+
+func (p RetryPolicy) clone() RetryPolicy { p.Codes = slices.Clone(p.Codes); return p }
+
+func (p RetryPolicy) codes() []int {
+	if p.Codes == nil {
+		return DefaultRetryCodes()
+	}
+	return p.Codes
+}
+
+// isTransient returns true if err contains a database error (warnings excluded) classified as transient.
+func (p RetryPolicy) isTransient(err error) bool {
+	codes := p.codes()
+	return hasErrorCode(err, func(code int) bool { return slices.Contains(codes, code) })
+}
+
+// retry calls fn and retries it up to maxRetries times with exponential backoff as long as fn fails with an error
+// classified as transient. retried is called before every retry. Waiting for a retry is stopped by the
+// cancellation of ctx returning the last error of fn.
+func retry(ctx context.Context, maxRetries int, backoff time.Duration, isTransient func(err error) bool, retried func(), fn func() error) error {
+	err := fn()
+	for i := 0; i < maxRetries && err != nil && isTransient(err); i++ {
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		retried()
+		backoff *= 2
+		err = fn()
+	}
+	return err
+}
+
+/*
+retryQuery calls the query function fn applying the retry policy of the connection if retryable is set.
+
+Only queries outside of transactions (auto-commit mode) are retried: a failing query got rolled back by the database
+server and can be executed again, whereas inside of a transaction the transaction needs to be retried as a whole
+(see IsSerializationFailure), so that queries within transactions are never retried silently. Queries with lob
+arguments are not retryable as the lob readers are consumed by the first execution.
+*/
+func (c *conn) retryQuery(ctx context.Context, retryable bool, fn func() error) error {
+	policy := c.attrs._retryPolicy
+	if !retryable || c.inTx || policy.MaxRetries == 0 {
+		return fn()
+	}
+	return retry(ctx, policy.MaxRetries, policy.Backoff, policy.isTransient, func() {
+		c.collector.msgCh <- counterMsg{idx: counterRetries, v: 1}
+	}, fn)
+}
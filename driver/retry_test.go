@@ -0,0 +1,72 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRetry(t *testing.T) {
+	errTransient := errors.New("transient error")
+	errPermanent := errors.New("permanent error")
+	isTransient := func(err error) bool { return errors.Is(err, errTransient) }
+
+	testData := []struct {
+		errs       []error // errors returned by subsequent calls
+		maxRetries int
+		err        error
+		calls      int
+	}{
+		{[]error{nil}, 3, nil, 1},
+		{[]error{errPermanent}, 3, errPermanent, 1},
+		{[]error{errTransient, errTransient, nil}, 3, nil, 3},
+		{[]error{errTransient, errPermanent}, 3, errPermanent, 2},
+		{[]error{errTransient, errTransient, errTransient, errTransient}, 3, errTransient, 4},
+		{[]error{errTransient, nil}, 0, errTransient, 1},
+	}
+
+	for i, d := range testData {
+		calls, retries := 0, 0
+		err := retry(context.Background(), d.maxRetries, time.Millisecond, isTransient, func() { retries++ }, func() error {
+			err := d.errs[calls]
+			calls++
+			return err
+		})
+		if !errors.Is(err, d.err) {
+			t.Fatalf("%d: error %v - expected %v", i, err, d.err)
+		}
+		if calls != d.calls || retries != d.calls-1 {
+			t.Fatalf("%d: calls %d retries %d - expected %d %d", i, calls, retries, d.calls, d.calls-1)
+		}
+	}
+
+	// cancelled context stops retries.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls := 0
+	if err := retry(ctx, 3, time.Hour, isTransient, func() {}, func() error { calls++; return errTransient }); !errors.Is(err, errTransient) || calls != 1 {
+		t.Fatalf("error %v calls %d - expected %v %d", err, calls, errTransient, 1)
+	}
+}
+
+func TestRetryPolicy(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 1}
+	// no database errors
+	for _, err := range []error{nil, errors.New("test error"), errCancelled} {
+		if policy.isTransient(err) {
+			t.Fatalf("error %v: unexpected transient error", err)
+		}
+	}
+
+	codes := []int{HdbErrTxRolledBackLockTimeout}
+	policy = RetryPolicy{Codes: codes}
+	clone := policy.clone()
+	codes[0] = HdbErrUniqueConstraintViolated
+	if clone.Codes[0] != HdbErrTxRolledBackLockTimeout {
+		t.Fatal("retry policy codes not cloned")
+	}
+	if (RetryPolicy{}).String() != "maxRetries 0 backoff 0s codes [129 131 133]" {
+		t.Fatalf("unexpected default retry policy %s", RetryPolicy{})
+	}
+}
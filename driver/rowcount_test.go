@@ -0,0 +1,23 @@
+package driver
+
+import "testing"
+
+func TestEstimateRowCount(t *testing.T) {
+	testData := []struct {
+		lastPacket bool
+		numRow     int
+		count      int64
+		ok         bool
+	}{
+		{false, 32, 0, false}, // more packets to fetch
+		{true, 32, 32, true},  // complete resultset in first packet
+		{true, 0, 0, true},    // empty resultset
+	}
+
+	for i, d := range testData {
+		count, ok := estimateRowCount(d.lastPacket, d.numRow)
+		if count != d.count || ok != d.ok {
+			t.Fatalf("%d: estimated row count %d %t - expected %d %t", i, count, ok, d.count, d.ok)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package driver
+
+// numRowSizeSample is the number of recent resultset replies the row size estimate is based on.
+const numRowSizeSample = 8
+
+// rowSizeEstimate is a rolling estimate of the average number of bytes per row.
+// It is calculated from the variable part length and the number of rows of the most recent
+// resultset replies and might be used to tune the fetch size.
+type rowSizeEstimate struct {
+	numByte [numRowSizeSample]int
+	numRow  [numRowSizeSample]int
+	idx     int
+}
+
+func (e *rowSizeEstimate) add(numByte, numRow int) {
+	if numRow <= 0 {
+		return
+	}
+	e.numByte[e.idx], e.numRow[e.idx] = numByte, numRow
+	e.idx = (e.idx + 1) % numRowSizeSample
+}
+
+// bytesPerRow returns the estimated number of bytes per row or zero if no rows were read yet.
+func (e *rowSizeEstimate) bytesPerRow() float64 {
+	var numByte, numRow int
+	for i := 0; i < numRowSizeSample; i++ {
+		numByte += e.numByte[i]
+		numRow += e.numRow[i]
+	}
+	if numRow == 0 {
+		return 0
+	}
+	return float64(numByte) / float64(numRow)
+}
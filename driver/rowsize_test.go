@@ -0,0 +1,36 @@
+package driver
+
+import (
+	"testing"
+)
+
+func TestRowSizeEstimate(t *testing.T) {
+	e := &rowSizeEstimate{}
+
+	if v := e.bytesPerRow(); v != 0 {
+		t.Fatalf("bytes per row %f - expected 0", v)
+	}
+
+	e.add(1000, 0) // replies without rows are ignored
+	if v := e.bytesPerRow(); v != 0 {
+		t.Fatalf("bytes per row %f - expected 0", v)
+	}
+
+	e.add(1000, 10)
+	if v := e.bytesPerRow(); v != 100 {
+		t.Fatalf("bytes per row %f - expected 100", v)
+	}
+
+	e.add(3000, 10)
+	if v := e.bytesPerRow(); v != 200 {
+		t.Fatalf("bytes per row %f - expected 200", v)
+	}
+
+	// older fetches drop out of the estimate
+	for i := 0; i < numRowSizeSample; i++ {
+		e.add(500, 10)
+	}
+	if v := e.bytesPerRow(); v != 50 {
+		t.Fatalf("bytes per row %f - expected 50", v)
+	}
+}
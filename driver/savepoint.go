@@ -0,0 +1,41 @@
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+)
+
+// savepoint sql statements.
+const (
+	sqlSavepoint           = "savepoint"
+	sqlRollbackToSavepoint = "rollback to savepoint"
+	sqlReleaseSavepoint    = "release savepoint"
+)
+
+func execSavepoint(ctx context.Context, tx *sql.Tx, stmt string, name Identifier) error {
+	_, err := tx.ExecContext(ctx, strings.Join([]string{stmt, name.String()}, " "))
+	return err
+}
+
+/*
+Savepoint sets the savepoint name within the transaction tx.
+
+Savepoints allow to roll back a part of a transaction (see RollbackToSavepoint), so that e.g. a failing statement
+of a long running transaction does not discard the work done before. Setting, rolling back to or releasing a
+savepoint does not end the transaction: tx needs to be committed or rolled back as usual.
+*/
+func Savepoint(ctx context.Context, tx *sql.Tx, name Identifier) error {
+	return execSavepoint(ctx, tx, sqlSavepoint, name)
+}
+
+// RollbackToSavepoint rolls back the changes of transaction tx done after setting the savepoint name.
+// The savepoint remains set, so that it can be rolled back to again.
+func RollbackToSavepoint(ctx context.Context, tx *sql.Tx, name Identifier) error {
+	return execSavepoint(ctx, tx, sqlRollbackToSavepoint, name)
+}
+
+// ReleaseSavepoint releases the savepoint name of transaction tx keeping the changes done after setting the savepoint.
+func ReleaseSavepoint(ctx context.Context, tx *sql.Tx, name Identifier) error {
+	return execSavepoint(ctx, tx, sqlReleaseSavepoint, name)
+}
@@ -0,0 +1,79 @@
+//go:build !unit
+
+package driver
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestSavepoint(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := MT.DB()
+
+	table := RandomIdentifier("savepoint_")
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("create table %s (i integer primary key)", table)); err != nil {
+		t.Fatalf("create table failed: %s", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	insert := func(i int) error {
+		_, err := tx.ExecContext(ctx, fmt.Sprintf("insert into %s values (?)", table), i)
+		return err
+	}
+
+	if err := insert(1); err != nil {
+		t.Fatal(err)
+	}
+	sp := Identifier("sp1")
+	if err := Savepoint(ctx, tx, sp); err != nil {
+		t.Fatal(err)
+	}
+	if err := insert(2); err != nil {
+		t.Fatal(err)
+	}
+	if err := insert(1); !IsUniqueConstraintViolation(err) { // failing statement does not end the transaction
+		t.Fatalf("error %v - expected unique constraint violation", err)
+	}
+	if err := RollbackToSavepoint(ctx, tx, sp); err != nil { // discard insert of 2
+		t.Fatal(err)
+	}
+	if err := insert(3); err != nil {
+		t.Fatal(err)
+	}
+	if err := ReleaseSavepoint(ctx, tx, sp); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := db.QueryContext(ctx, fmt.Sprintf("select i from %s order by i", table))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	var values []int
+	for rows.Next() {
+		var i int
+		if err := rows.Scan(&i); err != nil {
+			t.Fatal(err)
+		}
+		values = append(values, i)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if expected := []int{1, 3}; !slices.Equal(values, expected) {
+		t.Fatalf("values %v - expected %v", values, expected)
+	}
+}
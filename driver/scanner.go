@@ -144,7 +144,16 @@ func NewStructScanner[S any]() (*StructScanner[S], error) {
 		return nil, fmt.Errorf("invalid type %s", rt.Kind())
 	}
 
-	tagger, hasTagger := any(s).(Tagger)
+	columns, nameColumnMap, err := newStructColumns(rt, s)
+	if err != nil {
+		return nil, err
+	}
+	return &StructScanner[S]{columns: columns, nameColumnMap: nameColumnMap}, nil
+}
+
+// newStructColumns returns the columns of the exported fields of struct type rt (tags might be provided by s implementing Tagger).
+func newStructColumns(rt reflect.Type, s any) (structColumns, map[string]*structColumn, error) {
+	tagger, hasTagger := s.(Tagger)
 
 	columns := []*structColumn{}
 	nameColumnMap := map[string]*structColumn{}
@@ -163,13 +172,13 @@ func NewStructScanner[S any]() (*StructScanner[S], error) {
 			}
 			name := column.Name()
 			if _, ok := nameColumnMap[name]; ok {
-				return nil, fmt.Errorf("duplicate column name %s", name)
+				return nil, nil, fmt.Errorf("duplicate column name %s", name)
 			}
 			columns = append(columns, column)
 			nameColumnMap[name] = column
 		}
 	}
-	return &StructScanner[S]{columns: columns, nameColumnMap: nameColumnMap}, nil
+	return columns, nameColumnMap, nil
 }
 
 // ScanRow scans the field values of the first row in rows into struct s of type *S and closes rows.
@@ -204,7 +213,7 @@ func (sc StructScanner[S]) Scan(rows *sql.Rows, s *S) error {
 		if !ok {
 			return fmt.Errorf("field for column name %s not found", name)
 		}
-		values[i] = rv.FieldByIndex(column.fieldIndex).Addr().Interface()
+		values[i] = scanDest(rv.FieldByIndex(column.fieldIndex).Addr().Interface())
 	}
 	return rows.Scan(values...)
 }
@@ -85,12 +85,62 @@ func TestScanStruct(t *testing.T) {
 		return nil
 	}
 
+	testScanStructFunc := func() error {
+		// testScanRow: tagged mapping (A, B and Y) and positional mapping (C).
+		tagged := new(testScanRow)
+		// positional mapping by field order.
+		positional := new(struct {
+			S string
+			I int
+			C bool
+			X string
+		})
+
+		rows, err := db.Query(fmt.Sprintf(`select "x", "C", "i", "s" from %s`, tableName))
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			if err := ScanStruct(rows, tagged); err != nil {
+				return err
+			}
+			if *tagged != testRow {
+				return fmt.Errorf("row %v not equal to %v", tagged, testRow)
+			}
+			if err := ScanStruct(rows, positional); err == nil {
+				return fmt.Errorf("type mismatch error expected")
+			}
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		rows, err = db.Query(fmt.Sprintf(`select "s", "i", "C", "x" from %s`, tableName))
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			if err := ScanStruct(rows, positional); err != nil {
+				return err
+			}
+			if positional.S != testRow.A || positional.I != testRow.B || positional.C != testRow.C || positional.X != testRow.Y {
+				return fmt.Errorf("row %v not equal to %v", positional, testRow)
+			}
+		}
+		return rows.Err()
+	}
+
 	tests := []struct {
 		name string
 		fn   func() error
 	}{
 		{"testScanStructRows", testScanStructRows},
 		{"testScanStructRow", testScanStructRow},
+		{"testScanStructFunc", testScanStructFunc},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,209 @@
+package driver
+
+import (
+	"database/sql"
+	"encoding"
+	"fmt"
+	"reflect"
+
+	hdbreflect "github.com/SAP/go-hdb/driver/internal/reflect"
+)
+
+/*
+ScanStruct scans the field values of the current row of rows into struct s of type *S.
+
+The exported fields of the struct are mapped to the result columns
+  - by column name in case the field is tagged with a column name (see StructScanner and Tagger),
+  - by position (field order) otherwise, skipping the columns mapped by name.
+
+An error is returned in case the number of fields does not match the number of result columns or
+in case a result column cannot be scanned into the type of the mapped field.
+*/
+func ScanStruct[S any](rows *sql.Rows, s *S) error {
+	mapping, err := structMapping(rows, s)
+	if err != nil {
+		return err
+	}
+	return scanStruct(rows, s, mapping)
+}
+
+// structMapping returns the struct column per result column of rows.
+func structMapping[S any](rows *sql.Rows, s *S) (structColumns, error) {
+	rt := reflect.TypeOf(s).Elem()
+	if rt.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("invalid type %s", rt.Kind())
+	}
+	columns, _, err := newStructColumns(rt, s)
+	if err != nil {
+		return nil, err
+	}
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(columnTypes))
+	scanTypes := make([]reflect.Type, len(columnTypes))
+	for i, columnType := range columnTypes {
+		names[i], scanTypes[i] = columnType.Name(), columnType.ScanType()
+	}
+	return mapStructColumns(columns, names, scanTypes)
+}
+
+// scanStruct scans the field values of the current row of rows into s by mapping.
+func scanStruct[S any](rows *sql.Rows, s *S, mapping structColumns) error {
+	rv := reflect.ValueOf(s).Elem()
+	values := make([]any, len(mapping))
+	for i, column := range mapping {
+		values[i] = scanDest(rv.FieldByIndex(column.fieldIndex).Addr().Interface())
+	}
+	return rows.Scan(values...)
+}
+
+/*
+ScanStructs scans all remaining rows of rows into a slice of structs of type S (see ScanStruct) and closes rows.
+It can be used e.g. to retrieve the values of table output parameters of stored procedures (sql.Rows scan
+destinations), validating that the number and types of the table type columns match the struct fields.
+*/
+func ScanStructs[S any](rows *sql.Rows) ([]S, error) {
+	defer rows.Close()
+	var s S
+	// the mapping of the result columns is the same for all rows.
+	mapping, err := structMapping(rows, &s)
+	if err != nil {
+		return nil, err
+	}
+	var structs []S
+	for rows.Next() {
+		var s S
+		if err := scanStruct(rows, &s, mapping); err != nil {
+			return nil, err
+		}
+		structs = append(structs, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return structs, nil
+}
+
+// mapStructColumns returns the struct column per result column.
+func mapStructColumns(columns structColumns, names []string, scanTypes []reflect.Type) (structColumns, error) {
+	if len(columns) != len(names) {
+		return nil, fmt.Errorf("number of struct fields %d does not match number of result columns %d", len(columns), len(names))
+	}
+	mapping := make(structColumns, len(names))
+	// tagged fields: map by name.
+	var positional structColumns
+	for _, column := range columns {
+		if column.sqlName == "" {
+			positional = append(positional, column)
+			continue
+		}
+		i := -1
+		for j, name := range names {
+			if name == column.sqlName {
+				i = j
+				break
+			}
+		}
+		if i == -1 {
+			return nil, fmt.Errorf("result column %s of field %s not found", column.sqlName, column.fieldName)
+		}
+		if mapping[i] != nil {
+			return nil, fmt.Errorf("result column %s mapped by fields %s and %s", names[i], mapping[i].fieldName, column.fieldName)
+		}
+		mapping[i] = column
+	}
+	// untagged fields: map by position.
+	for i := range mapping {
+		if mapping[i] == nil {
+			mapping[i], positional = positional[0], positional[1:]
+		}
+	}
+	for i, column := range mapping {
+		if !scanCompatible(scanTypes[i], column.fieldType) {
+			return nil, fmt.Errorf("result column %s of scan type %s cannot be scanned into field %s of type %s", names[i], scanTypes[i], column.fieldName, column.fieldType)
+		}
+	}
+	return mapping, nil
+}
+
+type scanKind int
+
+const (
+	scanKindOther scanKind = iota
+	scanKindBool
+	scanKindNumber
+	scanKindString
+	scanKindTime
+)
+
+var (
+	scannerType         = hdbreflect.TypeFor[sql.Scanner]()
+	textUnmarshalerType = hdbreflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+func scanKindOf(typ reflect.Type) scanKind {
+	if typ == timeType {
+		return scanKindTime
+	}
+	switch typ.Kind() {
+	case reflect.Bool:
+		return scanKindBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return scanKindNumber
+	case reflect.String:
+		return scanKindString
+	case reflect.Slice:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			return scanKindString
+		}
+	}
+	return scanKindOther
+}
+
+// nullValueType returns the value type of sql null types like sql.NullInt64 (first field), typ otherwise.
+func nullValueType(typ reflect.Type) reflect.Type {
+	if typ.Kind() != reflect.Struct || typ.NumField() != 2 {
+		return typ
+	}
+	if f, ok := typ.FieldByName("Valid"); !ok || f.Type.Kind() != reflect.Bool || f.Index[0] != 1 {
+		return typ
+	}
+	return typ.Field(0).Type
+}
+
+// scanCompatible returns false if values of scanType cannot be scanned into a field of fieldType.
+// The check is conservative: in case of doubt scanning is left to sql.Rows.Scan.
+func scanCompatible(scanType, fieldType reflect.Type) bool {
+	if scanType == nil {
+		return true
+	}
+	for fieldType.Kind() == reflect.Pointer {
+		fieldType = fieldType.Elem()
+	}
+	if fieldType.Kind() == reflect.Interface {
+		return true
+	}
+	if fieldType != timeType {
+		ptrType := reflect.PointerTo(fieldType)
+		if ptrType.Implements(scannerType) || ptrType.Implements(textUnmarshalerType) {
+			return true
+		}
+	}
+	fieldKind := scanKindOf(fieldType)
+	switch scanKindOf(nullValueType(scanType)) {
+	case scanKindBool:
+		return fieldKind == scanKindBool || fieldKind == scanKindString
+	case scanKindNumber:
+		return fieldKind == scanKindNumber || fieldKind == scanKindBool || fieldKind == scanKindString
+	case scanKindString:
+		return fieldKind != scanKindTime
+	case scanKindTime:
+		return fieldKind == scanKindTime || fieldKind == scanKindString
+	default:
+		return true
+	}
+}
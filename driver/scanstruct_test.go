@@ -0,0 +1,108 @@
+package driver
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+
+	hdbreflect "github.com/SAP/go-hdb/driver/internal/reflect"
+)
+
+type testPositionalRow struct {
+	A string
+	B int
+	C sql.NullTime
+}
+
+type testTaggedRow struct {
+	A string `sql:"x"`
+	B int
+	C *bool `sql:"z"`
+}
+
+func testMapStructColumns[S any](t *testing.T, names []string, scanTypes []reflect.Type) ([]string, error) {
+	t.Helper()
+	columns, _, err := newStructColumns(hdbreflect.TypeFor[S](), new(S))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mapping, err := mapStructColumns(columns, names, scanTypes)
+	if err != nil {
+		return nil, err
+	}
+	fieldNames := make([]string, len(mapping))
+	for i, column := range mapping {
+		fieldNames[i] = column.fieldName
+	}
+	return fieldNames, nil
+}
+
+func TestMapStructColumns(t *testing.T) {
+	stringType := hdbreflect.TypeFor[string]()
+	int32Type := hdbreflect.TypeFor[int32]()
+	boolType := hdbreflect.TypeFor[bool]()
+	nullTimeType := hdbreflect.TypeFor[sql.NullTime]()
+
+	t.Run("positional", func(t *testing.T) {
+		fieldNames, err := testMapStructColumns[testPositionalRow](t, []string{"s", "i", "t"}, []reflect.Type{stringType, int32Type, hdbreflect.TypeFor[time.Time]()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(fieldNames, []string{"A", "B", "C"}) {
+			t.Fatalf("fields %v - expected %v", fieldNames, []string{"A", "B", "C"})
+		}
+	})
+
+	t.Run("tagged", func(t *testing.T) {
+		fieldNames, err := testMapStructColumns[testTaggedRow](t, []string{"z", "y", "x"}, []reflect.Type{boolType, int32Type, stringType})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(fieldNames, []string{"C", "B", "A"}) {
+			t.Fatalf("fields %v - expected %v", fieldNames, []string{"C", "B", "A"})
+		}
+	})
+
+	t.Run("tagColumnNotFound", func(t *testing.T) {
+		if _, err := testMapStructColumns[testTaggedRow](t, []string{"a", "b", "c"}, []reflect.Type{stringType, int32Type, boolType}); err == nil {
+			t.Fatal("column not found error expected")
+		}
+	})
+
+	t.Run("countMismatch", func(t *testing.T) {
+		if _, err := testMapStructColumns[testPositionalRow](t, []string{"s", "i"}, []reflect.Type{stringType, int32Type}); err == nil {
+			t.Fatal("count mismatch error expected")
+		}
+	})
+
+	t.Run("typeMismatch", func(t *testing.T) {
+		if _, err := testMapStructColumns[testPositionalRow](t, []string{"s", "i", "t"}, []reflect.Type{stringType, nullTimeType, nullTimeType}); err == nil {
+			t.Fatal("type mismatch error expected")
+		}
+	})
+}
+
+func TestScanCompatible(t *testing.T) {
+	tests := []struct {
+		scanType, fieldType reflect.Type
+		compatible          bool
+	}{
+		{hdbreflect.TypeFor[int64](), hdbreflect.TypeFor[int](), true},
+		{hdbreflect.TypeFor[sql.NullInt64](), hdbreflect.TypeFor[*int](), true},
+		{hdbreflect.TypeFor[sql.NullInt64](), hdbreflect.TypeFor[sql.NullInt64](), true},
+		{hdbreflect.TypeFor[int64](), hdbreflect.TypeFor[string](), true},
+		{hdbreflect.TypeFor[int64](), hdbreflect.TypeFor[time.Time](), false},
+		{hdbreflect.TypeFor[bool](), hdbreflect.TypeFor[int](), false},
+		{hdbreflect.TypeFor[string](), hdbreflect.TypeFor[float64](), true},
+		{hdbreflect.TypeFor[string](), hdbreflect.TypeFor[time.Time](), false},
+		{hdbreflect.TypeFor[time.Time](), hdbreflect.TypeFor[sql.NullTime](), true},
+		{hdbreflect.TypeFor[Decimal](), hdbreflect.TypeFor[*Decimal](), true},
+		{hdbreflect.TypeFor[float64](), hdbreflect.TypeFor[any](), true},
+	}
+	for _, test := range tests {
+		if compatible := scanCompatible(test.scanType, test.fieldType); compatible != test.compatible {
+			t.Fatalf("scan type %s field type %s: compatible %t - expected %t", test.scanType, test.fieldType, compatible, test.compatible)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package driver
+
+import "testing"
+
+func TestSessionCookieReconnect(t *testing.T) {
+	attrs := &authAttrs{}
+	attrs.SetPassword("ey.dummy.token") // JWT token as password
+
+	if attrs.cookieAuth() != nil {
+		t.Fatal("unexpected session cookie authentication before initial authentication")
+	}
+
+	// capture the session cookie of the initial authentication
+	attrs.setCookie("USER123", []byte("5be8f43e064e0589ce07ba9de6fce107"))
+
+	// reconnect uses the captured cookie
+	authHnd := attrs.cookieAuth()
+	if authHnd == nil {
+		t.Fatal("session cookie authentication expected after cookie was captured")
+	}
+	if expected := "logonname USER123"; authHnd.String() != expected {
+		t.Fatalf("got %s - expected %s", authHnd.String(), expected)
+	}
+
+	// cookie rejected: fall back to full authentication
+	attrs.invalidateCookie()
+	if attrs.cookieAuth() != nil {
+		t.Fatal("session cookie authentication not expected after cookie was rejected")
+	}
+	if attrs.authHnd() == nil {
+		t.Fatal("full authentication expected")
+	}
+}
+
+func TestSessionCookieDisabled(t *testing.T) {
+	attrs := &authAttrs{}
+	attrs.setCookie("USER123", []byte("5be8f43e064e0589ce07ba9de6fce107"))
+	if attrs.cookieAuth() == nil {
+		t.Fatal("session cookie authentication expected")
+	}
+
+	// disabling drops the captured cookie
+	attrs.SetSessionCookieDisabled(true)
+	if attrs.cookieAuth() != nil {
+		t.Fatal("session cookie authentication not expected if disabled")
+	}
+
+	// cookies are not captured if disabled
+	attrs.setCookie("USER123", []byte("5be8f43e064e0589ce07ba9de6fce107"))
+	if attrs.cookieAuth() != nil {
+		t.Fatal("session cookie captured although disabled")
+	}
+
+	attrs.SetSessionCookieDisabled(false)
+	attrs.setCookie("USER123", []byte("5be8f43e064e0589ce07ba9de6fce107"))
+	if attrs.cookieAuth() == nil {
+		t.Fatal("session cookie authentication expected after enabling")
+	}
+}
@@ -0,0 +1,74 @@
+package driver
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
+
+// sessionTimezoneOffsetQuery selects the UTC offset of the session time zone in seconds.
+const sessionTimezoneOffsetQuery = "select seconds_between(current_utctimestamp, current_timestamp) from dummy"
+
+// sessionTimezoneNameQuery selects the time zone name of the database host of the session.
+const sessionTimezoneNameQuery = "select value from m_host_information where key = 'timezone_name' and host = (select host from m_connections where own = 'TRUE')"
+
+// sessionLocation returns the location of the database session time zone (see SetSessionTimezone).
+func (c *conn) sessionLocation(ctx context.Context) (*time.Location, error) {
+	v, err := c.querySessionValue(ctx, sessionTimezoneOffsetQuery)
+	if err != nil {
+		return nil, err
+	}
+	offset, ok := v.(int64)
+	if !ok {
+		return nil, fmt.Errorf("invalid session time zone offset %v", v)
+	}
+	// the time zone name is optional (e.g. missing privilege to select the host information): use the offset instead.
+	var name string
+	if v, err := c.querySessionValue(ctx, sessionTimezoneNameQuery); err == nil {
+		if b, ok := v.([]byte); ok {
+			name = string(b)
+		}
+	}
+	return namedLocation(name, int(offset), time.Now()), nil
+}
+
+// querySessionValue returns the value of the first field of the first row selected by query.
+func (c *conn) querySessionValue(ctx context.Context, query string) (driver.Value, error) {
+	rows, err := c.queryDirect(ctx, query, !c.inTx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	dest := make([]driver.Value, 1)
+	if err := rows.Next(dest); err != nil {
+		return nil, err
+	}
+	return dest[0], nil
+}
+
+// namedLocation returns the location loaded by name, so that daylight saving time is respected.
+// If the location cannot be loaded or if its UTC offset at time now differs from offset (e.g. ambiguous
+// abbreviations), the location with the fixed UTC offset is returned.
+func namedLocation(name string, offset int, now time.Time) *time.Location {
+	if name != "" {
+		if loc, err := time.LoadLocation(name); err == nil {
+			if _, locOffset := now.In(loc).Zone(); locOffset == offset {
+				return loc
+			}
+		}
+	}
+	return offsetLocation(offset)
+}
+
+// offsetLocation returns a location with the UTC offset in seconds named like UTC+02:00.
+func offsetLocation(offset int) *time.Location {
+	if offset == 0 {
+		return time.UTC
+	}
+	sign, abs := '+', offset
+	if offset < 0 {
+		sign, abs = '-', -offset
+	}
+	return time.FixedZone(fmt.Sprintf("UTC%c%02d:%02d", sign, abs/3600, abs%3600/60), offset)
+}
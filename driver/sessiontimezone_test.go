@@ -0,0 +1,58 @@
+package driver
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata" // location database independent of the test system
+)
+
+func TestOffsetLocation(t *testing.T) {
+	testData := []struct {
+		offset int
+		name   string
+	}{
+		{0, "UTC"},
+		{2 * 60 * 60, "UTC+02:00"},
+		{5*60*60 + 30*60, "UTC+05:30"},
+		{-8 * 60 * 60, "UTC-08:00"},
+	}
+	for _, d := range testData {
+		loc := offsetLocation(d.offset)
+		if loc.String() != d.name {
+			t.Fatalf("offset %d: location %s - expected %s", d.offset, loc, d.name)
+		}
+		if _, offset := time.Date(2024, time.January, 1, 0, 0, 0, 0, loc).Zone(); offset != d.offset {
+			t.Fatalf("location %s: offset %d - expected %d", loc, offset, d.offset)
+		}
+	}
+}
+
+func TestNamedLocation(t *testing.T) {
+	winter := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	summer := time.Date(2024, time.July, 1, 0, 0, 0, 0, time.UTC)
+
+	testData := []struct {
+		name   string
+		offset int
+		now    time.Time
+		loc    string
+	}{
+		{"Europe/Berlin", 60 * 60, winter, "Europe/Berlin"},
+		{"Europe/Berlin", 2 * 60 * 60, summer, "Europe/Berlin"},
+		{"Europe/Berlin", 5 * 60 * 60, winter, "UTC+05:00"}, // offset mismatch
+		{"CEST", 2 * 60 * 60, summer, "UTC+02:00"},          // cannot be loaded
+		{"", -8 * 60 * 60, winter, "UTC-08:00"},
+	}
+	for _, d := range testData {
+		loc := namedLocation(d.name, d.offset, d.now)
+		if loc.String() != d.loc {
+			t.Fatalf("name %s offset %d: location %s - expected %s", d.name, d.offset, loc, d.loc)
+		}
+	}
+
+	// daylight saving time is respected by named locations.
+	loc := namedLocation("Europe/Berlin", 60*60, winter)
+	if _, offset := summer.In(loc).Zone(); offset != 2*60*60 {
+		t.Fatalf("location %s: summer offset %d - expected %d", loc, offset, 2*60*60)
+	}
+}
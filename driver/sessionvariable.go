@@ -0,0 +1,117 @@
+package driver
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	p "github.com/SAP/go-hdb/driver/internal/protocol"
+)
+
+/*
+Session variables evaluated by the database server e.g. for workload management and auditing (see M_SESSION_CONTEXT).
+
+In connection pools the end user of a connection changes: set SessionVariableApplicationUser per connection
+(see Conn SetSessionVariable) before executing statements on behalf of a user, so that the database activity
+is attributed to the end user instead of the technical database user.
+*/
+const (
+	SessionVariableApplication       = "APPLICATION"
+	SessionVariableApplicationUser   = "APPLICATIONUSER"
+	SessionVariableApplicationSource = "APPLICATIONSOURCE"
+)
+
+// ErrInvalidSessionVariable is returned if a session variable key is empty.
+var ErrInvalidSessionVariable = errors.New("invalid session variable: key is empty")
+
+/*
+WithSessionVariables returns a copy of ctx with session variables sv overriding the connection session variables
+for statements executed or prepared with the returned context, e.g. to attach a correlation id for tracing.
+The overriding session variables are sent as part of the client info of these statements only: the connection
+session variables are restored with the next statement executed without overrides and session variables which
+are not set on the connection are unset again.
+*/
+func WithSessionVariables(ctx context.Context, sv SessionVariables) context.Context {
+	return p.WithSessionVariables(ctx, sv)
+}
+
+// sessionContextQuery returns the query selecting the session variable key.
+func sessionContextQuery(key string) string {
+	return fmt.Sprintf("select session_context('%s') from dummy", strings.ReplaceAll(key, "'", "''"))
+}
+
+/*
+SetSessionVariable implements the Conn interface.
+
+The session variable is set for this connection only and is sent to the database as part of the client info
+(see SetSessionVariables). To report errors immediately, a database round trip is executed.
+Setting an empty value unsets the session variable.
+*/
+func (c *conn) SetSessionVariable(ctx context.Context, key, value string) error {
+	c.keepAlive.busy()
+	if key == "" {
+		return ErrInvalidSessionVariable
+	}
+
+	ctx, done := withDBCall(ctx)
+	var err error
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		c.pw.SetSessionVariable(key, value)
+		_, err = c.queryDirect(ctx, dummyQuery, !c.inTx)
+		close(done)
+	}()
+
+	select {
+	case <-ctx.Done():
+		c.cancelled(done, &err)
+		return ctx.Err()
+	case <-done:
+		c.setLastError(err)
+		return err
+	}
+}
+
+// SessionVariable implements the Conn interface.
+// It returns the effective value of the session variable on the database, the empty string if the variable is not set.
+func (c *conn) SessionVariable(ctx context.Context, key string) (string, error) {
+	c.keepAlive.busy()
+	if key == "" {
+		return "", ErrInvalidSessionVariable
+	}
+
+	ctx, done := withDBCall(ctx)
+	var value string
+	var err error
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		defer close(done)
+		var rows driver.Rows
+		if rows, err = c.queryDirect(ctx, sessionContextQuery(key), !c.inTx); err != nil {
+			return
+		}
+		defer rows.Close()
+		dest := make([]driver.Value, 1)
+		if err = rows.Next(dest); err != nil {
+			if err == io.EOF {
+				err = nil
+			}
+			return
+		}
+		value, _ = dest[0].(string) // NULL if not set
+	}()
+
+	select {
+	case <-ctx.Done():
+		c.cancelled(done, &err)
+		return "", ctx.Err()
+	case <-done:
+		c.setLastError(err)
+		return value, err
+	}
+}
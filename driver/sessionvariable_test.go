@@ -0,0 +1,10 @@
+package driver
+
+import "testing"
+
+func TestSessionContextQuery(t *testing.T) {
+	const expected = "select session_context('APPLICATIONUSER''S') from dummy"
+	if query := sessionContextQuery("APPLICATIONUSER'S"); query != expected {
+		t.Fatalf("query %s - expected %s", query, expected)
+	}
+}
@@ -0,0 +1,163 @@
+package driver
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/SAP/go-hdb/driver/spatial"
+)
+
+// ErrEmptySliceArg is the error raised if an empty slice is passed as argument for a placeholder (see expandSliceArgs).
+var ErrEmptySliceArg = errors.New("empty slice argument")
+
+// isSliceArg returns true if v is a slice to be expanded into one argument per element.
+// Binary values ([]byte) and types converting themselves (e.g. driver.Valuer, spatial geometries) are not expanded.
+func isSliceArg(v any) bool {
+	switch v.(type) {
+	case nil, driver.Valuer, encoding.TextMarshaler, spatial.Geometry:
+		return false
+	}
+	rt := reflect.TypeOf(v)
+	return rt.Kind() == reflect.Slice && rt.Elem().Kind() != reflect.Uint8
+}
+
+/*
+expandSliceArgs expands slice arguments (e.g. []int64 or []string) of a query into one argument per slice element
+and the respective placeholder into a comma separated list of placeholders, so that a slice can be passed for an
+IN predicate:
+
+	db.Query("select * from t where id in (?)", []int{1, 2, 3}) // select * from t where id in (?, ?, ?)
+
+Nil slice elements are passed as NULL values. Empty slices return ErrEmptySliceArg, as an empty IN list is not
+valid SQL and any replacement would change the semantics of a NOT IN predicate.
+
+Every slice element counts as one statement parameter, so that the number of elements is limited by the maximum
+number of parameters of a statement supported by the database server (32767). For large lookups a temporary table
+or a join with a table function (e.g. series_generate_integer) should be considered instead.
+
+Expansion applies to queries and statements executed via sql.DB, sql.Conn and sql.Tx. Statements prepared
+explicitly have a fixed number of parameters and do not support slice arguments.
+Slice arguments cannot be combined with named arguments.
+*/
+func expandSliceArgs(query string, nvargs []driver.NamedValue) (string, []driver.NamedValue, bool, error) {
+	expand := false
+	for _, nvarg := range nvargs {
+		if isSliceArg(nvarg.Value) {
+			if nvarg.Name != "" {
+				return "", nil, false, fmt.Errorf("invalid argument %s - slice arguments cannot be named", nvarg.Name)
+			}
+			expand = true
+		}
+	}
+	if !expand {
+		return query, nvargs, false, nil
+	}
+
+	var b strings.Builder
+	var args []driver.NamedValue
+	i := 0 // placeholder index
+	err := scanPlaceholders(query, func(s string, placeholder bool) error {
+		if !placeholder {
+			b.WriteString(s)
+			return nil
+		}
+		if i >= len(nvargs) {
+			return fmt.Errorf("invalid number of arguments %d - placeholder %d without argument", len(nvargs), i+1)
+		}
+		v := nvargs[i].Value
+		i++
+		if !isSliceArg(v) {
+			b.WriteByte('?')
+			args = append(args, driver.NamedValue{Ordinal: len(args) + 1, Value: v})
+			return nil
+		}
+		rv := reflect.ValueOf(v)
+		n := rv.Len()
+		if n == 0 {
+			return fmt.Errorf("%w for placeholder %d", ErrEmptySliceArg, i)
+		}
+		for j := 0; j < n; j++ {
+			if j > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteByte('?')
+			args = append(args, driver.NamedValue{Ordinal: len(args) + 1, Value: rv.Index(j).Interface()})
+		}
+		return nil
+	})
+	if err != nil {
+		return "", nil, false, err
+	}
+	if i != len(nvargs) {
+		return "", nil, false, fmt.Errorf("invalid number of arguments %d - %d placeholders", len(nvargs), i)
+	}
+	return b.String(), args, true, nil
+}
+
+// scanPlaceholders splits query into text and placeholders ('?') calling fn for each piece.
+// Question marks within string literals, quoted identifiers and comments are not treated as placeholders.
+func scanPlaceholders(query string, fn func(s string, placeholder bool) error) error {
+	start := 0
+	for i := 0; i < len(query); i++ {
+		switch c := query[i]; {
+		case c == '\'' || c == '"': // string literal or quoted identifier (doubled quote is an escaped quote)
+			for i++; i < len(query) && query[i] != c; i++ {
+			}
+		case c == '-' && strings.HasPrefix(query[i:], "--"): // line comment
+			if j := strings.IndexByte(query[i:], '\n'); j != -1 {
+				i += j
+			} else {
+				i = len(query)
+			}
+		case c == '/' && strings.HasPrefix(query[i:], "/*"): // block comment
+			if j := strings.Index(query[i+2:], "*/"); j != -1 {
+				i += j + 3
+			} else {
+				i = len(query)
+			}
+		case c == '?':
+			if err := fn(query[start:i], false); err != nil {
+				return err
+			}
+			if err := fn("?", true); err != nil {
+				return err
+			}
+			start = i + 1
+		}
+	}
+	return fn(query[start:], false)
+}
+
+// preparedQuery prepares query and executes the resulting statement, which is closed together with the rows.
+func (c *conn) preparedQuery(ctx context.Context, query string, nvargs []driver.NamedValue) (driver.Rows, error) {
+	ds, err := c.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	s := ds.(*stmt)
+	rows, err := s.QueryContext(ctx, nvargs)
+	if err != nil {
+		s.Close()
+		return nil, err
+	}
+	if qr, ok := rows.(*queryResult); ok {
+		return &stmtQueryResult{queryResult: qr, stmt: s}, nil
+	}
+	return rows, s.Close()
+}
+
+// preparedExec prepares query, executes and closes the resulting statement.
+func (c *conn) preparedExec(ctx context.Context, query string, nvargs []driver.NamedValue) (driver.Result, error) {
+	ds, err := c.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	s := ds.(*stmt)
+	result, err := s.ExecContext(ctx, nvargs)
+	return result, errors.Join(err, s.Close())
+}
@@ -0,0 +1,62 @@
+package driver
+
+import (
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestExpandSliceArgs(t *testing.T) {
+	nvargs := func(values ...any) []driver.NamedValue {
+		nvargs := make([]driver.NamedValue, len(values))
+		for i, v := range values {
+			nvargs[i] = driver.NamedValue{Ordinal: i + 1, Value: v}
+		}
+		return nvargs
+	}
+
+	testData := []struct {
+		query     string
+		args      []driver.NamedValue
+		expQuery  string
+		expArgs   []driver.NamedValue
+		expanding bool
+	}{
+		{"select * from t where x = ?", nvargs(1), "select * from t where x = ?", nvargs(1), false},
+		{"select * from t where x = ?", nvargs([]byte{1, 2}), "select * from t where x = ?", nvargs([]byte{1, 2}), false},
+		{"select * from t where x in (?)", nvargs([]int{1, 2, 3}), "select * from t where x in (?, ?, ?)", nvargs(1, 2, 3), true},
+		{"select * from t where x in (?) and y = ?", nvargs([]string{"a", "b"}, 42), "select * from t where x in (?, ?) and y = ?", nvargs("a", "b", 42), true},
+		{"select * from t where x in (?)", nvargs([]any{1, nil}), "select * from t where x in (?, ?)", nvargs(1, nil), true},
+		{
+			"select '?', \"a?\" /* ? */ from t -- ?\nwhere x in (?) and y = 'it''s?'",
+			nvargs([]int{1, 2}),
+			"select '?', \"a?\" /* ? */ from t -- ?\nwhere x in (?, ?) and y = 'it''s?'",
+			nvargs(1, 2),
+			true,
+		},
+	}
+
+	for _, d := range testData {
+		query, args, expanded, err := expandSliceArgs(d.query, d.args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expanded != d.expanding {
+			t.Fatalf("query %s: expanded %t - expected %t", d.query, expanded, d.expanding)
+		}
+		if query != d.expQuery {
+			t.Fatalf("query %s - expected %s", query, d.expQuery)
+		}
+		if !reflect.DeepEqual(args, d.expArgs) {
+			t.Fatalf("args %v - expected %v", args, d.expArgs)
+		}
+	}
+
+	if _, _, _, err := expandSliceArgs("select * from t where x in (?)", nvargs([]int{})); !errors.Is(err, ErrEmptySliceArg) {
+		t.Fatalf("error %v - expected %v", err, ErrEmptySliceArg)
+	}
+	if _, _, _, err := expandSliceArgs("select * from t where x in (?)", nvargs([]int{1}, 2)); err == nil {
+		t.Fatal("expected invalid number of arguments error")
+	}
+}
@@ -73,8 +73,8 @@ func (s *Sniffer) Run() error {
 	go pipeData(wg, s.conn, s.dbConn, clientWr)
 	go pipeData(wg, s.dbConn, s.conn, dbWr)
 
-	pClientRd := p.NewClientReader(clientRd, true, s.logger, cesu8.DefaultDecoder)
-	pDBRd := p.NewDBReader(dbRd, true, s.logger, cesu8.DefaultDecoder)
+	pClientRd := p.NewClientReader(clientRd, true, false, s.logger, nil, cesu8.DefaultDecoder)
+	pDBRd := p.NewDBReader(dbRd, true, false, s.logger, nil, cesu8.DefaultDecoder)
 
 	go logData(ctx, wg, pClientRd)
 	go logData(ctx, wg, pDBRd)
@@ -60,3 +60,14 @@ func ExampleGeometry() {
 	// SRID=4711;GEOMETRYCOLLECTION (POINT (1 1),LINESTRING (1 1,2 2))
 	// {"type":"GeometryCollection","geometries":[{"type":"Point","coordinates":[1,1]},{"type":"LineString","coordinates":[[1,1],[2,2]]}]}
 }
+
+// ExampleDecodeEWKT demonstrates the conversion of the 'extended well known text' format to a geospatial object.
+func ExampleDecodeEWKT() {
+	g, srid, err := spatial.DecodeEWKT([]byte("SRID=4326;POINT (2.5 3)"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("%#v %d\n", g, srid)
+
+	// output: spatial.Point{X:2.5, Y:3} 4326
+}
@@ -278,3 +278,50 @@ func geoType(g Geometry) uint32 {
 		panic("invalid geoTypeName")
 	}
 }
+
+// geometries contains a value of each geometry type.
+var geometries = []Geometry{
+	Point{}, PointZ{}, PointM{}, PointZM{},
+	LineString{}, LineStringZ{}, LineStringM{}, LineStringZM{},
+	CircularString{}, CircularStringZ{}, CircularStringM{}, CircularStringZM{},
+	Polygon{}, PolygonZ{}, PolygonM{}, PolygonZM{},
+	MultiPoint{}, MultiPointZ{}, MultiPointM{}, MultiPointZM{},
+	MultiLineString{}, MultiLineStringZ{}, MultiLineStringM{}, MultiLineStringZM{},
+	MultiPolygon{}, MultiPolygonZ{}, MultiPolygonM{}, MultiPolygonZM{},
+	GeometryCollection{}, GeometryCollectionZ{}, GeometryCollectionM{}, GeometryCollectionZM{},
+}
+
+// geometryTypes maps the wkb type codes (geo type + dim offset) to the geometry types.
+var geometryTypes = func() map[uint32]reflect.Type {
+	m := make(map[uint32]reflect.Type, len(geometries))
+	for _, g := range geometries {
+		m[wkbType(g)] = reflect.TypeOf(g)
+	}
+	return m
+}()
+
+// newCoord returns the coordinate of dimension dim with the values fs (order x, y, z, m).
+func newCoord(dim uint32, fs []float64) reflect.Value {
+	switch dim {
+	case dimZ:
+		return reflect.ValueOf(CoordZ{X: fs[0], Y: fs[1], Z: fs[2]})
+	case dimM:
+		return reflect.ValueOf(CoordM{X: fs[0], Y: fs[1], M: fs[2]})
+	case dimZM:
+		return reflect.ValueOf(CoordZM{X: fs[0], Y: fs[1], Z: fs[2], M: fs[3]})
+	default:
+		return reflect.ValueOf(Coord{X: fs[0], Y: fs[1]})
+	}
+}
+
+// coordSize returns the number of coordinate values of dimension dim.
+func coordSize(dim uint32) int {
+	switch dim {
+	case dimZ, dimM:
+		return 3
+	case dimZM:
+		return 4
+	default:
+		return 2
+	}
+}
@@ -0,0 +1,136 @@
+package spatial
+
+import (
+	"errors"
+	"testing"
+)
+
+var testGeometries = []Geometry{
+	Point{X: 2.5, Y: 3.0},
+	PointZ{X: -3.0, Y: -4.5, Z: 5.0},
+	PointM{X: -3.0, Y: -4.5, M: NaN()},
+	PointZM{X: -3.0, Y: -4.5, Z: 5.0, M: 6.0},
+	LineString{},
+	LineString{{X: 3.0, Y: 3.0}, {X: 5.0, Y: 4.0}, {X: 6.0, Y: 3.0}},
+	LineStringZM{{X: 3.0, Y: 3.0, Z: 1, M: 2}, {X: 5.0, Y: 4.0, Z: 3, M: 4}},
+	CircularString{{X: 3.0, Y: 3.0}, {X: 5.0, Y: 4.0}, {X: 6.0, Y: 3.0}},
+	Polygon{},
+	Polygon{{{X: 6.0, Y: 7.0}, {X: 10.0, Y: 3.0}, {X: 10.0, Y: 10.0}, {X: 6.0, Y: 7.0}}},
+	PolygonZ{{{X: 6.0, Y: 7.0, Z: 1}, {X: 10.0, Y: 3.0, Z: 1}, {X: 10.0, Y: 10.0, Z: 1}, {X: 6.0, Y: 7.0, Z: 1}}},
+	MultiPoint{},
+	MultiPoint{{X: 3.0, Y: 3.0}, {X: 5.0, Y: 4.0}},
+	MultiPointM{{X: 3.0, Y: 3.0, M: 1}, {X: 5.0, Y: 4.0, M: 2}},
+	MultiLineString{{{X: 3.0, Y: 3.0}, {X: 5.0, Y: 4.0}}, {{X: 6.0, Y: 3.0}, {X: 7.0, Y: 4.0}}},
+	MultiPolygon{{{{X: 6.0, Y: 7.0}, {X: 10.0, Y: 3.0}, {X: 10.0, Y: 10.0}, {X: 6.0, Y: 7.0}}}},
+	GeometryCollection{},
+	GeometryCollection{Point{X: 1, Y: 1}, LineString{{X: 1, Y: 1}, {X: 2, Y: 2}}, MultiPoint{{X: 1, Y: 2}}},
+	GeometryCollectionZ{PointZ{X: 1, Y: 1, Z: 1}, LineStringZ{{X: 1, Y: 1, Z: 1}, {X: 2, Y: 2, Z: 2}}},
+}
+
+func TestDecode(t *testing.T) {
+	const srid = 4326
+
+	// compare decoded geometries by their wkt representation (NaN values).
+	assertEqual := func(t *testing.T, g, expected Geometry) {
+		t.Helper()
+		wkt, err := EncodeWKT(g)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expectedWKT, err := EncodeWKT(expected)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(wkt) != string(expectedWKT) {
+			t.Fatalf("geometry %s - expected %s", wkt, expectedWKT)
+		}
+	}
+
+	for _, g := range testGeometries {
+		for _, isXDR := range []bool{false, true} {
+			wkb, err := EncodeWKB(g, isXDR)
+			if err != nil {
+				t.Fatal(err)
+			}
+			dg, err := DecodeWKB(wkb)
+			if err != nil {
+				t.Fatalf("%s: %s", wkb, err)
+			}
+			assertEqual(t, dg, g)
+
+			ewkb, err := EncodeEWKB(g, isXDR, srid)
+			if err != nil {
+				t.Fatal(err)
+			}
+			dg, dsrid, err := DecodeEWKB(ewkb)
+			if err != nil {
+				t.Fatalf("%s: %s", ewkb, err)
+			}
+			assertEqual(t, dg, g)
+			if dsrid != srid {
+				t.Fatalf("srid %d - expected %d", dsrid, srid)
+			}
+		}
+
+		wkt, err := EncodeWKT(g)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dg, err := DecodeWKT(wkt)
+		if err != nil {
+			t.Fatalf("%s: %s", wkt, err)
+		}
+		assertEqual(t, dg, g)
+
+		ewkt, err := EncodeEWKT(g, srid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dg, dsrid, err := DecodeEWKT(ewkt)
+		if err != nil {
+			t.Fatalf("%s: %s", ewkt, err)
+		}
+		assertEqual(t, dg, g)
+		if dsrid != srid {
+			t.Fatalf("srid %d - expected %d", dsrid, srid)
+		}
+	}
+}
+
+func TestDecodeWKTVariants(t *testing.T) {
+	testData := []struct {
+		wkt      string
+		expected string
+	}{
+		{"point(1 2)", "POINT (1 2)"},
+		{"MULTIPOINT (1 2, 3 4)", "MULTIPOINT ((1 2),(3 4))"},
+		{" POLYGON ( ( 0 0 , 1 0 , 1 1 , 0 0 ) ) ", "POLYGON ((0 0,1 0,1 1,0 0))"},
+		{"GEOMETRYCOLLECTION M (POINT (1 2 3),POINT M (4 5 6))", "GEOMETRYCOLLECTION M (POINT (1 2 3),POINT (4 5 6))"},
+	}
+	for _, d := range testData {
+		g, err := DecodeWKT([]byte(d.wkt))
+		if err != nil {
+			t.Fatalf("%s: %s", d.wkt, err)
+		}
+		wkt, err := EncodeWKT(g)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(wkt) != d.expected {
+			t.Fatalf("%s: wkt %s - expected %s", d.wkt, wkt, d.expected)
+		}
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	for _, wkb := range []string{"", "02", "0101000000", "010100000000000000000000000000000000000000ff", "0104000000ffffffff", "zz"} {
+		if _, err := DecodeWKB([]byte(wkb)); !errors.Is(err, ErrInvalidWKB) {
+			t.Fatalf("wkb %q: error %v - expected %v", wkb, err, ErrInvalidWKB)
+		}
+	}
+	for _, wkt := range []string{"", "POINT", "POINT (1)", "POINT (1 2", "POINT (1 2) x", "SQUARE (1 2)", "SRID=x;POINT (1 2)", "GEOMETRYCOLLECTION (POINT Z (1 2 3))"} {
+		if _, err := DecodeWKT([]byte(wkt)); !errors.Is(err, ErrInvalidWKT) {
+			t.Fatalf("wkt %q: error %v - expected %v", wkt, err, ErrInvalidWKT)
+		}
+	}
+}
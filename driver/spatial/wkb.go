@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"encoding/binary"
 	"encoding/hex"
+	"errors"
+	"fmt"
 	"io"
 	"reflect"
 )
@@ -187,3 +189,168 @@ func EncodeEWKB(g Geometry, isXDR bool, srid int32) ([]byte, error) {
 	}
 	return b.bytes(), nil
 }
+
+// ErrInvalidWKB is returned in case of invalid "well known binary" data.
+var ErrInvalidWKB = errors.New("invalid well known binary")
+
+type wkbDecoder struct {
+	rd    *bytes.Reader
+	order binary.ByteOrder
+	srid  int32
+}
+
+func (d *wkbDecoder) read(v any) error {
+	if err := binary.Read(d.rd, d.order, v); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidWKB, err)
+	}
+	return nil
+}
+
+func (d *wkbDecoder) readType() (uint32, error) {
+	orderByte, err := d.rd.ReadByte()
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrInvalidWKB, err)
+	}
+	switch orderByte {
+	case XDR:
+		d.order = binary.BigEndian
+	case NDR:
+		d.order = binary.LittleEndian
+	default:
+		return 0, fmt.Errorf("%w: byte order %d", ErrInvalidWKB, orderByte)
+	}
+	var typ uint32
+	if err := d.read(&typ); err != nil {
+		return 0, err
+	}
+	if typ&sridFlag != 0 {
+		if err := d.read(&d.srid); err != nil {
+			return 0, err
+		}
+		typ &^= sridFlag
+	}
+	return typ, nil
+}
+
+func (d *wkbDecoder) readSize() (int, error) {
+	var size uint32
+	if err := d.read(&size); err != nil {
+		return 0, err
+	}
+	// each element needs at least one byte: protects against allocating huge slices.
+	if int64(size) > int64(d.rd.Len()) {
+		return 0, fmt.Errorf("%w: size %d exceeds data", ErrInvalidWKB, size)
+	}
+	return int(size), nil
+}
+
+func (d *wkbDecoder) readCoord(dim uint32) (reflect.Value, error) {
+	fs := make([]float64, coordSize(dim))
+	if err := d.read(fs); err != nil {
+		return reflect.Value{}, err
+	}
+	return newCoord(dim, fs), nil
+}
+
+func (d *wkbDecoder) readCoords(typ reflect.Type, dim uint32) (reflect.Value, error) {
+	size, err := d.readSize()
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	v := reflect.MakeSlice(typ, size, size)
+	for i := 0; i < size; i++ {
+		c, err := d.readCoord(dim)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		v.Index(i).Set(c.Convert(typ.Elem()))
+	}
+	return v, nil
+}
+
+func (d *wkbDecoder) decode() (Geometry, error) {
+	code, err := d.readType()
+	if err != nil {
+		return nil, err
+	}
+	typ, ok := geometryTypes[code]
+	if !ok {
+		return nil, fmt.Errorf("%w: geometry type %d", ErrInvalidWKB, code)
+	}
+	gt, dim := code%dimZ, code-code%dimZ
+
+	var v reflect.Value
+	switch gt {
+	case geoPoint:
+		c, err := d.readCoord(dim)
+		if err != nil {
+			return nil, err
+		}
+		v = c.Convert(typ)
+	case geoLineString, geoCircularString:
+		if v, err = d.readCoords(typ, dim); err != nil {
+			return nil, err
+		}
+	case geoPolygon:
+		size, err := d.readSize()
+		if err != nil {
+			return nil, err
+		}
+		v = reflect.MakeSlice(typ, size, size)
+		for i := 0; i < size; i++ {
+			ringv, err := d.readCoords(typ.Elem(), dim)
+			if err != nil {
+				return nil, err
+			}
+			v.Index(i).Set(ringv)
+		}
+	case geoMultiPoint, geoMultiLineString, geoMultiPolygon, geoGeometryCollection:
+		size, err := d.readSize()
+		if err != nil {
+			return nil, err
+		}
+		v = reflect.MakeSlice(typ, size, size)
+		for i := 0; i < size; i++ {
+			g, err := d.decode()
+			if err != nil {
+				return nil, err
+			}
+			gv := reflect.ValueOf(g)
+			if !gv.Type().AssignableTo(typ.Elem()) {
+				return nil, fmt.Errorf("%w: %s element of %s", ErrInvalidWKB, gv.Type().Name(), typ.Name())
+			}
+			v.Index(i).Set(gv)
+		}
+	}
+	return v.Interface().(Geometry), nil
+}
+
+// decodeWKB decodes a hex encoded or binary "(extended) well known binary" geometry.
+func decodeWKB(b []byte) (Geometry, int32, error) {
+	if len(b) != 0 && b[0] != XDR && b[0] != NDR { // hex encoded
+		var err error
+		if b, err = hex.DecodeString(string(b)); err != nil {
+			return nil, 0, fmt.Errorf("%w: %w", ErrInvalidWKB, err)
+		}
+	}
+	d := &wkbDecoder{rd: bytes.NewReader(b), srid: -1}
+	g, err := d.decode()
+	if err != nil {
+		return nil, 0, err
+	}
+	if d.rd.Len() != 0 {
+		return nil, 0, fmt.Errorf("%w: %d trailing bytes", ErrInvalidWKB, d.rd.Len())
+	}
+	return g, d.srid, nil
+}
+
+// DecodeWKB decodes a geometry from the "well known binary" format.
+// b might either be hex encoded (like the result of EncodeWKB) or binary.
+func DecodeWKB(b []byte) (Geometry, error) {
+	g, _, err := decodeWKB(b)
+	return g, err
+}
+
+// DecodeEWKB decodes a geometry and its spatial reference system identifier from the "extended well known binary" format.
+// b might either be hex encoded (like the result of EncodeEWKB) or binary. In case b does not contain a srid -1 is returned.
+func DecodeEWKB(b []byte) (Geometry, int32, error) { return decodeWKB(b) }
@@ -2,6 +2,8 @@ package spatial
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
 	"math"
 	"reflect"
 	"strconv"
@@ -152,3 +154,247 @@ func EncodeEWKT(g Geometry, srid int32) ([]byte, error) {
 	encodeWKT(b, typeFull, g)
 	return b.Bytes(), nil
 }
+
+// ErrInvalidWKT is returned in case of invalid "well known text" data.
+var ErrInvalidWKT = errors.New("invalid well known text")
+
+// wktGeoTypes maps the short wkt type names to the geo types.
+var wktGeoTypes = map[string]uint32{
+	"POINT":              geoPoint,
+	"LINESTRING":         geoLineString,
+	"POLYGON":            geoPolygon,
+	"MULTIPOINT":         geoMultiPoint,
+	"MULTILINESTRING":    geoMultiLineString,
+	"MULTIPOLYGON":       geoMultiPolygon,
+	"GEOMETRYCOLLECTION": geoGeometryCollection,
+	"CIRCULARSTRING":     geoCircularString,
+}
+
+// wktDims maps the wkt dimension names to the dim offsets.
+var wktDims = map[string]uint32{"Z": dimZ, "M": dimM, "ZM": dimZM}
+
+type wktParser struct {
+	s   string
+	pos int
+	tok string // current token
+}
+
+func (p *wktParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w: %s at position %d", ErrInvalidWKT, fmt.Sprintf(format, args...), p.pos)
+}
+
+func isWKTDelim(c byte) bool { return c == '(' || c == ')' || c == ',' || c == ';' || c == '=' }
+
+func isWKTSpace(c byte) bool { return c == ' ' || c == '\t' || c == '\n' || c == '\r' }
+
+// next reads the next token (empty at the end of the input).
+func (p *wktParser) next() {
+	for p.pos < len(p.s) && isWKTSpace(p.s[p.pos]) {
+		p.pos++
+	}
+	start := p.pos
+	switch {
+	case p.pos == len(p.s):
+	case isWKTDelim(p.s[p.pos]):
+		p.pos++
+	default:
+		for p.pos < len(p.s) && !isWKTSpace(p.s[p.pos]) && !isWKTDelim(p.s[p.pos]) {
+			p.pos++
+		}
+	}
+	p.tok = strings.ToUpper(p.s[start:p.pos])
+}
+
+// accept reads the next token in case the current token equals tok.
+func (p *wktParser) accept(tok string) bool {
+	if p.tok != tok {
+		return false
+	}
+	p.next()
+	return true
+}
+
+func (p *wktParser) expect(tok string) error {
+	if !p.accept(tok) {
+		return p.errorf("%q expected instead of %q", tok, p.tok)
+	}
+	return nil
+}
+
+// list parses a bracketed comma separated list calling fn for each element.
+func (p *wktParser) list(fn func() error) error {
+	if p.accept("EMPTY") {
+		return nil
+	}
+	if err := p.expect("("); err != nil {
+		return err
+	}
+	for {
+		if err := fn(); err != nil {
+			return err
+		}
+		if !p.accept(",") {
+			return p.expect(")")
+		}
+	}
+}
+
+func (p *wktParser) parseFloat() (float64, error) {
+	if p.accept("NULL") {
+		return math.NaN(), nil
+	}
+	f, err := strconv.ParseFloat(p.tok, 64)
+	if err != nil {
+		return 0, p.errorf("invalid coordinate value %q", p.tok)
+	}
+	p.next()
+	return f, nil
+}
+
+func (p *wktParser) parseCoord(dim uint32) (reflect.Value, error) {
+	fs := make([]float64, coordSize(dim))
+	for i := range fs {
+		var err error
+		if fs[i], err = p.parseFloat(); err != nil {
+			return reflect.Value{}, err
+		}
+	}
+	return newCoord(dim, fs), nil
+}
+
+func (p *wktParser) parseCoords(typ reflect.Type, dim uint32) (reflect.Value, error) {
+	v := reflect.MakeSlice(typ, 0, 0)
+	err := p.list(func() error {
+		c, err := p.parseCoord(dim)
+		if err != nil {
+			return err
+		}
+		v = reflect.Append(v, c.Convert(typ.Elem()))
+		return nil
+	})
+	return v, err
+}
+
+// parseBody parses the geometry text of the geometry with type code.
+func (p *wktParser) parseBody(code uint32) (Geometry, error) {
+	typ, ok := geometryTypes[code]
+	if !ok {
+		return nil, p.errorf("invalid geometry type %d", code)
+	}
+	gt, dim := code%dimZ, code-code%dimZ
+
+	var v reflect.Value
+	var err error
+	switch gt {
+	case geoPoint:
+		if err = p.expect("("); err != nil {
+			return nil, err
+		}
+		if v, err = p.parseCoord(dim); err != nil {
+			return nil, err
+		}
+		if err = p.expect(")"); err != nil {
+			return nil, err
+		}
+		v = v.Convert(typ)
+	case geoLineString, geoCircularString:
+		v, err = p.parseCoords(typ, dim)
+	case geoPolygon:
+		v = reflect.MakeSlice(typ, 0, 0)
+		err = p.list(func() error {
+			ringv, err := p.parseCoords(typ.Elem(), dim)
+			v = reflect.Append(v, ringv)
+			return err
+		})
+	case geoMultiPoint, geoMultiLineString, geoMultiPolygon:
+		v = reflect.MakeSlice(typ, 0, 0)
+		elemCode := gt - (geoMultiPoint - geoPoint) + dim
+		err = p.list(func() error {
+			if gt == geoMultiPoint && p.tok != "(" { // point coordinates without brackets
+				c, err := p.parseCoord(dim)
+				if err != nil {
+					return err
+				}
+				v = reflect.Append(v, c.Convert(typ.Elem()))
+				return nil
+			}
+			g, err := p.parseBody(elemCode)
+			if err != nil {
+				return err
+			}
+			v = reflect.Append(v, reflect.ValueOf(g))
+			return nil
+		})
+	case geoGeometryCollection:
+		v = reflect.MakeSlice(typ, 0, 0)
+		err = p.list(func() error {
+			g, err := p.parseGeometry(dim)
+			if err != nil {
+				return err
+			}
+			gv := reflect.ValueOf(g)
+			if !gv.Type().AssignableTo(typ.Elem()) {
+				return p.errorf("%s element of %s", gv.Type().Name(), typ.Name())
+			}
+			v = reflect.Append(v, gv)
+			return nil
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return v.Interface().(Geometry), nil
+}
+
+// parseGeometry parses a tagged geometry text. Untagged dimensions default to dim (collection elements).
+func (p *wktParser) parseGeometry(dim uint32) (Geometry, error) {
+	gt, ok := wktGeoTypes[p.tok]
+	if !ok {
+		return nil, p.errorf("invalid geometry type %q", p.tok)
+	}
+	p.next()
+	if d, ok := wktDims[p.tok]; ok {
+		dim = d
+		p.next()
+	}
+	return p.parseBody(gt + dim)
+}
+
+// decodeWKT decodes an "(extended) well known text" geometry.
+func decodeWKT(b []byte) (Geometry, int32, error) {
+	p := &wktParser{s: string(b)}
+	p.next()
+	srid := int32(-1)
+	if p.accept("SRID") {
+		if err := p.expect("="); err != nil {
+			return nil, 0, err
+		}
+		i, err := strconv.ParseInt(p.tok, 10, 32)
+		if err != nil {
+			return nil, 0, p.errorf("invalid srid %q", p.tok)
+		}
+		srid = int32(i)
+		p.next()
+		if err := p.expect(";"); err != nil {
+			return nil, 0, err
+		}
+	}
+	g, err := p.parseGeometry(0)
+	if err != nil {
+		return nil, 0, err
+	}
+	if p.tok != "" {
+		return nil, 0, p.errorf("unexpected %q", p.tok)
+	}
+	return g, srid, nil
+}
+
+// DecodeWKT decodes a geometry from the "well known text" format.
+func DecodeWKT(b []byte) (Geometry, error) {
+	g, _, err := decodeWKT(b)
+	return g, err
+}
+
+// DecodeEWKT decodes a geometry and its spatial reference system identifier from the "extended well known text" format.
+// In case b does not contain a srid -1 is returned.
+func DecodeEWKT(b []byte) (Geometry, int32, error) { return decodeWKT(b) }
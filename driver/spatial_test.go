@@ -0,0 +1,74 @@
+//go:build !unit
+
+package driver
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/SAP/go-hdb/driver/spatial"
+)
+
+func TestSpatialRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	const srid = 4326
+
+	db := MT.DB()
+
+	table := RandomIdentifier("spatialRoundTrip")
+	if _, err := db.Exec(fmt.Sprintf("create table %s (x st_point(%d), i integer)", table, srid)); err != nil {
+		t.Fatal(err)
+	}
+
+	testData := []spatial.Point{{X: 2.5, Y: 3.0}, {X: -3.0, Y: -4.5}}
+
+	for i, p := range testData {
+		if _, err := db.Exec(fmt.Sprintf("insert into %s values (?, ?)", table), p, i); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rows, err := db.Query(fmt.Sprintf("select x, x.st_srid(), x.st_asewkb() from %s order by i", table))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	i := 0
+	for rows.Next() {
+		var wkb string
+		var rsrid int32
+		ewkb := new(bytes.Buffer)
+		if err := rows.Scan(&wkb, &rsrid, NewLob(nil, ewkb)); err != nil {
+			t.Fatal(err)
+		}
+
+		g, err := spatial.DecodeWKB([]byte(wkb))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if g != testData[i] {
+			t.Fatalf("geometry %v - expected %v", g, testData[i])
+		}
+		if rsrid != srid {
+			t.Fatalf("srid %d - expected %d", rsrid, srid)
+		}
+
+		g, esrid, err := spatial.DecodeEWKB(ewkb.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if g != testData[i] || esrid != srid {
+			t.Fatalf("geometry %v srid %d - expected %v %d", g, esrid, testData[i], srid)
+		}
+		i++
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if i != len(testData) {
+		t.Fatalf("rows %d - expected %d", i, len(testData))
+	}
+}
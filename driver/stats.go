@@ -17,13 +17,28 @@ type Stats struct {
 	OpenConnections  int // The number of current established driver connections.
 	OpenTransactions int // The number of current open driver transactions.
 	OpenStatements   int // The number of current open driver database statements.
+	StmtCacheSize    int // The number of prepared statements currently held by connection statement caches.
 	// Counters
-	ReadBytes    uint64 // Total bytes read by client connection.
-	WrittenBytes uint64 // Total bytes written by client connection.
+	ReadBytes                uint64            // Total bytes read by client connection.
+	WrittenBytes             uint64            // Total bytes written by client connection.
+	LobReadBytes             uint64            // Total lob bytes read by lob read requests (included in ReadBytes).
+	LobWrittenBytes          uint64            // Total lob bytes written by lob write requests (included in WrittenBytes).
+	UncompressedReadBytes    uint64            // Total uncompressed bytes of messages read (equals ReadBytes without prolog if compression is off).
+	UncompressedWrittenBytes uint64            // Total uncompressed bytes of messages written (equals WrittenBytes without prolog if compression is off).
+	DroppedWarnings          uint64            // Total database warnings dropped exceeding the maximum number of retained warnings.
+	Retries                  uint64            // Total retries of queries failing with transient database errors (see SetRetryPolicy).
+	StmtCacheHits            uint64            // Total prepares served by connection statement caches (see SetStmtCacheSize).
+	StmtCacheMisses          uint64            // Total prepares not served by connection statement caches.
+	SQLErrors                map[string]uint64 // Number of failed SQL statements.
 	// Time histograms (Sum and upper bounds in Unit)
-	TimeUnit  string                     // Time unit
-	ReadTime  *StatsHistogram            // Time spent on reading from connection.
-	WriteTime *StatsHistogram            // Time spent on writing to connection.
-	AuthTime  *StatsHistogram            // Time spent on authentication.
-	SQLTimes  map[string]*StatsHistogram // Time spent on different SQL statements.
+	TimeUnit       string                     // Time unit
+	ReadTime       *StatsHistogram            // Time spent on reading from connection.
+	WriteTime      *StatsHistogram            // Time spent on writing to connection.
+	AuthTime       *StatsHistogram            // Time spent on authentication.
+	ServerTime     *StatsHistogram            // Time spent on waiting for the server (from request flush to first reply byte).
+	ClientTime     *StatsHistogram            // Time spent on processing replies (decoding and converting without waiting for the network).
+	SQLTimes       map[string]*StatsHistogram // Time spent on different SQL statements.
+	SQLTimeSamples map[string][]float64       // Most recent SQL times (oldest first) if enabled via SetSQLTimeSamples.
+	// Row histograms
+	RowsPerQuery *StatsHistogram // Number of rows returned per query and fetch.
 }
@@ -12,9 +12,10 @@ import (
 var statsCfgRaw []byte
 
 var statsCfg struct {
-	TimeUnit        string    `json:"timeUnit"`
-	SQLTimeTexts    []string  `json:"sqlTimeTexts"`
-	TimeUpperBounds []float64 `json:"timeUpperBounds"`
+	TimeUnit           string               `json:"timeUnit"`
+	SQLTimeTexts       []string             `json:"sqlTimeTexts"`
+	TimeUpperBounds    []float64            `json:"timeUpperBounds"`
+	SQLTimeUpperBounds map[string][]float64 `json:"sqlTimeUpperBounds"` // optional sql time specific upper bounds (key: sqlTimeTexts)
 }
 
 // time unit map (see go package time format.go).
@@ -50,5 +51,16 @@ func loadStatsCfg() error {
 	slices.Sort(statsCfg.TimeUpperBounds)
 	statsCfg.TimeUpperBounds = slices.Compact(statsCfg.TimeUpperBounds)
 
+	for k, upperBounds := range statsCfg.SQLTimeUpperBounds {
+		if !slices.Contains(statsCfg.SQLTimeTexts, k) {
+			return fmt.Errorf("invalid statscfg.json sqlTimeUpperBounds key %s - expected one of %v", k, statsCfg.SQLTimeTexts)
+		}
+		if len(upperBounds) == 0 {
+			return fmt.Errorf("number of statscfg.json sqlTimeUpperBounds for %s needs to be greater than %d", k, 0)
+		}
+		slices.Sort(upperBounds)
+		statsCfg.SQLTimeUpperBounds[k] = slices.Compact(upperBounds)
+	}
+
 	return nil
 }
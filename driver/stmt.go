@@ -6,10 +6,12 @@ import (
 	"database/sql/driver"
 	"errors"
 	"fmt"
+	"reflect"
 	"slices"
 	"time"
 
 	p "github.com/SAP/go-hdb/driver/internal/protocol"
+	hdbreflect "github.com/SAP/go-hdb/driver/internal/reflect"
 )
 
 // check if statements implements all required interfaces.
@@ -18,8 +20,20 @@ var (
 	_ driver.StmtExecContext   = (*stmt)(nil)
 	_ driver.StmtQueryContext  = (*stmt)(nil)
 	_ driver.NamedValueChecker = (*stmt)(nil)
+	_ Stmt                     = (*stmt)(nil)
 )
 
+// Stmt enhances driver.Stmt with go-hdb specific statement functions.
+// It is available via sql.Conn.Raw preparing the statement on the driver connection directly.
+type Stmt interface {
+	driver.Stmt
+	// IsDDL returns true if the statement is a DDL statement (function code DDL), false otherwise.
+	// DDL statements are committed automatically by the database (see SetDDLPolicy).
+	IsDDL() bool
+	// ParameterInfos returns the descriptions of the statement parameters provided by the database on prepare.
+	ParameterInfos() []ParameterInfo
+}
+
 type stmt struct {
 	conn  *conn
 	query string
@@ -28,19 +42,6 @@ type stmt struct {
 	rows *sql.Rows
 }
 
-type totalRowsAffected int64
-
-func (t *totalRowsAffected) add(r driver.Result) {
-	if r == nil {
-		return
-	}
-	rows, err := r.RowsAffected()
-	if err != nil {
-		return
-	}
-	*t += totalRowsAffected(rows)
-}
-
 func newStmt(conn *conn, query string, pr *prepareResult) *stmt {
 	return &stmt{conn: conn, query: query, pr: pr}
 }
@@ -54,7 +55,15 @@ NumInput differs dependent on statement (check is done in QueryContext and ExecC
 */
 func (s *stmt) NumInput() int { return -1 }
 
+// IsDDL implements the Stmt interface.
+func (s *stmt) IsDDL() bool { return s.pr.isDDL() }
+
 func (s *stmt) Close() error {
+	// database/sql closes statements of idle connections as well: synchronize with keep-alive pings.
+	return s.conn.keepAlive.do(s.close)
+}
+
+func (s *stmt) close() error {
 	c := s.conn
 
 	c.collector.msgCh <- gaugeMsg{idx: gaugeStmt, v: -1} // decrement number of statements.
@@ -62,9 +71,13 @@ func (s *stmt) Close() error {
 	if s.rows != nil {
 		s.rows.Close()
 	}
+	c.waitDrain(context.Background())
 	if c.isBad() {
 		return driver.ErrBadConn
 	}
+	if c.stmtCache != nil && !c.stmtCache.release(s.pr) {
+		return nil // statement kept in cache
+	}
 	return c.dropStatementID(context.Background(), s.pr.stmtID)
 }
 
@@ -75,44 +88,61 @@ func (s *stmt) CheckNamedValue(nv *driver.NamedValue) error {
 }
 
 func (s *stmt) QueryContext(ctx context.Context, nvargs []driver.NamedValue) (driver.Rows, error) {
+	s.conn.keepAlive.busy()
 	if s.pr.isProcedureCall() {
 		return nil, fmt.Errorf("invalid procedure call %s - please use Exec instead", s.query)
 	}
 	c := s.conn
+	if rows, routed, err := c.routeQuery(ctx, s.query, nvargs); routed {
+		return rows, err
+	}
 	if c.sqlTrace {
 		defer c.logSQLTrace(ctx, time.Now(), s.query, nvargs)
 	}
+	if err := c.checkDDL(ctx, s.pr, s.query); err != nil {
+		return nil, err
+	}
 
-	done := make(chan struct{})
+	ctx, done := withDBCall(ctx)
 	var rows driver.Rows
 	var err error
 	c.wg.Add(1)
 	go func() {
 		defer c.wg.Done()
-		rows, err = c.query(ctx, s.pr, nvargs, !s.conn.inTx)
+		err = c.retryQuery(ctx, !s.pr.hasLobParameter(), func() (err error) {
+			rows, err = c.query(ctx, s.pr, nvargs, !s.conn.inTx)
+			return err
+		})
 		close(done)
 	}()
 
 	select {
 	case <-ctx.Done():
-		c.lastError = errCancelled
+		c.cancelled(done, &err)
 		return nil, ctx.Err()
 	case <-done:
-		c.lastError = err
+		c.setLastError(err)
 		return rows, err
 	}
 }
 
 func (s *stmt) ExecContext(ctx context.Context, nvargs []driver.NamedValue) (driver.Result, error) {
 	c := s.conn
+	c.keepAlive.busy()
+	if err := c.checkReadOnlyRouting(ctx); err != nil {
+		return nil, err
+	}
 	if connHook != nil {
 		connHook(c, choStmtExec)
 	}
 	if c.sqlTrace {
 		defer c.logSQLTrace(ctx, time.Now(), s.query, nvargs)
 	}
+	if err := c.checkDDL(ctx, s.pr, s.query); err != nil {
+		return nil, err
+	}
 
-	done := make(chan struct{})
+	ctx, done := withDBCall(ctx)
 	var result driver.Result
 	var err error
 	c.wg.Add(1)
@@ -123,22 +153,26 @@ func (s *stmt) ExecContext(ctx context.Context, nvargs []driver.NamedValue) (dri
 		} else {
 			result, err = s.execDefault(ctx, nvargs)
 		}
+		if err == nil {
+			err = c.invalidateStmtCache(ctx, s.query)
+		}
 		close(done)
 	}()
 
 	select {
 	case <-ctx.Done():
-		c.lastError = errCancelled
+		c.cancelled(done, &err)
 		return nil, ctx.Err()
 	case <-done:
-		c.lastError = err
+		c.setLastError(err)
 		return result, err
 	}
 }
 
-func (s *stmt) execCall(ctx context.Context, pr *prepareResult, nvargs []driver.NamedValue) (driver.Result, *sql.Rows, error) {
+func (s *stmt) execCall(ctx context.Context, pr *prepareResult, nvargs []driver.NamedValue) (_ driver.Result, _ *sql.Rows, err error) {
 	c := s.conn
-	defer c.addSQLTimeValue(time.Now(), sqlTimeCall)
+	defer c.addSQLTimeValue(ctx, time.Now(), sqlTimeCall)
+	defer c.addSQLErrorValue(ctx, sqlTimeCall, &err)
 
 	callArgs, err := convertCallArgs(pr.parameterFields, nvargs, c.attrs._cesu8Encoder(), c.attrs._lobChunkSize)
 	if err != nil {
@@ -170,7 +204,7 @@ func (s *stmt) execCall(ctx context.Context, pr *prepareResult, nvargs []driver.
 			- chunkReaders
 			- cr (callResult output parameters are set after all lob input parameters are written)
 		*/
-		if err := c.encodeLobs(cr, ids, callArgs.inFields, callArgs.inArgs); err != nil {
+		if err := c.encodeLobs(ctx, cr, ids, callArgs.inFields, callArgs.inArgs); err != nil {
 			return nil, nil, err
 		}
 	}
@@ -222,6 +256,9 @@ func (s *stmt) execDefault(ctx context.Context, nvargs []driver.NamedValue) (dri
 		if _, ok := nvargs[0].Value.(func(args []any) error); ok {
 			return s.execFct(ctx, nvargs)
 		}
+		if seq, ok := rowSeq(nvargs[0].Value); ok {
+			return s.execSeq(ctx, seq)
+		}
 	}
 	if numNVArg == numField {
 		return s.exec(ctx, s.pr, nvargs, !c.inTx, 0)
@@ -243,7 +280,7 @@ execMany data might only be written partially to the database in case of hdb stm
 func (s *stmt) execFct(ctx context.Context, nvargs []driver.NamedValue) (driver.Result, error) {
 	c := s.conn
 
-	totalRowsAffected := totalRowsAffected(0)
+	res := &execResult{fc: s.pr.fc}
 	args := make([]driver.NamedValue, 0, s.pr.numField())
 	scanArgs := make([]any, s.pr.numField())
 
@@ -263,33 +300,112 @@ func (s *stmt) execFct(ctx context.Context, nvargs []driver.NamedValue) (driver.
 				break
 			}
 			if err != nil {
-				return driver.RowsAffected(totalRowsAffected), err
+				return res, err
 			}
 
-			args = slices.Grow(args, len(scanArgs))
-			for j, scanArg := range scanArgs {
-				nv := driver.NamedValue{Ordinal: j + 1}
-				if t, ok := scanArg.(sql.NamedArg); ok {
-					nv.Name = t.Name
-					nv.Value = t.Value
-				} else {
-					nv.Name = ""
-					nv.Value = scanArg
-				}
-				args = append(args, nv)
-			}
+			args = appendRowArgs(args, scanArgs)
 		}
 
 		if len(args) != 0 {
 			r, err := s.exec(ctx, s.pr, args, !c.inTx, batch*c.attrs._bulkSize)
-			totalRowsAffected.add(r)
+			res.add(r)
 			if err != nil {
-				return driver.RowsAffected(totalRowsAffected), err
+				return res, err
 			}
 		}
 		batch++
 	}
-	return driver.RowsAffected(totalRowsAffected), nil
+	return res, nil
+}
+
+// appendRowArgs appends the values of a row to args.
+func appendRowArgs(args []driver.NamedValue, row []any) []driver.NamedValue {
+	args = slices.Grow(args, len(row))
+	for j, v := range row {
+		nv := driver.NamedValue{Ordinal: j + 1}
+		if t, ok := v.(sql.NamedArg); ok {
+			nv.Name = t.Name
+			nv.Value = t.Value
+		} else {
+			nv.Value = v
+		}
+		args = append(args, nv)
+	}
+	return args
+}
+
+// iterator function types compatible with iter.Seq[[]any] and iter.Seq2[[]any, error].
+var (
+	seqType  = hdbreflect.TypeFor[func(yield func([]any) bool)]()
+	seq2Type = hdbreflect.TypeFor[func(yield func([]any, error) bool)]()
+)
+
+// rowSeq returns a row iterator function if v is an iter.Seq[[]any] or an iter.Seq2[[]any, error] compatible function.
+func rowSeq(v any) (func(yield func([]any, error) bool), bool) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Func || rv.IsNil() {
+		return nil, false
+	}
+	switch {
+	case rv.Type().ConvertibleTo(seq2Type):
+		return rv.Convert(seq2Type).Interface().(func(yield func([]any, error) bool)), true
+	case rv.Type().ConvertibleTo(seqType):
+		seq := rv.Convert(seqType).Interface().(func(yield func([]any) bool))
+		return func(yield func([]any, error) bool) {
+			seq(func(row []any) bool { return yield(row, nil) })
+		}, true
+	default:
+		return nil, false
+	}
+}
+
+/*
+execSeq executes a bulk statement pulling the rows from an iterator. Rows are sent to the database
+whenever bulkSize rows are collected, so that the rows do not need to be materialized at once.
+In case the iterator yields an error, the collected rows not yet sent are discarded and the error is returned.
+
+Non 'atomic' (transactional) operation due to the split in packages (bulkSize),
+execSeq data might only be written partially to the database in case of hdb stmt or iterator errors.
+*/
+func (s *stmt) execSeq(ctx context.Context, seq func(yield func([]any, error) bool)) (driver.Result, error) {
+	c := s.conn
+	bulkSize := c.attrs._bulkSize
+	numField := s.pr.numField()
+
+	res := &execResult{fc: s.pr.fc}
+	args := make([]driver.NamedValue, 0, numField*bulkSize)
+	batch := 0
+	var err error
+
+	flush := func() bool {
+		r, execErr := s.exec(ctx, s.pr, args, !c.inTx, batch*bulkSize)
+		res.add(r)
+		args = args[:0]
+		batch++
+		err = execErr
+		return err == nil
+	}
+
+	seq(func(row []any, rowErr error) bool {
+		if rowErr != nil {
+			err = rowErr // abort: discard rows of in-flight batch
+			return false
+		}
+		if len(row) != numField {
+			err = fmt.Errorf("invalid number of row values %d - expected %d", len(row), numField)
+			return false
+		}
+		args = appendRowArgs(args, row)
+		if len(args) == numField*bulkSize {
+			return flush()
+		}
+		return true
+	})
+
+	if err == nil && len(args) != 0 {
+		flush()
+	}
+	return res, err
 }
 
 /*
@@ -300,7 +416,7 @@ func (s *stmt) execMany(ctx context.Context, nvargs []driver.NamedValue) (driver
 	c := s.conn
 	bulkSize := c.attrs._bulkSize
 
-	totalRowsAffected := totalRowsAffected(0)
+	res := &execResult{fc: s.pr.fc}
 	numField := s.pr.numField()
 	numNVArg := len(nvargs)
 	numRec := numNVArg / numField
@@ -316,12 +432,12 @@ func (s *stmt) execMany(ctx context.Context, nvargs []driver.NamedValue) (driver
 			to = numNVArg
 		}
 		r, err := s.exec(ctx, s.pr, nvargs[from:to], !c.inTx, i*bulkSize)
-		totalRowsAffected.add(r)
+		res.add(r)
 		if err != nil {
-			return driver.RowsAffected(totalRowsAffected), err
+			return res, err
 		}
 	}
-	return driver.RowsAffected(totalRowsAffected), nil
+	return res, nil
 }
 
 /*
@@ -344,9 +460,10 @@ Bulk insert containing LOBs:
   - Package invariant:
     .for all packages except the last one, the last row contains 'incomplete' LOB data ('piecewise' writing)
 */
-func (s *stmt) exec(ctx context.Context, pr *prepareResult, nvargs []driver.NamedValue, commit bool, ofs int) (driver.Result, error) {
+func (s *stmt) exec(ctx context.Context, pr *prepareResult, nvargs []driver.NamedValue, commit bool, ofs int) (_ driver.Result, err error) {
 	c := s.conn
-	defer c.addSQLTimeValue(time.Now(), sqlTimeExec)
+	defer c.addSQLTimeValue(ctx, time.Now(), sqlTimeExec)
+	defer c.addSQLErrorValue(ctx, sqlTimeExec, &err)
 
 	addLobDataRecs, err := convertExecArgs(pr.parameterFields, nvargs, c.attrs._cesu8Encoder(), c.attrs._lobChunkSize)
 	if err != nil {
@@ -355,17 +472,47 @@ func (s *stmt) exec(ctx context.Context, pr *prepareResult, nvargs []driver.Name
 
 	// piecewise LOB handling
 	numColumn := len(pr.parameterFields)
-	totalRowsAffected := totalRowsAffected(0)
-	from := 0
-	for i := 0; i < len(addLobDataRecs); i++ {
-		to := (addLobDataRecs[i] + 1) * numColumn
+	recs := addLobDataRecs
+	if numColumn != 0 {
+		// split packages exceeding the maximum message size
+		recs = splitRecsBySize(recs, func(i int) int {
+			return p.RowSize(pr.parameterFields, nvargs[i*numColumn:(i+1)*numColumn])
+		}, p.MaxInputParametersSize)
+	}
+	res := &execResult{fc: pr.fc}
+	from, rec := 0, 0
+	for i := 0; i < len(recs); i++ {
+		to := (recs[i] + 1) * numColumn
 
-		r, err := c.exec(ctx, pr, nvargs[from:to], commit, ofs)
-		totalRowsAffected.add(r)
+		r, err := c.exec(ctx, pr, nvargs[from:to], commit, ofs+rec)
+		res.add(r)
 		if err != nil {
-			return driver.RowsAffected(totalRowsAffected), err
+			return res, err
+		}
+		from, rec = to, recs[i]+1
+	}
+	return res, nil
+}
+
+/*
+splitRecsBySize splits packages given by the index of their last record further, so that the size of a package
+does not exceed maxSize. Records exceeding maxSize on their own are sent as single record package.
+*/
+func splitRecsBySize(recs []int, recSize func(i int) int, maxSize int) []int {
+	split := make([]int, 0, len(recs))
+	from := 0
+	for _, to := range recs {
+		size := 0
+		for i := from; i <= to; i++ {
+			recSize := recSize(i)
+			if size != 0 && size+recSize > maxSize {
+				split = append(split, i-1)
+				size = 0
+			}
+			size += recSize
 		}
-		from = to
+		split = append(split, to)
+		from = to + 1
 	}
-	return driver.RowsAffected(totalRowsAffected), nil
+	return split
 }
@@ -0,0 +1,28 @@
+package driver
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSplitRecsBySize(t *testing.T) {
+	recSizes := []int{10, 10, 10, 50, 10, 10, 10, 10}
+	recSize := func(i int) int { return recSizes[i] }
+
+	tests := []struct {
+		recs     []int
+		maxSize  int
+		expected []int
+	}{
+		{[]int{7}, 1000, []int{7}},                                         // no split
+		{[]int{7}, 30, []int{2, 3, 6, 7}},                                  // split by size, record 3 exceeds maxSize
+		{[]int{3, 7}, 30, []int{2, 3, 6, 7}},                               // lob packages
+		{[]int{1, 4, 7}, 20, []int{1, 2, 3, 4, 6, 7}},                      // lob packages split further
+		{[]int{0, 1, 2, 3, 4, 5, 6, 7}, 10, []int{0, 1, 2, 3, 4, 5, 6, 7}}, // single record packages
+	}
+	for i, test := range tests {
+		if split := splitRecsBySize(test.recs, recSize, test.maxSize); !slices.Equal(split, test.expected) {
+			t.Fatalf("%d: split %v - expected %v", i, split, test.expected)
+		}
+	}
+}
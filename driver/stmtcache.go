@@ -0,0 +1,163 @@
+package driver
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"strings"
+)
+
+// stmtCacheEntry is a prepared statement of the statement cache.
+type stmtCacheEntry struct {
+	query   string
+	pr      *prepareResult
+	refs    int  // number of open statements using the prepare result
+	evicted bool // entry evicted from cache (statement id is dropped as soon as it is not used anymore)
+}
+
+/*
+stmtCache is a least recently used cache of prepared statements of a connection keyed by the sql query text.
+
+Cached statements are shared by all statements of the connection preparing the same query: the database statement
+is dropped only after the statement got evicted from the cache and is not used by any open statement anymore.
+The cache is not synchronized as database/sql serializes the access to a connection.
+As the cache is keyed by the query text, it is cleared whenever the default schema of the session changes.
+*/
+type stmtCache struct {
+	size    int
+	lru     *list.List // most recently used entry first
+	queries map[string]*list.Element
+	entries map[*prepareResult]*stmtCacheEntry // including evicted entries still in use
+}
+
+func newStmtCache(size int) *stmtCache {
+	return &stmtCache{
+		size:    size,
+		lru:     list.New(),
+		queries: map[string]*list.Element{},
+		entries: map[*prepareResult]*stmtCacheEntry{},
+	}
+}
+
+// len returns the number of cached statements.
+func (sc *stmtCache) len() int { return sc.lru.Len() }
+
+// get returns the cached prepare result of query and true, or nil and false in case the query is not cached.
+func (sc *stmtCache) get(query string) (*prepareResult, bool) {
+	elem, ok := sc.queries[query]
+	if !ok {
+		return nil, false
+	}
+	sc.lru.MoveToFront(elem)
+	entry := elem.Value.(*stmtCacheEntry)
+	entry.refs++
+	return entry.pr, true
+}
+
+// add adds the prepare result of query used by an open statement and returns the prepare results of evicted
+// entries not in use anymore, which statement ids need to be dropped.
+func (sc *stmtCache) add(query string, pr *prepareResult) []*prepareResult {
+	if _, ok := sc.queries[query]; ok { // do not replace entries in use
+		return nil
+	}
+	entry := &stmtCacheEntry{query: query, pr: pr, refs: 1}
+	sc.queries[query] = sc.lru.PushFront(entry)
+	sc.entries[pr] = entry
+
+	var drop []*prepareResult
+	for sc.lru.Len() > sc.size {
+		entry := sc.lru.Remove(sc.lru.Back()).(*stmtCacheEntry)
+		delete(sc.queries, entry.query)
+		entry.evicted = true
+		if entry.refs == 0 {
+			delete(sc.entries, entry.pr)
+			drop = append(drop, entry.pr)
+		}
+	}
+	return drop
+}
+
+// clear evicts all entries and returns the prepare results not in use anymore, which statement ids need to be dropped.
+// Entries in use are dropped on release.
+func (sc *stmtCache) clear() []*prepareResult {
+	var drop []*prepareResult
+	for sc.lru.Len() > 0 {
+		entry := sc.lru.Remove(sc.lru.Back()).(*stmtCacheEntry)
+		delete(sc.queries, entry.query)
+		entry.evicted = true
+		if entry.refs == 0 {
+			delete(sc.entries, entry.pr)
+			drop = append(drop, entry.pr)
+		}
+	}
+	return drop
+}
+
+// release is called on closing a statement using pr. It returns true if the statement id of pr needs to be dropped,
+// which is the case for prepare results not managed by the cache or evicted entries not in use anymore.
+func (sc *stmtCache) release(pr *prepareResult) bool {
+	entry, ok := sc.entries[pr]
+	if !ok {
+		return true
+	}
+	entry.refs--
+	if entry.refs > 0 || !entry.evicted {
+		return false
+	}
+	delete(sc.entries, pr)
+	return true
+}
+
+// prepareCached returns the prepare result of query from the statement cache of the connection if enabled,
+// or prepares query adding the prepare result to the cache. DDL statements are not cached.
+func (c *conn) prepareCached(ctx context.Context, query string) (*prepareResult, error) {
+	if c.stmtCache == nil {
+		return c.prepare(ctx, query)
+	}
+	if pr, ok := c.stmtCache.get(query); ok {
+		c.collector.msgCh <- counterMsg{idx: counterStmtCacheHits, v: 1}
+		return pr, nil
+	}
+	c.collector.msgCh <- counterMsg{idx: counterStmtCacheMisses, v: 1}
+	pr, err := c.prepare(ctx, query)
+	if err != nil || pr.isDDL() {
+		return pr, err
+	}
+	n := c.stmtCache.len()
+	drop := c.stmtCache.add(query, pr)
+	c.collector.msgCh <- gaugeMsg{idx: gaugeStmtCache, v: int64(c.stmtCache.len() - n)}
+	if err := c.dropStatementIDs(ctx, drop); err != nil {
+		c.stmtCache.release(pr)
+		return nil, err
+	}
+	return pr, nil
+}
+
+// isSetSchema returns true if query is a 'set schema' statement.
+func isSetSchema(query string) bool {
+	fields := strings.Fields(query)
+	return len(fields) > 1 && strings.EqualFold(fields[0], "set") && strings.EqualFold(fields[1], "schema")
+}
+
+// invalidateStmtCache evicts all cached statements in case the default schema of the session got changed by query,
+// as the cached statements might reference database objects of the former default schema.
+func (c *conn) invalidateStmtCache(ctx context.Context, query string) error {
+	if c.stmtCache == nil || !isSetSchema(query) {
+		return nil
+	}
+	n := c.stmtCache.len()
+	drop := c.stmtCache.clear()
+	c.collector.msgCh <- gaugeMsg{idx: gaugeStmtCache, v: -int64(n)}
+	return c.dropStatementIDs(ctx, drop)
+}
+
+// dropStatementIDs drops the statement ids of prepare results. All statement ids are dropped even in case of errors.
+func (c *conn) dropStatementIDs(ctx context.Context, prs []*prepareResult) error {
+	var errs []error
+	for _, pr := range prs {
+		if err := c.dropStatementID(ctx, pr.stmtID); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,92 @@
+package driver
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestStmtCache(t *testing.T) {
+	sc := newStmtCache(2)
+
+	pr1, pr2, pr3 := &prepareResult{stmtID: 1}, &prepareResult{stmtID: 2}, &prepareResult{stmtID: 3}
+
+	if drop := sc.add("q1", pr1); len(drop) != 0 {
+		t.Fatalf("dropped %v - expected none", drop)
+	}
+	if drop := sc.add("q2", pr2); len(drop) != 0 {
+		t.Fatalf("dropped %v - expected none", drop)
+	}
+	if sc.release(pr1) || sc.release(pr2) { // closing statements keeps cached statements
+		t.Fatal("cached statement dropped on release")
+	}
+
+	if pr, ok := sc.get("q1"); !ok || pr != pr1 { // q1 is most recently used
+		t.Fatalf("cached statement %v %t - expected %v", pr, ok, pr1)
+	}
+	if drop := sc.add("q3", pr3); !slices.Equal(drop, []*prepareResult{pr2}) { // q2 least recently used and not in use
+		t.Fatalf("dropped %v - expected %v", drop, pr2)
+	}
+	if _, ok := sc.get("q2"); ok {
+		t.Fatal("evicted statement q2 found in cache")
+	}
+	if sc.len() != 2 {
+		t.Fatalf("cache size %d - expected %d", sc.len(), 2)
+	}
+
+	// evict q1 while in use: statement is dropped on release.
+	if pr, ok := sc.get("q3"); !ok || pr != pr3 {
+		t.Fatalf("cached statement %v %t - expected %v", pr, ok, pr3)
+	}
+	if drop := sc.add("q2", pr2); len(drop) != 0 { // q1 in use
+		t.Fatalf("dropped %v - expected none", drop)
+	}
+	if !sc.release(pr1) {
+		t.Fatal("evicted statement q1 not dropped on release")
+	}
+
+	// statements not managed by the cache are dropped.
+	if !sc.release(&prepareResult{stmtID: 4}) {
+		t.Fatal("uncached statement not dropped on release")
+	}
+}
+
+func TestStmtCacheClear(t *testing.T) {
+	sc := newStmtCache(2)
+
+	pr1, pr2 := &prepareResult{stmtID: 1}, &prepareResult{stmtID: 2}
+
+	sc.add("q1", pr1)
+	sc.add("q2", pr2)
+	sc.release(pr1) // pr2 still in use
+
+	if drop := sc.clear(); !slices.Equal(drop, []*prepareResult{pr1}) {
+		t.Fatalf("dropped %v - expected %v", drop, pr1)
+	}
+	if sc.len() != 0 {
+		t.Fatalf("cache size %d - expected %d", sc.len(), 0)
+	}
+	if _, ok := sc.get("q2"); ok {
+		t.Fatal("cleared statement q2 found in cache")
+	}
+	if !sc.release(pr2) {
+		t.Fatal("cleared statement q2 not dropped on release")
+	}
+}
+
+func TestIsSetSchema(t *testing.T) {
+	testData := []struct {
+		query string
+		match bool
+	}{
+		{"set schema myschema", true},
+		{"  SET\tSchema \"mySchema\"", true},
+		{"set transaction read only", false},
+		{"select * from schemas", false},
+		{"set", false},
+	}
+	for _, r := range testData {
+		if match := isSetSchema(r.query); match != r.match {
+			t.Fatalf("query %q: match %t - expected %t", r.query, match, r.match)
+		}
+	}
+}
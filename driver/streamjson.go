@@ -0,0 +1,222 @@
+package driver
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"time"
+
+	hdbreflect "github.com/SAP/go-hdb/driver/internal/reflect"
+)
+
+// jsonKind is the json representation kind of a result column.
+type jsonKind int
+
+const (
+	jkAny jsonKind = iota
+	jkBool
+	jkInt
+	jkFloat
+	jkTime
+	jkString
+	jkBytes
+	jkDecimal
+	jkTextLob
+	jkBinaryLob
+)
+
+var jsonKindScanTypes = map[reflect.Type]jsonKind{
+	hdbreflect.TypeFor[bool]():            jkBool,
+	hdbreflect.TypeFor[sql.NullBool]():    jkBool,
+	hdbreflect.TypeFor[uint8]():           jkInt,
+	hdbreflect.TypeFor[int16]():           jkInt,
+	hdbreflect.TypeFor[int32]():           jkInt,
+	hdbreflect.TypeFor[int64]():           jkInt,
+	hdbreflect.TypeFor[sql.NullByte]():    jkInt,
+	hdbreflect.TypeFor[sql.NullInt16]():   jkInt,
+	hdbreflect.TypeFor[sql.NullInt32]():   jkInt,
+	hdbreflect.TypeFor[sql.NullInt64]():   jkInt,
+	hdbreflect.TypeFor[float32]():         jkFloat,
+	hdbreflect.TypeFor[float64]():         jkFloat,
+	hdbreflect.TypeFor[sql.NullFloat64](): jkFloat,
+	hdbreflect.TypeFor[time.Time]():       jkTime,
+	hdbreflect.TypeFor[sql.NullTime]():    jkTime,
+	hdbreflect.TypeFor[string]():          jkString,
+	hdbreflect.TypeFor[sql.NullString]():  jkString,
+	hdbreflect.TypeFor[[]byte]():          jkBytes,
+	hdbreflect.TypeFor[NullBytes]():       jkBytes,
+	hdbreflect.TypeFor[Decimal]():         jkDecimal,
+	hdbreflect.TypeFor[NullDecimal]():     jkDecimal,
+	hdbreflect.TypeFor[Lob]():             jkTextLob,
+	hdbreflect.TypeFor[NullLob]():         jkTextLob,
+}
+
+// jsonColumn encodes the values of a result column as json values.
+type jsonColumn struct {
+	kind jsonKind
+	name string
+	dest any // scan destination
+	buf  bytes.Buffer
+}
+
+func newJSONColumn(name, databaseTypeName string, scanType reflect.Type) *jsonColumn {
+	c := &jsonColumn{name: name, kind: jsonKindScanTypes[scanType]}
+	if c.kind == jkTextLob && databaseTypeName == "BLOB" {
+		c.kind = jkBinaryLob
+	}
+	switch c.kind {
+	case jkBool:
+		c.dest = new(sql.NullBool)
+	case jkInt:
+		c.dest = new(sql.NullInt64)
+	case jkFloat:
+		c.dest = new(sql.NullFloat64)
+	case jkTime:
+		c.dest = new(sql.NullTime)
+	case jkString:
+		c.dest = new(sql.NullString)
+	case jkBytes:
+		c.dest = new(NullBytes)
+	case jkDecimal:
+		c.dest = new(NullDecimal)
+	case jkTextLob, jkBinaryLob:
+		c.dest = new(NullLob)
+	default:
+		c.dest = new(any)
+	}
+	return c
+}
+
+// reset prepares the scan destination for the next row.
+func (c *jsonColumn) reset() {
+	if lob, ok := c.dest.(*NullLob); ok {
+		c.buf.Reset()
+		lob.Lob = NewLob(nil, &c.buf)
+	}
+}
+
+// appendValue appends the json value of the scanned column value to b.
+func (c *jsonColumn) appendValue(b []byte, enc func(b []byte, v any) ([]byte, error)) ([]byte, error) {
+	switch dest := c.dest.(type) {
+	case *sql.NullBool:
+		if dest.Valid {
+			return strconv.AppendBool(b, dest.Bool), nil
+		}
+	case *sql.NullInt64:
+		if dest.Valid {
+			return strconv.AppendInt(b, dest.Int64, 10), nil
+		}
+	case *sql.NullFloat64:
+		if dest.Valid && !math.IsNaN(dest.Float64) && !math.IsInf(dest.Float64, 0) {
+			return strconv.AppendFloat(b, dest.Float64, 'g', -1, 64), nil
+		}
+	case *sql.NullTime:
+		if dest.Valid {
+			return enc(b, dest.Time.Format(time.RFC3339Nano))
+		}
+	case *sql.NullString:
+		if dest.Valid {
+			return enc(b, dest.String)
+		}
+	case *NullBytes:
+		if dest.Valid {
+			return enc(b, base64.StdEncoding.EncodeToString(dest.Bytes))
+		}
+	case *NullDecimal:
+		if dest.Valid {
+			r := (*big.Rat)(dest.Decimal)
+			return append(b, r.FloatString(decimalDigits(r.Denom()))...), nil
+		}
+	case *NullLob:
+		if dest.Valid {
+			if c.kind == jkBinaryLob {
+				return enc(b, base64.StdEncoding.EncodeToString(c.buf.Bytes()))
+			}
+			return enc(b, c.buf.String())
+		}
+	case *any:
+		if *dest != nil {
+			return enc(b, *dest)
+		}
+	}
+	return append(b, "null"...), nil
+}
+
+/*
+StreamJSON executes query with args via q (e.g. a sql.DB, sql.Conn or sql.Tx) and writes the rows of the resultset
+as newline delimited JSON (NDJSON) to w - one JSON object per row with the column names as keys.
+
+The JSON type of the values is derived from the column scan types: numbers and booleans are written as JSON numbers
+and booleans, decimals as exact JSON numbers, character data as strings, binary data as base64 encoded strings and
+timestamps as RFC 3339 strings. NULL values and floating point values not supported by JSON (NaN, Inf) are written
+as null. The rows are written incrementally while being fetched, so that the resultset is not buffered in memory.
+*/
+func StreamJSON(ctx context.Context, q Queryer, query string, w io.Writer, args ...any) error {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return err
+	}
+	columns := make([]*jsonColumn, len(columnTypes))
+	dest := make([]any, len(columnTypes))
+	for i, ct := range columnTypes {
+		columns[i] = newJSONColumn(ct.Name(), ct.DatabaseTypeName(), ct.ScanType())
+		dest[i] = columns[i].dest
+	}
+
+	encBuf := new(bytes.Buffer)
+	jsonEnc := json.NewEncoder(encBuf)
+	jsonEnc.SetEscapeHTML(false)
+	enc := func(b []byte, v any) ([]byte, error) {
+		encBuf.Reset()
+		if err := jsonEnc.Encode(v); err != nil {
+			return b, err
+		}
+		return append(b, bytes.TrimSuffix(encBuf.Bytes(), []byte{'\n'})...), nil
+	}
+
+	bw := bufio.NewWriter(w)
+	var line []byte
+	for rows.Next() {
+		for _, c := range columns {
+			c.reset()
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return err
+		}
+		line = append(line[:0], '{')
+		for i, c := range columns {
+			if i > 0 {
+				line = append(line, ',')
+			}
+			if line, err = enc(line, c.name); err != nil {
+				return err
+			}
+			line = append(line, ':')
+			if line, err = c.appendValue(line, enc); err != nil {
+				return err
+			}
+		}
+		line = append(line, '}', '\n')
+		if _, err := bw.Write(line); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
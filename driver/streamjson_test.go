@@ -0,0 +1,39 @@
+//go:build !unit
+
+package driver
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestStreamJSON(t *testing.T) {
+	t.Parallel()
+
+	query := `select
+	1 as "I",
+	cast(2.5 as double) as "F",
+	cast('1.50' as decimal(10,2)) as "DEC",
+	'a"b<c>' as "S",
+	cast(x'0102ff' as varbinary(3)) as "B",
+	to_timestamp('2024-01-02 03:04:05') as "T",
+	cast(null as integer) as "N"
+from dummy
+union all
+select
+	?, ?, ?, ?, ?, ?, ?
+from dummy`
+
+	const expected = `{"I":1,"F":2.5,"DEC":1.5,"S":"a\"b<c>","B":"AQL/","T":"2024-01-02T03:04:05Z","N":null}
+{"I":-7,"F":null,"DEC":-0.125,"S":"ä","B":null,"T":null,"N":42}
+`
+
+	buf := new(bytes.Buffer)
+	if err := StreamJSON(context.Background(), MT.DB(), query, buf, -7, nil, "-0.125", "ä", nil, nil, 42); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != expected {
+		t.Fatalf("json\n%s\nexpected\n%s", buf.String(), expected)
+	}
+}
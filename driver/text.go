@@ -0,0 +1,50 @@
+package driver
+
+import (
+	"database/sql"
+	"encoding"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// ScanText supports scanning string, binary and decimal database fields into a destination implementing encoding.TextUnmarshaler.
+// This enables using domain types (e.g. UUIDs or netip.Addr) for scanning without an own sql.Scanner implementation.
+// A NULL value leaves the destination unchanged.
+func ScanText(src any, dest encoding.TextUnmarshaler) error {
+	if dest == nil {
+		return fmt.Errorf("text scan error: parameter dest %T is nil", dest)
+	}
+	switch src := src.(type) {
+	case nil:
+		return nil
+	case string:
+		return dest.UnmarshalText([]byte(src))
+	case []byte:
+		return dest.UnmarshalText(src)
+	case *big.Rat: // decimal: exact decimal text
+		return dest.UnmarshalText([]byte(src.FloatString(decimalDigits(src.Denom()))))
+	default:
+		return fmt.Errorf("text scan error: unsupported source type %T", src)
+	}
+}
+
+// textScanner is a sql.Scanner for encoding.TextUnmarshaler destinations.
+type textScanner struct {
+	dest encoding.TextUnmarshaler
+}
+
+func (s textScanner) Scan(src any) error { return ScanText(src, s.dest) }
+
+// scanDest returns a scanner for dest in case dest does implement encoding.TextUnmarshaler
+// but not sql.Scanner, dest otherwise.
+func scanDest(dest any) any {
+	switch dest.(type) {
+	case sql.Scanner, *time.Time: // time.Time implements encoding.TextUnmarshaler but is scanned natively
+		return dest
+	}
+	if u, ok := dest.(encoding.TextUnmarshaler); ok {
+		return textScanner{dest: u}
+	}
+	return dest
+}
@@ -0,0 +1,106 @@
+package driver
+
+import (
+	"database/sql"
+	"fmt"
+	"math/big"
+	"net/netip"
+	"testing"
+	"time"
+)
+
+// testUUID is a domain type implementing encoding.TextUnmarshaler.
+type testUUID [4]byte
+
+func (u *testUUID) UnmarshalText(b []byte) error {
+	_, err := fmt.Sscanf(string(b), "%02x%02x-%02x%02x", &u[0], &u[1], &u[2], &u[3])
+	return err
+}
+
+func TestScanText(t *testing.T) {
+	expected := testUUID{0x01, 0x02, 0xfe, 0xff}
+
+	for _, src := range []any{"0102-feff", []byte("0102-feff")} {
+		var id testUUID
+		if err := scanDest(&id).(sql.Scanner).Scan(src); err != nil {
+			t.Fatal(err)
+		}
+		if id != expected {
+			t.Fatalf("id %v - expected %v", id, expected)
+		}
+	}
+
+	// NULL value
+	id := expected
+	if err := ScanText(nil, &id); err != nil || id != expected {
+		t.Fatalf("id %v error %v - expected unchanged id %v", id, err, expected)
+	}
+
+	// invalid source type
+	if err := ScanText(42, &id); err == nil {
+		t.Fatal("error expected")
+	}
+
+	// ip address
+	var addr netip.Addr
+	if err := ScanText([]byte("192.168.0.1"), &addr); err != nil {
+		t.Fatal(err)
+	}
+	if addr != netip.MustParseAddr("192.168.0.1") {
+		t.Fatalf("ip address %s - expected %s", addr, "192.168.0.1")
+	}
+	if err := ScanText("invalid", &addr); err == nil {
+		t.Fatal("error expected")
+	}
+
+	// natively scanned destinations
+	var tm time.Time
+	var s string
+	for _, dest := range []any{&tm, &s, &NullBytes{}} {
+		if scanDest(dest) != dest {
+			t.Fatalf("destination %T is not expected to be wrapped", dest)
+		}
+	}
+}
+
+// testFixedPoint is a custom fixed-point decimal type implementing encoding.TextUnmarshaler.
+type testFixedPoint string
+
+func (d *testFixedPoint) UnmarshalText(b []byte) error {
+	*d = testFixedPoint(b)
+	return nil
+}
+
+func TestScanDecimalText(t *testing.T) {
+	testData := []struct {
+		src  any
+		text string
+	}{
+		{big.NewRat(-3, 2), "-1.5"},
+		{big.NewRat(1, 1000), "0.001"},
+		{big.NewRat(42, 1), "42"},
+		{"-1.50", "-1.50"}, // see SetDecimalAsText
+	}
+	for _, d := range testData {
+		var fp testFixedPoint
+		if err := ScanText(d.src, &fp); err != nil {
+			t.Fatal(err)
+		}
+		if string(fp) != d.text {
+			t.Fatalf("decimal %v: text %s - expected %s", d.src, fp, d.text)
+		}
+	}
+
+	// Decimal scans decimal text as well.
+	var dec Decimal
+	if err := dec.Scan("-1.50"); err != nil {
+		t.Fatal(err)
+	}
+	if (*big.Rat)(&dec).Cmp(big.NewRat(-3, 2)) != 0 {
+		t.Fatalf("decimal %s - expected %s", (*big.Rat)(&dec), big.NewRat(-3, 2))
+	}
+	var nullDec NullDecimal
+	if err := nullDec.Scan([]byte("invalid")); err == nil || nullDec.Valid {
+		t.Fatalf("error %v valid %t - expected error and invalid value", err, nullDec.Valid)
+	}
+}
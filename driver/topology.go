@@ -0,0 +1,44 @@
+package driver
+
+import (
+	"fmt"
+
+	p "github.com/SAP/go-hdb/driver/internal/protocol"
+)
+
+// TopologyHost represents a database host of the system topology provided by the database on connect.
+//
+// In scale-out systems the topology contains all index server hosts together with the volume they are
+// owning, so that applications can route statements to the host owning the relevant data.
+//
+// The driver itself does not route statements to the host owning the data: the partition information of
+// statements is not decoded and a connection cannot switch its session per statement. Applications can use
+// the topology to open connectors to dedicated hosts instead.
+type TopologyHost struct {
+	Host             string
+	Port             int
+	VolumeID         int
+	IsPrimary        bool
+	IsCurrentSession bool // the host of the current session.
+	IsStandby        bool
+}
+
+func (h TopologyHost) String() string {
+	return fmt.Sprintf("Host: %s Port: %d VolumeID: %d primary: %t current session: %t standby: %t", h.Host, h.Port, h.VolumeID, h.IsPrimary, h.IsCurrentSession, h.IsStandby)
+}
+
+func newTopology(ti *p.TopologyInformation) []TopologyHost {
+	tiHosts := ti.Hosts()
+	hosts := make([]TopologyHost, len(tiHosts))
+	for i, h := range tiHosts {
+		hosts[i] = TopologyHost{
+			Host:             h.HostName,
+			Port:             h.Port,
+			VolumeID:         h.VolumeID,
+			IsPrimary:        h.IsPrimary,
+			IsCurrentSession: h.IsCurrentSession,
+			IsStandby:        h.IsStandby,
+		}
+	}
+	return hosts
+}
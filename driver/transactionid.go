@@ -0,0 +1,55 @@
+package driver
+
+import (
+	"context"
+	"database/sql/driver"
+	"io"
+)
+
+// transactionIDQuery selects the transaction id of the current transaction of the session.
+const transactionIDQuery = "select top 1 transaction_id from m_transactions where connection_id = current_connection and transaction_status = 'ACTIVE'"
+
+/*
+TransactionID implements the Conn interface.
+
+It returns the database transaction id of the transaction started by BeginTx and true,
+or 0 and false if the connection is not in a transaction or the id cannot be determined.
+
+As the transaction id is not part of the hdb protocol (transaction flags), it is determined by
+a lightweight query on the first call within a transaction and is cached until the transaction ends.
+*/
+func (c *conn) TransactionID() (int64, bool) {
+	if !c.inTx || c.isBad() {
+		return 0, false
+	}
+	if c.txID != 0 {
+		return c.txID, true
+	}
+	id, err := c.queryTransactionID(context.Background())
+	c.setLastError(err)
+	if err != nil || id == 0 {
+		return 0, false
+	}
+	c.txID = id
+	return id, true
+}
+
+func (c *conn) queryTransactionID(ctx context.Context) (int64, error) {
+	c.wg.Add(1)
+	defer c.wg.Done()
+
+	rows, err := c.queryDirect(ctx, transactionIDQuery, !c.inTx)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+	dest := make([]driver.Value, 1)
+	if err := rows.Next(dest); err != nil {
+		if err == io.EOF { // no active transaction found
+			err = nil
+		}
+		return 0, err
+	}
+	id, _ := dest[0].(int64)
+	return id, nil
+}
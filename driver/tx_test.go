@@ -4,6 +4,7 @@ package driver_test
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"testing"
 
@@ -109,6 +110,86 @@ func testTransactionRollback(t *testing.T, db *sql.DB) {
 	}
 }
 
+func testTransactionDDL(t *testing.T, db *sql.DB) {
+	testData := []struct {
+		policy driver.DDLPolicy
+		err    error
+	}{
+		{driver.DDLPolicyAllow, nil},
+		{driver.DDLPolicyWarn, nil},
+		{driver.DDLPolicyError, driver.ErrDDLInTransaction},
+	}
+
+	for _, r := range testData {
+		t.Run(r.policy.String(), func(t *testing.T) {
+			connector := driver.MT.NewConnector()
+			connector.SetDDLPolicy(r.policy)
+			db := sql.OpenDB(connector)
+			defer db.Close()
+
+			table := driver.RandomIdentifier("testTxDDL_")
+
+			tx, err := db.Begin()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer tx.Rollback() //nolint:errcheck
+
+			_, err = tx.Exec(fmt.Sprintf("create table %s (i tinyint)", table))
+			if !errors.Is(err, r.err) {
+				t.Fatalf("error %v - expected %v", err, r.err)
+			}
+
+			// check if table was created
+			var n int
+			if err := db.QueryRow("select count(*) from tables where schema_name = current_schema and table_name = ?", string(table)).Scan(&n); err != nil {
+				t.Fatal(err)
+			}
+			expected := 1
+			if r.err != nil {
+				expected = 0
+			}
+			if n != expected {
+				t.Fatalf("number of tables %d - expected %d", n, expected)
+			}
+		})
+	}
+}
+
+func testTransactionSerializationFailure(t *testing.T, db *sql.DB) {
+	table := driver.RandomIdentifier("testTxSerialization_")
+	if _, err := db.Exec(fmt.Sprintf("create table %s (i tinyint primary key, j tinyint)", table)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(fmt.Sprintf("insert into %s values (1, 1)", table)); err != nil {
+		t.Fatal(err)
+	}
+
+	tx1, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx1.Rollback() //nolint:errcheck
+
+	tx2, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx2.Rollback() //nolint:errcheck
+
+	if _, err := tx1.Exec(fmt.Sprintf("update %s set j = 2 where i = 1", table)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx2.Exec("set transaction lock wait timeout 100"); err != nil { // milliseconds
+		t.Fatal(err)
+	}
+	// row locked by tx1: tx2 gets rolled back by lock wait timeout
+	_, err = tx2.Exec(fmt.Sprintf("update %s set j = 3 where i = 1", table))
+	if !driver.IsSerializationFailure(err) {
+		t.Fatalf("error %v - expected serialization failure", err)
+	}
+}
+
 func TestTransaction(t *testing.T) {
 	tests := []struct {
 		name string
@@ -116,6 +197,8 @@ func TestTransaction(t *testing.T) {
 	}{
 		{"transactionCommit", testTransactionCommit},
 		{"transactionRollback", testTransactionRollback},
+		{"transactionDDL", testTransactionDDL},
+		{"transactionSerializationFailure", testTransactionSerializationFailure},
 	}
 
 	db := driver.MT.DB()
@@ -0,0 +1,30 @@
+package driver
+
+import (
+	"reflect"
+
+	p "github.com/SAP/go-hdb/driver/internal/protocol"
+)
+
+// TypeInfo describes a database type supported by the driver.
+type TypeInfo struct {
+	TypeCode  byte           // database type code
+	TypeName  string         // database type name (see sql.ColumnType.DatabaseTypeName)
+	BindTypes []reflect.Type // types accepted as parameter values (pointers and sql.Null variants are accepted as well)
+	ScanTypes []reflect.Type // scan types of not nullable and nullable fields (see sql.ColumnType.ScanType)
+}
+
+// SupportedTypes returns the database types which can be bound and scanned by this driver version.
+func SupportedTypes() []TypeInfo {
+	pTypeInfos := p.SupportedTypes()
+	typeInfos := make([]TypeInfo, len(pTypeInfos))
+	for i, ti := range pTypeInfos {
+		typeInfos[i] = TypeInfo{
+			TypeCode:  ti.TypeCode,
+			TypeName:  ti.TypeName,
+			BindTypes: ti.DataType.BindTypes(),
+			ScanTypes: []reflect.Type{ti.DataType.ScanType(false), ti.DataType.ScanType(true)},
+		}
+	}
+	return typeInfos
+}
@@ -0,0 +1,31 @@
+package driver
+
+import "testing"
+
+func TestSupportedTypes(t *testing.T) {
+	typeInfos := map[string]TypeInfo{}
+	for _, ti := range SupportedTypes() {
+		for _, typ := range ti.ScanTypes {
+			if typ == nil {
+				t.Fatalf("%s: scan type not registered", ti.TypeName)
+			}
+		}
+		typeInfos[ti.TypeName] = ti
+	}
+
+	coreTypes := []string{"BOOLEAN", "TINYINT", "SMALLINT", "INTEGER", "BIGINT", "REAL", "DOUBLE", "DECIMAL",
+		"VARCHAR", "NVARCHAR", "VARBINARY", "LONGDATE", "DAYDATE", "SECONDTIME", "BLOB", "CLOB", "NCLOB"}
+	for _, name := range coreTypes {
+		ti, ok := typeInfos[name]
+		if !ok {
+			t.Fatalf("missing core type %s", name)
+		}
+		if len(ti.BindTypes) == 0 {
+			t.Fatalf("%s: missing bind types", name)
+		}
+	}
+
+	if ti := typeInfos["DECIMAL"]; ti.ScanTypes[0] != decimalType {
+		t.Fatalf("DECIMAL: scan type %s - expected %s", ti.ScanTypes[0], decimalType)
+	}
+}
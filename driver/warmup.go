@@ -0,0 +1,142 @@
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync"
+)
+
+// warmUpConns holds the connections opened by the first connect of a connector (see SetWarmUpConns).
+type warmUpConns struct {
+	once  sync.Once
+	mu    sync.Mutex
+	conns []driver.Conn
+}
+
+// connect returns a retained warm-up connection or, if none is left, a connection opened by fn.
+// The first call opens n connections concurrently, returns one of them and retains the others.
+func (w *warmUpConns) connect(ctx context.Context, n int, fn func(ctx context.Context) (driver.Conn, error)) (driver.Conn, error) {
+	warmUp := false
+	w.once.Do(func() { warmUp = n > 1 })
+	if warmUp {
+		return w.open(ctx, n, fn)
+	}
+	for {
+		conn := w.pop()
+		if conn == nil {
+			return fn(ctx)
+		}
+		// validate like an idle pool connection.
+		if err := conn.(driver.SessionResetter).ResetSession(ctx); err == nil {
+			return conn, nil
+		}
+		conn.Close()
+	}
+}
+
+func (w *warmUpConns) open(ctx context.Context, n int, fn func(ctx context.Context) (driver.Conn, error)) (driver.Conn, error) {
+	conns := make([]driver.Conn, n)
+	errs := make([]error, n)
+
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func(i int) {
+			defer wg.Done()
+			conns[i], errs[i] = fn(ctx)
+		}(i)
+	}
+	wg.Wait()
+
+	var conn driver.Conn
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, c := range conns {
+		switch {
+		case c == nil:
+		case conn == nil:
+			conn = c
+		default:
+			w.conns = append(w.conns, c)
+		}
+	}
+	if conn == nil {
+		return nil, errs[0]
+	}
+	return conn, nil
+}
+
+func (w *warmUpConns) pop() driver.Conn {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.conns) == 0 {
+		return nil
+	}
+	conn := w.conns[len(w.conns)-1]
+	w.conns = w.conns[:len(w.conns)-1]
+	return conn
+}
+
+func (w *warmUpConns) close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	errs := make([]error, len(w.conns))
+	for i, conn := range w.conns {
+		errs[i] = conn.Close()
+	}
+	w.conns = nil
+	return errors.Join(errs...)
+}
+
+/*
+WarmUp opens n connections of db concurrently, validates each one by a ping and returns them to the connection pool,
+so that the latency of the connection handshake (prolog, authentication and session setup) is not added to the first
+database operations (e.g. at application startup or in anticipation of a load burst).
+
+The number of connections is limited to the maximum number of open connections of db (see sql.DB SetMaxOpenConns),
+as all connections are held until the last one is opened.
+As the connections are returned to the idle pool of db, the maximum number of idle connections (see
+sql.DB.SetMaxIdleConns, default 2) needs to be at least n, otherwise the exceeding connections are closed again.
+The connections are subject to the connection lifetime settings of db like any other pooled connection.
+
+Connections are opened respecting the deadline of ctx. In case any connection fails, the connections opened
+successfully are returned to the pool nevertheless and the errors are returned.
+
+For the validation of idle connections by the pool please see SetPingInterval.
+For opening the connections by the first connect of a connector please see SetWarmUpConns.
+*/
+func WarmUp(ctx context.Context, db *sql.DB, n int) error {
+	if maxOpen := db.Stats().MaxOpenConnections; maxOpen > 0 {
+		n = min(n, maxOpen)
+	}
+	if n <= 0 {
+		return nil
+	}
+	conns := make([]*sql.Conn, n)
+	errs := make([]error, n)
+
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func(i int) {
+			defer wg.Done()
+			conn, err := db.Conn(ctx) // held until all connections are opened, so that no connection is reused
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			conns[i] = conn
+			errs[i] = conn.PingContext(ctx)
+		}(i)
+	}
+	wg.Wait()
+
+	for _, conn := range conns {
+		if conn != nil {
+			conn.Close() // return to pool
+		}
+	}
+	return errors.Join(errs...)
+}
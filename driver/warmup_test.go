@@ -0,0 +1,80 @@
+//go:build !unit
+
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+)
+
+func TestWarmUp(t *testing.T) {
+	t.Parallel()
+
+	const numConn = 4
+
+	db := sql.OpenDB(MT.NewConnector())
+	defer db.Close()
+	db.SetMaxIdleConns(numConn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	if err := WarmUp(ctx, db, numConn); err != nil {
+		t.Fatal(err)
+	}
+	stats := db.Stats()
+	if stats.OpenConnections != numConn || stats.Idle != numConn {
+		t.Fatalf("open connections %d idle %d - expected %d", stats.OpenConnections, stats.Idle, numConn)
+	}
+
+	// warmed up connections are reused.
+	if err := db.PingContext(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if stats := db.Stats(); stats.OpenConnections != numConn {
+		t.Fatalf("open connections %d - expected %d", stats.OpenConnections, numConn)
+	}
+
+	// context deadline is respected.
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	if err := WarmUp(ctx, db, numConn+1); err == nil {
+		t.Fatal("context error expected")
+	}
+}
+
+func TestConnectorWarmUpConns(t *testing.T) {
+	t.Parallel()
+
+	const numConn = 4
+
+	connector := MT.NewConnector()
+	connector.SetWarmUpConns(numConn)
+	db := sql.OpenDB(connector)
+	defer db.Close()
+	db.SetMaxIdleConns(numConn)
+
+	// first connect opens the warm-up connections.
+	if err := db.Ping(); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(connector.warmUp.conns); n != numConn-1 {
+		t.Fatalf("retained connections %d - expected %d", n, numConn-1)
+	}
+
+	// retained connections are handed out by subsequent connects.
+	conns := make([]*sql.Conn, numConn)
+	for i := range conns {
+		conn, err := db.Conn(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+		conns[i] = conn
+	}
+	if n := len(connector.warmUp.conns); n != 0 {
+		t.Fatalf("retained connections %d - expected %d", n, 0)
+	}
+}
@@ -0,0 +1,92 @@
+package driver
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+type testWarmUpConn struct {
+	driver.Conn
+	resetErr error
+	closed   bool
+}
+
+func (c *testWarmUpConn) ResetSession(ctx context.Context) error { return c.resetErr }
+func (c *testWarmUpConn) Close() error                           { c.closed = true; return nil }
+
+func TestWarmUpConns(t *testing.T) {
+	const numConn = 4
+
+	var numOpen atomic.Int64
+	open := func(ctx context.Context) (driver.Conn, error) {
+		numOpen.Add(1)
+		return &testWarmUpConn{}, nil
+	}
+
+	w := &warmUpConns{}
+
+	// first connect opens all warm-up connections.
+	if _, err := w.connect(context.Background(), numConn, open); err != nil {
+		t.Fatal(err)
+	}
+	if n := numOpen.Load(); n != numConn {
+		t.Fatalf("opened connections %d - expected %d", n, numConn)
+	}
+	if n := len(w.conns); n != numConn-1 {
+		t.Fatalf("retained connections %d - expected %d", n, numConn-1)
+	}
+
+	// retained connections failing validation are closed.
+	invalid := w.conns[len(w.conns)-1].(*testWarmUpConn)
+	invalid.resetErr = driver.ErrBadConn
+
+	// subsequent connects hand out the valid retained connections.
+	for i := 0; i < numConn-2; i++ {
+		conn, err := w.connect(context.Background(), numConn, open)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if conn == invalid {
+			t.Fatal("invalid connection handed out")
+		}
+	}
+	if !invalid.closed {
+		t.Fatal("invalid connection not closed")
+	}
+	if n := numOpen.Load(); n != numConn {
+		t.Fatalf("opened connections %d - expected %d", n, numConn)
+	}
+
+	// retained connections used up: open new connection.
+	if _, err := w.connect(context.Background(), numConn, open); err != nil {
+		t.Fatal(err)
+	}
+	if n := numOpen.Load(); n != numConn+1 {
+		t.Fatalf("opened connections %d - expected %d", n, numConn+1)
+	}
+
+	t.Run("close", func(t *testing.T) {
+		w := &warmUpConns{}
+		if _, err := w.connect(context.Background(), 2, open); err != nil {
+			t.Fatal(err)
+		}
+		retained := w.conns[0].(*testWarmUpConn)
+		if err := w.close(); err != nil {
+			t.Fatal(err)
+		}
+		if !retained.closed || len(w.conns) != 0 {
+			t.Fatal("retained connection not closed")
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		testErr := errors.New("test error")
+		w := &warmUpConns{}
+		if _, err := w.connect(context.Background(), 2, func(ctx context.Context) (driver.Conn, error) { return nil, testErr }); !errors.Is(err, testErr) {
+			t.Fatalf("error %v - expected %v", err, testErr)
+		}
+	})
+}
@@ -0,0 +1,74 @@
+package driver
+
+import (
+	"sync"
+
+	p "github.com/SAP/go-hdb/driver/internal/protocol"
+)
+
+// warnings retains the most recent database warnings of a connection up to a maximum number.
+type warnings struct {
+	mu      sync.Mutex
+	max     int
+	errs    []DBError
+	dropped func(n int) // called with the number of dropped warnings
+}
+
+func newWarnings(max int, dropped func(n int)) *warnings {
+	return &warnings{max: max, dropped: dropped}
+}
+
+// add adds the warnings of a reply dropping the oldest ones exceeding the maximum number.
+func (w *warnings) add(hdbErrs []*p.HdbError) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if n := len(w.errs) + len(hdbErrs) - w.max; n > 0 {
+		w.dropped(n)
+		k := min(n, len(w.errs))
+		w.errs = append(w.errs[:0], w.errs[k:]...)
+		hdbErrs = hdbErrs[n-k:]
+	}
+	for _, hdbErr := range hdbErrs {
+		w.errs = append(w.errs, hdbErr)
+	}
+}
+
+// drain returns and removes the retained warnings (oldest first).
+func (w *warnings) drain() []DBError {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	errs := w.errs
+	w.errs = nil
+	return errs
+}
+
+// onWarnings returns the protocol warnings callback retaining the warnings in w (if retain is set) and calling fn
+// per warning (if set), nil if neither is requested.
+func onWarnings(w *warnings, retain bool, fn func(warning DBError)) func(hdbErrs []*p.HdbError) {
+	switch {
+	case !retain && fn == nil:
+		return nil
+	case fn == nil:
+		return w.add
+	}
+	return func(hdbErrs []*p.HdbError) {
+		if retain {
+			w.add(hdbErrs)
+		}
+		for _, hdbErr := range hdbErrs {
+			fn(hdbErr)
+		}
+	}
+}
+
+/*
+Warnings implements the Conn interface.
+
+Warnings returns and removes the database warnings retained by the connection (oldest first).
+Warnings of all replies are retained up to the maximum number of warnings set by SetMaxWarnings,
+including warnings of replies returning a result or an error. Replies containing warnings only
+do not return an error - the warnings are logged and retained.
+*/
+func (c *conn) Warnings() []DBError { return c.warnings.drain() }
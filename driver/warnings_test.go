@@ -0,0 +1,73 @@
+package driver
+
+import (
+	"testing"
+
+	p "github.com/SAP/go-hdb/driver/internal/protocol"
+)
+
+func TestWarnings(t *testing.T) {
+	const maxWarnings = 10
+
+	m := newMetrics(nil, statsCfg.TimeUnit, statsCfg.TimeUpperBounds, nil)
+	collector := newMetricsCollector(m)
+
+	w := newWarnings(maxWarnings, func(n int) { collector.msgCh <- counterMsg{idx: counterDroppedWarnings, v: uint64(n)} })
+
+	hdbErrs := make([]*p.HdbError, 25)
+	for i := range hdbErrs {
+		hdbErrs[i] = new(p.HdbError)
+	}
+	w.add(hdbErrs[:5])  // no warning dropped
+	w.add(hdbErrs[5:8]) // no warning dropped
+	w.add(hdbErrs[8:])  // 15 warnings dropped (more warnings than cap)
+
+	errs := w.drain()
+	collector.close()
+
+	if len(errs) != maxWarnings {
+		t.Fatalf("number of warnings %d - expected %d", len(errs), maxWarnings)
+	}
+	for i, err := range errs { // most recent warnings are retained
+		if err != hdbErrs[len(hdbErrs)-maxWarnings+i] {
+			t.Fatalf("warning %d is not the expected one", i)
+		}
+	}
+	if dropped := m.stats().DroppedWarnings; dropped != uint64(len(hdbErrs)-maxWarnings) {
+		t.Fatalf("dropped warnings %d - expected %d", dropped, len(hdbErrs)-maxWarnings)
+	}
+	if errs := w.drain(); len(errs) != 0 {
+		t.Fatalf("number of warnings %d after drain - expected %d", len(errs), 0)
+	}
+}
+
+func TestOnWarnings(t *testing.T) {
+	w := newWarnings(10, func(n int) {})
+
+	if onWarnings(w, false, nil) != nil {
+		t.Fatal("no warnings callback expected")
+	}
+
+	var called []DBError
+	fn := onWarnings(w, true, func(warning DBError) { called = append(called, warning) })
+
+	hdbErrs := []*p.HdbError{new(p.HdbError), new(p.HdbError)}
+	fn(hdbErrs)
+
+	if len(called) != len(hdbErrs) {
+		t.Fatalf("number of callback calls %d - expected %d", len(called), len(hdbErrs))
+	}
+	if errs := w.drain(); len(errs) != len(hdbErrs) {
+		t.Fatalf("number of retained warnings %d - expected %d", len(errs), len(hdbErrs))
+	}
+
+	called = nil
+	fn = onWarnings(w, false, func(warning DBError) { called = append(called, warning) })
+	fn(hdbErrs)
+	if len(called) != len(hdbErrs) {
+		t.Fatalf("number of callback calls %d - expected %d", len(called), len(hdbErrs))
+	}
+	if errs := w.drain(); len(errs) != 0 {
+		t.Fatalf("number of retained warnings %d - expected %d", len(errs), 0)
+	}
+}
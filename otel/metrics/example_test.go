@@ -0,0 +1,60 @@
+//go:build !unit
+
+package metrics_test
+
+import (
+	"log"
+	"os"
+
+	"github.com/SAP/go-hdb/driver"
+	"github.com/SAP/go-hdb/otel/metrics"
+	"go.opentelemetry.io/otel"
+)
+
+// Example demonstrates the usage of go-hdb OpenTelemetry metrics.
+func Example() {
+	const envDSN = "GOHDBDSN"
+
+	dsn := os.Getenv(envDSN)
+	// exit if dsn is missing.
+	if dsn == "" {
+		log.Printf("to start the go-hdb OpenTelemetry metrics example please set environment variable %s", envDSN)
+		return
+	}
+
+	connector, err := driver.NewDSNConnector(dsn)
+	if err != nil {
+		log.Panic(err)
+	}
+	// use driver.OpenDB instead of sql.OpenDB to collect driver.DB specific statistics.
+	db := driver.OpenDB(connector)
+	defer db.Close()
+
+	// use dbName as attribute.
+	const dbName = "myDatabase"
+
+	// use the global meter provider - usually an OpenTelemetry SDK meter provider
+	// with a configured exporter would be set via otel.SetMeterProvider.
+	meter := otel.Meter("github.com/SAP/go-hdb")
+
+	// register instruments for go-hdb driver stats.
+	driverReg, err := metrics.RegisterDriverStats(meter, connector.NativeDriver(), dbName)
+	if err != nil {
+		log.Panic(err)
+	}
+	defer driverReg.Unregister() //nolint:errcheck
+
+	// register instruments for extended go-hdb db stats.
+	dbReg, err := metrics.RegisterDBExStats(meter, db, dbName)
+	if err != nil {
+		log.Panic(err)
+	}
+	defer dbReg.Unregister() //nolint:errcheck
+
+	// do some database stuff...
+	if err := db.Ping(); err != nil {
+		log.Panic(err)
+	}
+
+	// output:
+}
@@ -0,0 +1,271 @@
+// Package metrics provides OpenTelemetry metric instruments for driver and extended database statistics.
+package metrics
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/SAP/go-hdb/driver"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const namespace = "go_hdb"
+
+/*
+The OpenTelemetry metric API does not provide asynchronous histogram instruments.
+Therefore the driver time histograms are exported as observable counters
+  - <name>_count: number of observations
+  - <name>_sum: sum of observed values
+  - <name>_bucket: cumulative bucket counts with upper bound attribute 'le'
+like in the prometheus exposition format.
+*/
+
+type histogram struct {
+	count  metric.Int64ObservableCounter
+	sum    metric.Float64ObservableCounter
+	bucket metric.Int64ObservableCounter
+}
+
+func newHistogram(meter metric.Meter, name, desc, unit string) (*histogram, error) {
+	count, err := meter.Int64ObservableCounter(name+"_count", metric.WithDescription(desc+" (count)"))
+	if err != nil {
+		return nil, err
+	}
+	sum, err := meter.Float64ObservableCounter(name+"_sum", metric.WithDescription(desc+" (sum)"), metric.WithUnit(unit))
+	if err != nil {
+		return nil, err
+	}
+	bucket, err := meter.Int64ObservableCounter(name+"_bucket", metric.WithDescription(desc+" (cumulative bucket counts)"))
+	if err != nil {
+		return nil, err
+	}
+	return &histogram{count: count, sum: sum, bucket: bucket}, nil
+}
+
+func (h *histogram) instruments() []metric.Observable {
+	return []metric.Observable{h.count, h.sum, h.bucket}
+}
+
+func (h *histogram) observe(o metric.Observer, sh *driver.StatsHistogram, attrs ...attribute.KeyValue) {
+	o.ObserveInt64(h.count, int64(sh.Count), metric.WithAttributes(attrs...))
+	o.ObserveFloat64(h.sum, sh.Sum, metric.WithAttributes(attrs...))
+	for upperBound, count := range sh.Buckets {
+		o.ObserveInt64(h.bucket, int64(count), metric.WithAttributes(append(attrs, attribute.String("le", strconv.FormatFloat(upperBound, 'g', -1, 64)))...))
+	}
+}
+
+type instruments struct {
+	fn func() *driver.Stats
+
+	attrs []attribute.KeyValue
+
+	openConnections          metric.Int64ObservableGauge
+	openTransactions         metric.Int64ObservableGauge
+	openStatements           metric.Int64ObservableGauge
+	readBytes                metric.Int64ObservableCounter
+	writtenBytes             metric.Int64ObservableCounter
+	lobReadBytes             metric.Int64ObservableCounter
+	lobWrittenBytes          metric.Int64ObservableCounter
+	uncompressedReadBytes    metric.Int64ObservableCounter
+	uncompressedWrittenBytes metric.Int64ObservableCounter
+	droppedWarnings          metric.Int64ObservableCounter
+	retries                  metric.Int64ObservableCounter
+	stmtCacheSize            metric.Int64ObservableGauge
+	stmtCacheHits            metric.Int64ObservableCounter
+	stmtCacheMisses          metric.Int64ObservableCounter
+	readTime                 *histogram
+	writeTime                *histogram
+	authTime                 *histogram
+	serverTime               *histogram
+	clientTime               *histogram
+	sqlTimes                 *histogram
+	sqlErrors                metric.Int64ObservableCounter
+	rowsPerQuery             *histogram
+}
+
+func register(meter metric.Meter, fn func() *driver.Stats, subsystem string, attrs []attribute.KeyValue) (metric.Registration, error) {
+	// determine time unit
+	stats := fn()
+	// name: namespace, subsystem, name
+	name := func(name string) string { return strings.Join([]string{namespace, subsystem, name}, "_") }
+
+	in := &instruments{fn: fn, attrs: attrs}
+	var err error
+
+	if in.openConnections, err = meter.Int64ObservableGauge(
+		name("open_connections"),
+		metric.WithDescription("The number of established "+subsystem+" connections."),
+	); err != nil {
+		return nil, err
+	}
+	if in.openTransactions, err = meter.Int64ObservableGauge(
+		name("open_transactions"),
+		metric.WithDescription("The number of open "+subsystem+" transactions."),
+	); err != nil {
+		return nil, err
+	}
+	if in.openStatements, err = meter.Int64ObservableGauge(
+		name("open_statements"),
+		metric.WithDescription("The number of open "+subsystem+" statements."),
+	); err != nil {
+		return nil, err
+	}
+	if in.readBytes, err = meter.Int64ObservableCounter(
+		name("bytes_read"),
+		metric.WithDescription("The total bytes read from the database connection of "+subsystem+" statements."),
+		metric.WithUnit("By"),
+	); err != nil {
+		return nil, err
+	}
+	if in.writtenBytes, err = meter.Int64ObservableCounter(
+		name("bytes_written"),
+		metric.WithDescription("The total bytes written to the database connection of "+subsystem+" statements."),
+		metric.WithUnit("By"),
+	); err != nil {
+		return nil, err
+	}
+	if in.lobReadBytes, err = meter.Int64ObservableCounter(
+		name("lob_bytes_read"),
+		metric.WithDescription("The total lob bytes read from the database connection of "+subsystem+" statements."),
+		metric.WithUnit("By"),
+	); err != nil {
+		return nil, err
+	}
+	if in.lobWrittenBytes, err = meter.Int64ObservableCounter(
+		name("lob_bytes_written"),
+		metric.WithDescription("The total lob bytes written to the database connection of "+subsystem+" statements."),
+		metric.WithUnit("By"),
+	); err != nil {
+		return nil, err
+	}
+	if in.uncompressedReadBytes, err = meter.Int64ObservableCounter(
+		name("uncompressed_bytes_read"),
+		metric.WithDescription("The total uncompressed bytes of messages read from the database connection of "+subsystem+"."),
+		metric.WithUnit("By"),
+	); err != nil {
+		return nil, err
+	}
+	if in.uncompressedWrittenBytes, err = meter.Int64ObservableCounter(
+		name("uncompressed_bytes_written"),
+		metric.WithDescription("The total uncompressed bytes of messages written to the database connection of "+subsystem+"."),
+		metric.WithUnit("By"),
+	); err != nil {
+		return nil, err
+	}
+	if in.droppedWarnings, err = meter.Int64ObservableCounter(
+		name("dropped_warnings"),
+		metric.WithDescription("The total number of dropped database warnings exceeding the maximum number of retained warnings of "+subsystem+"."),
+	); err != nil {
+		return nil, err
+	}
+	if in.retries, err = meter.Int64ObservableCounter(
+		name("retries"),
+		metric.WithDescription("The total number of retries of queries failing with transient database errors of "+subsystem+"."),
+	); err != nil {
+		return nil, err
+	}
+	if in.stmtCacheSize, err = meter.Int64ObservableGauge(
+		name("stmt_cache_size"),
+		metric.WithDescription("The number of prepared statements currently held by the connection statement caches of "+subsystem+"."),
+	); err != nil {
+		return nil, err
+	}
+	if in.stmtCacheHits, err = meter.Int64ObservableCounter(
+		name("stmt_cache_hits"),
+		metric.WithDescription("The total number of prepares served by the connection statement caches of "+subsystem+"."),
+	); err != nil {
+		return nil, err
+	}
+	if in.stmtCacheMisses, err = meter.Int64ObservableCounter(
+		name("stmt_cache_misses"),
+		metric.WithDescription("The total number of prepares not served by the connection statement caches of "+subsystem+"."),
+	); err != nil {
+		return nil, err
+	}
+	if in.readTime, err = newHistogram(meter, name("read_time"), "The time spent for reading from the database connection of "+subsystem+".", stats.TimeUnit); err != nil {
+		return nil, err
+	}
+	if in.writeTime, err = newHistogram(meter, name("write_time"), "The time spent for writing to the database connection of "+subsystem+".", stats.TimeUnit); err != nil {
+		return nil, err
+	}
+	if in.authTime, err = newHistogram(meter, name("auth_time"), "The time spent for client authentication of "+subsystem+".", stats.TimeUnit); err != nil {
+		return nil, err
+	}
+	if in.serverTime, err = newHistogram(meter, name("server_time"), "The time spent for waiting on the database server replies of "+subsystem+".", stats.TimeUnit); err != nil {
+		return nil, err
+	}
+	if in.clientTime, err = newHistogram(meter, name("client_time"), "The time spent for processing the database server replies of "+subsystem+".", stats.TimeUnit); err != nil {
+		return nil, err
+	}
+	if in.sqlTimes, err = newHistogram(meter, name("sql_time"), "The time spent for the different sql statements of "+subsystem+".", stats.TimeUnit); err != nil {
+		return nil, err
+	}
+
+	if in.sqlErrors, err = meter.Int64ObservableCounter(
+		name("sql_errors"),
+		metric.WithDescription("The total number of failed sql statements of "+subsystem+"."),
+	); err != nil {
+		return nil, err
+	}
+
+	if in.rowsPerQuery, err = newHistogram(meter, name("rows_per_query"), "The number of rows returned per query and fetch of "+subsystem+".", "{row}"); err != nil {
+		return nil, err
+	}
+
+	observables := []metric.Observable{in.openConnections, in.openTransactions, in.openStatements, in.readBytes, in.writtenBytes, in.lobReadBytes, in.lobWrittenBytes, in.uncompressedReadBytes, in.uncompressedWrittenBytes, in.droppedWarnings, in.retries, in.stmtCacheSize, in.stmtCacheHits, in.stmtCacheMisses, in.sqlErrors}
+	for _, h := range []*histogram{in.readTime, in.writeTime, in.authTime, in.serverTime, in.clientTime, in.sqlTimes, in.rowsPerQuery} {
+		observables = append(observables, h.instruments()...)
+	}
+	return meter.RegisterCallback(in.observe, observables...)
+}
+
+func (in *instruments) observe(ctx context.Context, o metric.Observer) error {
+	// do not collect if meter provider got shut down or collection was cancelled.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	stats := in.fn()
+	opt := metric.WithAttributes(in.attrs...)
+	o.ObserveInt64(in.openConnections, int64(stats.OpenConnections), opt)
+	o.ObserveInt64(in.openTransactions, int64(stats.OpenTransactions), opt)
+	o.ObserveInt64(in.openStatements, int64(stats.OpenStatements), opt)
+	o.ObserveInt64(in.readBytes, int64(stats.ReadBytes), opt)
+	o.ObserveInt64(in.writtenBytes, int64(stats.WrittenBytes), opt)
+	o.ObserveInt64(in.lobReadBytes, int64(stats.LobReadBytes), opt)
+	o.ObserveInt64(in.lobWrittenBytes, int64(stats.LobWrittenBytes), opt)
+	o.ObserveInt64(in.uncompressedReadBytes, int64(stats.UncompressedReadBytes), opt)
+	o.ObserveInt64(in.uncompressedWrittenBytes, int64(stats.UncompressedWrittenBytes), opt)
+	o.ObserveInt64(in.droppedWarnings, int64(stats.DroppedWarnings), opt)
+	o.ObserveInt64(in.retries, int64(stats.Retries), opt)
+	o.ObserveInt64(in.stmtCacheSize, int64(stats.StmtCacheSize), opt)
+	o.ObserveInt64(in.stmtCacheHits, int64(stats.StmtCacheHits), opt)
+	o.ObserveInt64(in.stmtCacheMisses, int64(stats.StmtCacheMisses), opt)
+	in.readTime.observe(o, stats.ReadTime, in.attrs...)
+	in.writeTime.observe(o, stats.WriteTime, in.attrs...)
+	in.authTime.observe(o, stats.AuthTime, in.attrs...)
+	in.serverTime.observe(o, stats.ServerTime, in.attrs...)
+	in.clientTime.observe(o, stats.ClientTime, in.attrs...)
+	for k, v := range stats.SQLTimes {
+		in.sqlTimes.observe(o, v, append(in.attrs, attribute.String("sql", k))...)
+	}
+	for k, v := range stats.SQLErrors {
+		o.ObserveInt64(in.sqlErrors, int64(v), metric.WithAttributes(append(in.attrs, attribute.String("sql", k))...))
+	}
+	in.rowsPerQuery.observe(o, stats.RowsPerQuery, in.attrs...)
+	return nil
+}
+
+// RegisterDriverStats registers instruments at meter that export *driver.Driver statistics.
+// Call Unregister on the returned registration to stop the export.
+func RegisterDriverStats(meter metric.Meter, d driver.Driver, dbName string) (metric.Registration, error) {
+	return register(meter, d.Stats, "driver", []attribute.KeyValue{attribute.String("db_name", dbName)})
+}
+
+// RegisterDBExStats registers instruments at meter that export extended *driver.DB statistics.
+// Call Unregister on the returned registration to stop the export.
+func RegisterDBExStats(meter metric.Meter, db *driver.DB, dbName string) (metric.Registration, error) {
+	return register(meter, db.ExStats, "db", []attribute.KeyValue{attribute.String("db_name", dbName)})
+}
@@ -0,0 +1,172 @@
+package metrics
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/SAP/go-hdb/driver"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+// test instruments are pointers, so that they can be identified by the test observer.
+type testInt64Counter struct {
+	noop.Int64ObservableCounter
+	name string
+}
+
+type testInt64Gauge struct {
+	noop.Int64ObservableGauge
+	name string
+}
+
+type testFloat64Counter struct {
+	noop.Float64ObservableCounter
+	name string
+}
+
+// testMeter records the registered instruments and callback.
+type testMeter struct {
+	noop.Meter
+	names       []string
+	observables []metric.Observable
+	callback    metric.Callback
+}
+
+func (m *testMeter) Int64ObservableCounter(name string, _ ...metric.Int64ObservableCounterOption) (metric.Int64ObservableCounter, error) {
+	m.names = append(m.names, name)
+	return &testInt64Counter{name: name}, nil
+}
+
+func (m *testMeter) Int64ObservableGauge(name string, _ ...metric.Int64ObservableGaugeOption) (metric.Int64ObservableGauge, error) {
+	m.names = append(m.names, name)
+	return &testInt64Gauge{name: name}, nil
+}
+
+func (m *testMeter) Float64ObservableCounter(name string, _ ...metric.Float64ObservableCounterOption) (metric.Float64ObservableCounter, error) {
+	m.names = append(m.names, name)
+	return &testFloat64Counter{name: name}, nil
+}
+
+func (m *testMeter) RegisterCallback(f metric.Callback, instruments ...metric.Observable) (metric.Registration, error) {
+	m.callback = f
+	m.observables = instruments
+	return noop.Registration{}, nil
+}
+
+// testObserver records the observed values by instrument name and attributes.
+type testObserver struct {
+	noop.Observer
+	values map[string]float64
+}
+
+func observeKey(name string, opts []metric.ObserveOption) string {
+	attrs := metric.NewObserveConfig(opts).Attributes()
+	return name + "{" + attrs.Encoded(attribute.DefaultEncoder()) + "}"
+}
+
+func (o *testObserver) ObserveInt64(obsrv metric.Int64Observable, value int64, opts ...metric.ObserveOption) {
+	var name string
+	switch obsrv := obsrv.(type) {
+	case *testInt64Counter:
+		name = obsrv.name
+	case *testInt64Gauge:
+		name = obsrv.name
+	}
+	o.values[observeKey(name, opts)] = float64(value)
+}
+
+func (o *testObserver) ObserveFloat64(obsrv metric.Float64Observable, value float64, opts ...metric.ObserveOption) {
+	o.values[observeKey(obsrv.(*testFloat64Counter).name, opts)] = value
+}
+
+func testStats() *driver.Stats {
+	emptyHistogram := func() *driver.StatsHistogram { return &driver.StatsHistogram{Buckets: map[float64]uint64{}} }
+	return &driver.Stats{
+		OpenConnections: 2,
+		ReadBytes:       100,
+		StmtCacheHits:   5,
+		SQLErrors:       map[string]uint64{"query": 3},
+		TimeUnit:        "ms",
+		ReadTime:        &driver.StatsHistogram{Count: 3, Sum: 12.5, Buckets: map[float64]uint64{1: 1, 10: 2}},
+		WriteTime:       emptyHistogram(),
+		AuthTime:        emptyHistogram(),
+		ServerTime:      emptyHistogram(),
+		ClientTime:      emptyHistogram(),
+		SQLTimes:        map[string]*driver.StatsHistogram{"exec": {Count: 1, Sum: 0.5, Buckets: map[float64]uint64{1: 1}}},
+		RowsPerQuery:    emptyHistogram(),
+	}
+}
+
+func TestRegister(t *testing.T) {
+	meter := &testMeter{}
+	if _, err := register(meter, testStats, "driver", []attribute.KeyValue{attribute.String("db_name", "db")}); err != nil {
+		t.Fatal(err)
+	}
+
+	// all instruments are registered at the callback with unique names.
+	if len(meter.observables) != len(meter.names) {
+		t.Fatalf("number of callback instruments %d - expected %d", len(meter.observables), len(meter.names))
+	}
+	names := slices.Clone(meter.names)
+	slices.Sort(names)
+	if len(slices.Compact(names)) != len(meter.names) {
+		t.Fatalf("instrument names not unique: %v", meter.names)
+	}
+	for _, name := range []string{
+		"go_hdb_driver_open_connections",
+		"go_hdb_driver_bytes_read",
+		"go_hdb_driver_stmt_cache_hits",
+		"go_hdb_driver_sql_errors",
+		"go_hdb_driver_read_time_count",
+		"go_hdb_driver_read_time_sum",
+		"go_hdb_driver_read_time_bucket",
+		"go_hdb_driver_rows_per_query_count",
+	} {
+		if !slices.Contains(meter.names, name) {
+			t.Fatalf("instrument %s not registered", name)
+		}
+	}
+
+	o := &testObserver{values: map[string]float64{}}
+	if err := meter.callback(context.Background(), o); err != nil {
+		t.Fatal(err)
+	}
+
+	testData := []struct {
+		key   string
+		value float64
+	}{
+		{"go_hdb_driver_open_connections{db_name=db}", 2},
+		{"go_hdb_driver_open_transactions{db_name=db}", 0},
+		{"go_hdb_driver_bytes_read{db_name=db}", 100},
+		{"go_hdb_driver_stmt_cache_hits{db_name=db}", 5},
+		{"go_hdb_driver_sql_errors{db_name=db,sql=query}", 3},
+		{"go_hdb_driver_read_time_count{db_name=db}", 3},
+		{"go_hdb_driver_read_time_sum{db_name=db}", 12.5},
+		{"go_hdb_driver_read_time_bucket{db_name=db,le=1}", 1},
+		{"go_hdb_driver_read_time_bucket{db_name=db,le=10}", 2},
+		{"go_hdb_driver_sql_time_count{db_name=db,sql=exec}", 1},
+		{"go_hdb_driver_sql_time_bucket{db_name=db,le=1,sql=exec}", 1},
+		{"go_hdb_driver_write_time_count{db_name=db}", 0},
+	}
+	for _, r := range testData {
+		v, ok := o.values[r.key]
+		if !ok {
+			t.Fatalf("value %s not observed", r.key)
+		}
+		if v != r.value {
+			t.Fatalf("value %s %f - expected %f", r.key, v, r.value)
+		}
+	}
+
+	// no collection on cancelled context.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	o = &testObserver{values: map[string]float64{}}
+	if err := meter.callback(ctx, o); err == nil || len(o.values) != 0 {
+		t.Fatalf("callback on cancelled context: error %v values %d - expected error and no values", err, len(o.values))
+	}
+}
@@ -14,15 +14,28 @@ const namespace = "go_hdb"
 type collector struct {
 	fn func() *driver.Stats
 
-	openConnections  *prometheus.Desc
-	openTransactions *prometheus.Desc
-	openStatements   *prometheus.Desc
-	readBytes        *prometheus.Desc
-	writtenBytes     *prometheus.Desc
-	readTime         *prometheus.Desc
-	writeTime        *prometheus.Desc
-	authTime         *prometheus.Desc
-	sqlTimes         *prometheus.Desc
+	openConnections          *prometheus.Desc
+	openTransactions         *prometheus.Desc
+	openStatements           *prometheus.Desc
+	readBytes                *prometheus.Desc
+	writtenBytes             *prometheus.Desc
+	lobReadBytes             *prometheus.Desc
+	lobWrittenBytes          *prometheus.Desc
+	uncompressedReadBytes    *prometheus.Desc
+	uncompressedWrittenBytes *prometheus.Desc
+	droppedWarnings          *prometheus.Desc
+	retries                  *prometheus.Desc
+	stmtCacheSize            *prometheus.Desc
+	stmtCacheHits            *prometheus.Desc
+	stmtCacheMisses          *prometheus.Desc
+	readTime                 *prometheus.Desc
+	writeTime                *prometheus.Desc
+	authTime                 *prometheus.Desc
+	serverTime               *prometheus.Desc
+	clientTime               *prometheus.Desc
+	sqlTimes                 *prometheus.Desc
+	sqlErrors                *prometheus.Desc
+	rowsPerQuery             *prometheus.Desc
 }
 
 func newCollector(fn func() *driver.Stats, subsystem string, labels prometheus.Labels) prometheus.Collector {
@@ -62,6 +75,60 @@ func newCollector(fn func() *driver.Stats, subsystem string, labels prometheus.L
 			nil,
 			labels,
 		),
+		lobReadBytes: prometheus.NewDesc(
+			fqName("lob_bytes_read"),
+			fmt.Sprintf("The total lob bytes read from the database connection of %s statements.", subsystem),
+			nil,
+			labels,
+		),
+		lobWrittenBytes: prometheus.NewDesc(
+			fqName("lob_bytes_written"),
+			fmt.Sprintf("The total lob bytes written to the database connection of %s statements.", subsystem),
+			nil,
+			labels,
+		),
+		uncompressedReadBytes: prometheus.NewDesc(
+			fqName("uncompressed_bytes_read"),
+			fmt.Sprintf("The total uncompressed bytes of messages read from the database connection of %s.", subsystem),
+			nil,
+			labels,
+		),
+		uncompressedWrittenBytes: prometheus.NewDesc(
+			fqName("uncompressed_bytes_written"),
+			fmt.Sprintf("The total uncompressed bytes of messages written to the database connection of %s.", subsystem),
+			nil,
+			labels,
+		),
+		droppedWarnings: prometheus.NewDesc(
+			fqName("dropped_warnings"),
+			fmt.Sprintf("The total number of dropped database warnings exceeding the maximum number of retained warnings of %s.", subsystem),
+			nil,
+			labels,
+		),
+		retries: prometheus.NewDesc(
+			fqName("retries"),
+			fmt.Sprintf("The total number of retries of queries failing with transient database errors of %s.", subsystem),
+			nil,
+			labels,
+		),
+		stmtCacheSize: prometheus.NewDesc(
+			fqName("stmt_cache_size"),
+			fmt.Sprintf("The number of prepared statements currently held by the connection statement caches of %s.", subsystem),
+			nil,
+			labels,
+		),
+		stmtCacheHits: prometheus.NewDesc(
+			fqName("stmt_cache_hits"),
+			fmt.Sprintf("The total number of prepares served by the connection statement caches of %s.", subsystem),
+			nil,
+			labels,
+		),
+		stmtCacheMisses: prometheus.NewDesc(
+			fqName("stmt_cache_misses"),
+			fmt.Sprintf("The total number of prepares not served by the connection statement caches of %s.", subsystem),
+			nil,
+			labels,
+		),
 		readTime: prometheus.NewDesc(
 			fqName("read_time"),
 			fmt.Sprintf("The time spent measured in %s for reading from the database connection of %s.", stats.TimeUnit, subsystem),
@@ -80,12 +147,36 @@ func newCollector(fn func() *driver.Stats, subsystem string, labels prometheus.L
 			nil,
 			labels,
 		),
+		serverTime: prometheus.NewDesc(
+			fqName("server_time"),
+			fmt.Sprintf("The time spent measured in %s for waiting on the database server replies of %s.", stats.TimeUnit, subsystem),
+			nil,
+			labels,
+		),
+		clientTime: prometheus.NewDesc(
+			fqName("client_time"),
+			fmt.Sprintf("The time spent measured in %s for processing the database server replies of %s.", stats.TimeUnit, subsystem),
+			nil,
+			labels,
+		),
 		sqlTimes: prometheus.NewDesc(
 			fqName("sql_time"),
 			fmt.Sprintf("The spent time measured in %s for the different sql statements of %s.", stats.TimeUnit, subsystem),
 			[]string{"sql"},
 			labels,
 		),
+		sqlErrors: prometheus.NewDesc(
+			fqName("sql_errors"),
+			fmt.Sprintf("The total number of failed sql statements of %s.", subsystem),
+			[]string{"sql"},
+			labels,
+		),
+		rowsPerQuery: prometheus.NewDesc(
+			fqName("rows_per_query"),
+			fmt.Sprintf("The number of rows returned per query and fetch of %s.", subsystem),
+			nil,
+			labels,
+		),
 	}
 }
 
@@ -96,10 +187,23 @@ func (c *collector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.openStatements
 	ch <- c.readBytes
 	ch <- c.writtenBytes
+	ch <- c.lobReadBytes
+	ch <- c.lobWrittenBytes
+	ch <- c.uncompressedReadBytes
+	ch <- c.uncompressedWrittenBytes
+	ch <- c.droppedWarnings
+	ch <- c.retries
+	ch <- c.stmtCacheSize
+	ch <- c.stmtCacheHits
+	ch <- c.stmtCacheMisses
 	ch <- c.readTime
 	ch <- c.writeTime
 	ch <- c.authTime
+	ch <- c.serverTime
+	ch <- c.clientTime
 	ch <- c.sqlTimes
+	ch <- c.sqlErrors
+	ch <- c.rowsPerQuery
 }
 
 // Collect implements Collector.
@@ -110,12 +214,27 @@ func (c *collector) Collect(ch chan<- prometheus.Metric) {
 	ch <- prometheus.MustNewConstMetric(c.openStatements, prometheus.GaugeValue, float64(stats.OpenStatements))
 	ch <- prometheus.MustNewConstMetric(c.readBytes, prometheus.CounterValue, float64(stats.ReadBytes))
 	ch <- prometheus.MustNewConstMetric(c.writtenBytes, prometheus.CounterValue, float64(stats.WrittenBytes))
+	ch <- prometheus.MustNewConstMetric(c.lobReadBytes, prometheus.CounterValue, float64(stats.LobReadBytes))
+	ch <- prometheus.MustNewConstMetric(c.lobWrittenBytes, prometheus.CounterValue, float64(stats.LobWrittenBytes))
+	ch <- prometheus.MustNewConstMetric(c.uncompressedReadBytes, prometheus.CounterValue, float64(stats.UncompressedReadBytes))
+	ch <- prometheus.MustNewConstMetric(c.uncompressedWrittenBytes, prometheus.CounterValue, float64(stats.UncompressedWrittenBytes))
+	ch <- prometheus.MustNewConstMetric(c.droppedWarnings, prometheus.CounterValue, float64(stats.DroppedWarnings))
+	ch <- prometheus.MustNewConstMetric(c.retries, prometheus.CounterValue, float64(stats.Retries))
+	ch <- prometheus.MustNewConstMetric(c.stmtCacheSize, prometheus.GaugeValue, float64(stats.StmtCacheSize))
+	ch <- prometheus.MustNewConstMetric(c.stmtCacheHits, prometheus.CounterValue, float64(stats.StmtCacheHits))
+	ch <- prometheus.MustNewConstMetric(c.stmtCacheMisses, prometheus.CounterValue, float64(stats.StmtCacheMisses))
 	ch <- prometheus.MustNewConstHistogram(c.readTime, stats.ReadTime.Count, stats.ReadTime.Sum, stats.ReadTime.Buckets)
 	ch <- prometheus.MustNewConstHistogram(c.writeTime, stats.WriteTime.Count, stats.WriteTime.Sum, stats.WriteTime.Buckets)
 	ch <- prometheus.MustNewConstHistogram(c.authTime, stats.AuthTime.Count, stats.AuthTime.Sum, stats.AuthTime.Buckets)
+	ch <- prometheus.MustNewConstHistogram(c.serverTime, stats.ServerTime.Count, stats.ServerTime.Sum, stats.ServerTime.Buckets)
+	ch <- prometheus.MustNewConstHistogram(c.clientTime, stats.ClientTime.Count, stats.ClientTime.Sum, stats.ClientTime.Buckets)
 	for k, v := range stats.SQLTimes {
 		ch <- prometheus.MustNewConstHistogram(c.sqlTimes, v.Count, v.Sum, v.Buckets, k)
 	}
+	for k, v := range stats.SQLErrors {
+		ch <- prometheus.MustNewConstMetric(c.sqlErrors, prometheus.CounterValue, float64(v), k)
+	}
+	ch <- prometheus.MustNewConstHistogram(c.rowsPerQuery, stats.RowsPerQuery.Count, stats.RowsPerQuery.Sum, stats.RowsPerQuery.Buckets)
 }
 
 // NewDriverStatsCollector returns a collector that exports *driver.Driver statistics.